@@ -0,0 +1,1200 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: api/atlas/v1/atlas.proto
+
+// Typed access to the atlas for other services, served next to the REST api by cmd/server.
+// Regenerate the Go code from the backend directory with
+//   protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative api/atlas/v1/atlas.proto
+
+package atlasv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetPokemonRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPokemonRequest) Reset() {
+	*x = GetPokemonRequest{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPokemonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPokemonRequest) ProtoMessage() {}
+
+func (x *GetPokemonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPokemonRequest.ProtoReflect.Descriptor instead.
+func (*GetPokemonRequest) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPokemonRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListPokemonsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offset int32                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// 1 to 100, defaults to 20
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPokemonsRequest) Reset() {
+	*x = ListPokemonsRequest{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPokemonsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPokemonsRequest) ProtoMessage() {}
+
+func (x *ListPokemonsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPokemonsRequest.ProtoReflect.Descriptor instead.
+func (*ListPokemonsRequest) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListPokemonsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListPokemonsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListPokemonsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pokemons      []*Pokemon             `protobuf:"bytes,1,rep,name=pokemons,proto3" json:"pokemons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPokemonsResponse) Reset() {
+	*x = ListPokemonsResponse{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPokemonsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPokemonsResponse) ProtoMessage() {}
+
+func (x *ListPokemonsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPokemonsResponse.ProtoReflect.Descriptor instead.
+func (*ListPokemonsResponse) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPokemonsResponse) GetPokemons() []*Pokemon {
+	if x != nil {
+		return x.Pokemons
+	}
+	return nil
+}
+
+type StreamPokemonsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offset int32                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Up to 2000, defaults to every pokemon after the offset
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPokemonsRequest) Reset() {
+	*x = StreamPokemonsRequest{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPokemonsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPokemonsRequest) ProtoMessage() {}
+
+func (x *StreamPokemonsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPokemonsRequest.ProtoReflect.Descriptor instead.
+func (*StreamPokemonsRequest) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamPokemonsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *StreamPokemonsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetPokemonDetailedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPokemonDetailedRequest) Reset() {
+	*x = GetPokemonDetailedRequest{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPokemonDetailedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPokemonDetailedRequest) ProtoMessage() {}
+
+func (x *GetPokemonDetailedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPokemonDetailedRequest.ProtoReflect.Descriptor instead.
+func (*GetPokemonDetailedRequest) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPokemonDetailedRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type SearchLocationsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Search string                 `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	// 1 to 100, defaults to 20
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchLocationsRequest) Reset() {
+	*x = SearchLocationsRequest{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchLocationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchLocationsRequest) ProtoMessage() {}
+
+func (x *SearchLocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchLocationsRequest.ProtoReflect.Descriptor instead.
+func (*SearchLocationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchLocationsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *SearchLocationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchLocationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locations     []*Location            `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchLocationsResponse) Reset() {
+	*x = SearchLocationsResponse{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchLocationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchLocationsResponse) ProtoMessage() {}
+
+func (x *SearchLocationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchLocationsResponse.ProtoReflect.Descriptor instead.
+func (*SearchLocationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchLocationsResponse) GetLocations() []*Location {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type Pokemon struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Weight        int32                  `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	SpriteUrl     string                 `protobuf:"bytes,5,opt,name=sprite_url,json=spriteUrl,proto3" json:"sprite_url,omitempty"`
+	Types         []string               `protobuf:"bytes,6,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pokemon) Reset() {
+	*x = Pokemon{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pokemon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pokemon) ProtoMessage() {}
+
+func (x *Pokemon) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pokemon.ProtoReflect.Descriptor instead.
+func (*Pokemon) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Pokemon) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Pokemon) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pokemon) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Pokemon) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Pokemon) GetSpriteUrl() string {
+	if x != nil {
+		return x.SpriteUrl
+	}
+	return ""
+}
+
+func (x *Pokemon) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type PokemonDetails struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Weight     int32                  `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	Height     int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	SpriteUrl  string                 `protobuf:"bytes,5,opt,name=sprite_url,json=spriteUrl,proto3" json:"sprite_url,omitempty"`
+	Types      []string               `protobuf:"bytes,6,rep,name=types,proto3" json:"types,omitempty"`
+	Stats      []*Stat                `protobuf:"bytes,7,rep,name=stats,proto3" json:"stats,omitempty"`
+	Evolutions []*Evolution           `protobuf:"bytes,8,rep,name=evolutions,proto3" json:"evolutions,omitempty"`
+	HeldItems  []*HeldItem            `protobuf:"bytes,9,rep,name=held_items,json=heldItems,proto3" json:"held_items,omitempty"`
+	// Every variety and form of the pokemon's species
+	Forms         []*Form `protobuf:"bytes,10,rep,name=forms,proto3" json:"forms,omitempty"`
+	Cries         *Cries  `protobuf:"bytes,11,opt,name=cries,proto3" json:"cries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PokemonDetails) Reset() {
+	*x = PokemonDetails{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PokemonDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PokemonDetails) ProtoMessage() {}
+
+func (x *PokemonDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PokemonDetails.ProtoReflect.Descriptor instead.
+func (*PokemonDetails) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PokemonDetails) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PokemonDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PokemonDetails) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *PokemonDetails) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *PokemonDetails) GetSpriteUrl() string {
+	if x != nil {
+		return x.SpriteUrl
+	}
+	return ""
+}
+
+func (x *PokemonDetails) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *PokemonDetails) GetStats() []*Stat {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *PokemonDetails) GetEvolutions() []*Evolution {
+	if x != nil {
+		return x.Evolutions
+	}
+	return nil
+}
+
+func (x *PokemonDetails) GetHeldItems() []*HeldItem {
+	if x != nil {
+		return x.HeldItems
+	}
+	return nil
+}
+
+func (x *PokemonDetails) GetForms() []*Form {
+	if x != nil {
+		return x.Forms
+	}
+	return nil
+}
+
+func (x *PokemonDetails) GetCries() *Cries {
+	if x != nil {
+		return x.Cries
+	}
+	return nil
+}
+
+type Stat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BaseStat      int32                  `protobuf:"varint,2,opt,name=base_stat,json=baseStat,proto3" json:"base_stat,omitempty"`
+	Effort        int32                  `protobuf:"varint,3,opt,name=effort,proto3" json:"effort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stat) Reset() {
+	*x = Stat{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stat) ProtoMessage() {}
+
+func (x *Stat) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stat.ProtoReflect.Descriptor instead.
+func (*Stat) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Stat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Stat) GetBaseStat() int32 {
+	if x != nil {
+		return x.BaseStat
+	}
+	return 0
+}
+
+func (x *Stat) GetEffort() int32 {
+	if x != nil {
+		return x.Effort
+	}
+	return 0
+}
+
+// One step of the evolution chain of the pokemon's species
+type Evolution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PokemonId     int32                  `protobuf:"varint,1,opt,name=pokemon_id,json=pokemonId,proto3" json:"pokemon_id,omitempty"`
+	PokemonName   string                 `protobuf:"bytes,2,opt,name=pokemon_name,json=pokemonName,proto3" json:"pokemon_name,omitempty"`
+	EvolvesToId   int32                  `protobuf:"varint,3,opt,name=evolves_to_id,json=evolvesToId,proto3" json:"evolves_to_id,omitempty"`
+	EvolvesToName string                 `protobuf:"bytes,4,opt,name=evolves_to_name,json=evolvesToName,proto3" json:"evolves_to_name,omitempty"`
+	// e.g. "Level up holding Razor Fang at night"
+	Summary       string `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Evolution) Reset() {
+	*x = Evolution{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Evolution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Evolution) ProtoMessage() {}
+
+func (x *Evolution) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Evolution.ProtoReflect.Descriptor instead.
+func (*Evolution) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Evolution) GetPokemonId() int32 {
+	if x != nil {
+		return x.PokemonId
+	}
+	return 0
+}
+
+func (x *Evolution) GetPokemonName() string {
+	if x != nil {
+		return x.PokemonName
+	}
+	return ""
+}
+
+func (x *Evolution) GetEvolvesToId() int32 {
+	if x != nil {
+		return x.EvolvesToId
+	}
+	return 0
+}
+
+func (x *Evolution) GetEvolvesToName() string {
+	if x != nil {
+		return x.EvolvesToName
+	}
+	return ""
+}
+
+func (x *Evolution) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+// Item the pokemon may hold when encountered in the wild
+type HeldItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          string                 `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	SpriteUrl     string                 `protobuf:"bytes,2,opt,name=sprite_url,json=spriteUrl,proto3" json:"sprite_url,omitempty"`
+	Versions      []*HeldItemVersion     `protobuf:"bytes,3,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeldItem) Reset() {
+	*x = HeldItem{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeldItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeldItem) ProtoMessage() {}
+
+func (x *HeldItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeldItem.ProtoReflect.Descriptor instead.
+func (*HeldItem) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HeldItem) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *HeldItem) GetSpriteUrl() string {
+	if x != nil {
+		return x.SpriteUrl
+	}
+	return ""
+}
+
+func (x *HeldItem) GetVersions() []*HeldItemVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type HeldItemVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Rarity        int32                  `protobuf:"varint,2,opt,name=rarity,proto3" json:"rarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeldItemVersion) Reset() {
+	*x = HeldItemVersion{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeldItemVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeldItemVersion) ProtoMessage() {}
+
+func (x *HeldItemVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeldItemVersion.ProtoReflect.Descriptor instead.
+func (*HeldItemVersion) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HeldItemVersion) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HeldItemVersion) GetRarity() int32 {
+	if x != nil {
+		return x.Rarity
+	}
+	return 0
+}
+
+type Form struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	FormId    int32                  `protobuf:"varint,1,opt,name=form_id,json=formId,proto3" json:"form_id,omitempty"`
+	PokemonId int32                  `protobuf:"varint,2,opt,name=pokemon_id,json=pokemonId,proto3" json:"pokemon_id,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	FormName  string                 `protobuf:"bytes,4,opt,name=form_name,json=formName,proto3" json:"form_name,omitempty"`
+	// default, regional, mega, gigantamax, battle, alternate or cosmetic
+	Category      string `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	IsDefault     bool   `protobuf:"varint,6,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	SpriteUrl     string `protobuf:"bytes,7,opt,name=sprite_url,json=spriteUrl,proto3" json:"sprite_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Form) Reset() {
+	*x = Form{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Form) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Form) ProtoMessage() {}
+
+func (x *Form) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Form.ProtoReflect.Descriptor instead.
+func (*Form) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Form) GetFormId() int32 {
+	if x != nil {
+		return x.FormId
+	}
+	return 0
+}
+
+func (x *Form) GetPokemonId() int32 {
+	if x != nil {
+		return x.PokemonId
+	}
+	return 0
+}
+
+func (x *Form) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Form) GetFormName() string {
+	if x != nil {
+		return x.FormName
+	}
+	return ""
+}
+
+func (x *Form) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Form) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *Form) GetSpriteUrl() string {
+	if x != nil {
+		return x.SpriteUrl
+	}
+	return ""
+}
+
+// Paths of the cries on the REST api, empty for variants the pokemon doesn't have
+type Cries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latest        string                 `protobuf:"bytes,1,opt,name=latest,proto3" json:"latest,omitempty"`
+	Legacy        string                 `protobuf:"bytes,2,opt,name=legacy,proto3" json:"legacy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cries) Reset() {
+	*x = Cries{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cries) ProtoMessage() {}
+
+func (x *Cries) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cries.ProtoReflect.Descriptor instead.
+func (*Cries) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Cries) GetLatest() string {
+	if x != nil {
+		return x.Latest
+	}
+	return ""
+}
+
+func (x *Cries) GetLegacy() string {
+	if x != nil {
+		return x.Legacy
+	}
+	return ""
+}
+
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_api_atlas_v1_atlas_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_api_atlas_v1_atlas_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Location) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Location) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+var File_api_atlas_v1_atlas_proto protoreflect.FileDescriptor
+
+const file_api_atlas_v1_atlas_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/atlas/v1/atlas.proto\x12\batlas.v1\"'\n" +
+	"\x11GetPokemonRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"C\n" +
+	"\x13ListPokemonsRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"E\n" +
+	"\x14ListPokemonsResponse\x12-\n" +
+	"\bpokemons\x18\x01 \x03(\v2\x11.atlas.v1.PokemonR\bpokemons\"E\n" +
+	"\x15StreamPokemonsRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"+\n" +
+	"\x19GetPokemonDetailedRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"F\n" +
+	"\x16SearchLocationsRequest\x12\x16\n" +
+	"\x06search\x18\x01 \x01(\tR\x06search\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"K\n" +
+	"\x17SearchLocationsResponse\x120\n" +
+	"\tlocations\x18\x01 \x03(\v2\x12.atlas.v1.LocationR\tlocations\"\x92\x01\n" +
+	"\aPokemon\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\x12\x1d\n" +
+	"\n" +
+	"sprite_url\x18\x05 \x01(\tR\tspriteUrl\x12\x14\n" +
+	"\x05types\x18\x06 \x03(\tR\x05types\"\xf4\x02\n" +
+	"\x0ePokemonDetails\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\x12\x1d\n" +
+	"\n" +
+	"sprite_url\x18\x05 \x01(\tR\tspriteUrl\x12\x14\n" +
+	"\x05types\x18\x06 \x03(\tR\x05types\x12$\n" +
+	"\x05stats\x18\a \x03(\v2\x0e.atlas.v1.StatR\x05stats\x123\n" +
+	"\n" +
+	"evolutions\x18\b \x03(\v2\x13.atlas.v1.EvolutionR\n" +
+	"evolutions\x121\n" +
+	"\n" +
+	"held_items\x18\t \x03(\v2\x12.atlas.v1.HeldItemR\theldItems\x12$\n" +
+	"\x05forms\x18\n" +
+	" \x03(\v2\x0e.atlas.v1.FormR\x05forms\x12%\n" +
+	"\x05cries\x18\v \x01(\v2\x0f.atlas.v1.CriesR\x05cries\"O\n" +
+	"\x04Stat\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tbase_stat\x18\x02 \x01(\x05R\bbaseStat\x12\x16\n" +
+	"\x06effort\x18\x03 \x01(\x05R\x06effort\"\xb3\x01\n" +
+	"\tEvolution\x12\x1d\n" +
+	"\n" +
+	"pokemon_id\x18\x01 \x01(\x05R\tpokemonId\x12!\n" +
+	"\fpokemon_name\x18\x02 \x01(\tR\vpokemonName\x12\"\n" +
+	"\revolves_to_id\x18\x03 \x01(\x05R\vevolvesToId\x12&\n" +
+	"\x0fevolves_to_name\x18\x04 \x01(\tR\revolvesToName\x12\x18\n" +
+	"\asummary\x18\x05 \x01(\tR\asummary\"t\n" +
+	"\bHeldItem\x12\x12\n" +
+	"\x04item\x18\x01 \x01(\tR\x04item\x12\x1d\n" +
+	"\n" +
+	"sprite_url\x18\x02 \x01(\tR\tspriteUrl\x125\n" +
+	"\bversions\x18\x03 \x03(\v2\x19.atlas.v1.HeldItemVersionR\bversions\"C\n" +
+	"\x0fHeldItemVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06rarity\x18\x02 \x01(\x05R\x06rarity\"\xc9\x01\n" +
+	"\x04Form\x12\x17\n" +
+	"\aform_id\x18\x01 \x01(\x05R\x06formId\x12\x1d\n" +
+	"\n" +
+	"pokemon_id\x18\x02 \x01(\x05R\tpokemonId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
+	"\tform_name\x18\x04 \x01(\tR\bformName\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x06 \x01(\bR\tisDefault\x12\x1d\n" +
+	"\n" +
+	"sprite_url\x18\a \x01(\tR\tspriteUrl\"7\n" +
+	"\x05Cries\x12\x16\n" +
+	"\x06latest\x18\x01 \x01(\tR\x06latest\x12\x16\n" +
+	"\x06legacy\x18\x02 \x01(\tR\x06legacy\"F\n" +
+	"\bLocation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region2\x90\x03\n" +
+	"\fAtlasService\x12<\n" +
+	"\n" +
+	"GetPokemon\x12\x1b.atlas.v1.GetPokemonRequest\x1a\x11.atlas.v1.Pokemon\x12M\n" +
+	"\fListPokemons\x12\x1d.atlas.v1.ListPokemonsRequest\x1a\x1e.atlas.v1.ListPokemonsResponse\x12F\n" +
+	"\x0eStreamPokemons\x12\x1f.atlas.v1.StreamPokemonsRequest\x1a\x11.atlas.v1.Pokemon0\x01\x12S\n" +
+	"\x12GetPokemonDetailed\x12#.atlas.v1.GetPokemonDetailedRequest\x1a\x18.atlas.v1.PokemonDetails\x12V\n" +
+	"\x0fSearchLocations\x12 .atlas.v1.SearchLocationsRequest\x1a!.atlas.v1.SearchLocationsResponseB-Z+poke-atlas/web-service/api/atlas/v1;atlasv1b\x06proto3"
+
+var (
+	file_api_atlas_v1_atlas_proto_rawDescOnce sync.Once
+	file_api_atlas_v1_atlas_proto_rawDescData []byte
+)
+
+func file_api_atlas_v1_atlas_proto_rawDescGZIP() []byte {
+	file_api_atlas_v1_atlas_proto_rawDescOnce.Do(func() {
+		file_api_atlas_v1_atlas_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_atlas_v1_atlas_proto_rawDesc), len(file_api_atlas_v1_atlas_proto_rawDesc)))
+	})
+	return file_api_atlas_v1_atlas_proto_rawDescData
+}
+
+var file_api_atlas_v1_atlas_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_api_atlas_v1_atlas_proto_goTypes = []any{
+	(*GetPokemonRequest)(nil),         // 0: atlas.v1.GetPokemonRequest
+	(*ListPokemonsRequest)(nil),       // 1: atlas.v1.ListPokemonsRequest
+	(*ListPokemonsResponse)(nil),      // 2: atlas.v1.ListPokemonsResponse
+	(*StreamPokemonsRequest)(nil),     // 3: atlas.v1.StreamPokemonsRequest
+	(*GetPokemonDetailedRequest)(nil), // 4: atlas.v1.GetPokemonDetailedRequest
+	(*SearchLocationsRequest)(nil),    // 5: atlas.v1.SearchLocationsRequest
+	(*SearchLocationsResponse)(nil),   // 6: atlas.v1.SearchLocationsResponse
+	(*Pokemon)(nil),                   // 7: atlas.v1.Pokemon
+	(*PokemonDetails)(nil),            // 8: atlas.v1.PokemonDetails
+	(*Stat)(nil),                      // 9: atlas.v1.Stat
+	(*Evolution)(nil),                 // 10: atlas.v1.Evolution
+	(*HeldItem)(nil),                  // 11: atlas.v1.HeldItem
+	(*HeldItemVersion)(nil),           // 12: atlas.v1.HeldItemVersion
+	(*Form)(nil),                      // 13: atlas.v1.Form
+	(*Cries)(nil),                     // 14: atlas.v1.Cries
+	(*Location)(nil),                  // 15: atlas.v1.Location
+}
+var file_api_atlas_v1_atlas_proto_depIdxs = []int32{
+	7,  // 0: atlas.v1.ListPokemonsResponse.pokemons:type_name -> atlas.v1.Pokemon
+	15, // 1: atlas.v1.SearchLocationsResponse.locations:type_name -> atlas.v1.Location
+	9,  // 2: atlas.v1.PokemonDetails.stats:type_name -> atlas.v1.Stat
+	10, // 3: atlas.v1.PokemonDetails.evolutions:type_name -> atlas.v1.Evolution
+	11, // 4: atlas.v1.PokemonDetails.held_items:type_name -> atlas.v1.HeldItem
+	13, // 5: atlas.v1.PokemonDetails.forms:type_name -> atlas.v1.Form
+	14, // 6: atlas.v1.PokemonDetails.cries:type_name -> atlas.v1.Cries
+	12, // 7: atlas.v1.HeldItem.versions:type_name -> atlas.v1.HeldItemVersion
+	0,  // 8: atlas.v1.AtlasService.GetPokemon:input_type -> atlas.v1.GetPokemonRequest
+	1,  // 9: atlas.v1.AtlasService.ListPokemons:input_type -> atlas.v1.ListPokemonsRequest
+	3,  // 10: atlas.v1.AtlasService.StreamPokemons:input_type -> atlas.v1.StreamPokemonsRequest
+	4,  // 11: atlas.v1.AtlasService.GetPokemonDetailed:input_type -> atlas.v1.GetPokemonDetailedRequest
+	5,  // 12: atlas.v1.AtlasService.SearchLocations:input_type -> atlas.v1.SearchLocationsRequest
+	7,  // 13: atlas.v1.AtlasService.GetPokemon:output_type -> atlas.v1.Pokemon
+	2,  // 14: atlas.v1.AtlasService.ListPokemons:output_type -> atlas.v1.ListPokemonsResponse
+	7,  // 15: atlas.v1.AtlasService.StreamPokemons:output_type -> atlas.v1.Pokemon
+	8,  // 16: atlas.v1.AtlasService.GetPokemonDetailed:output_type -> atlas.v1.PokemonDetails
+	6,  // 17: atlas.v1.AtlasService.SearchLocations:output_type -> atlas.v1.SearchLocationsResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_api_atlas_v1_atlas_proto_init() }
+func file_api_atlas_v1_atlas_proto_init() {
+	if File_api_atlas_v1_atlas_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_atlas_v1_atlas_proto_rawDesc), len(file_api_atlas_v1_atlas_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_atlas_v1_atlas_proto_goTypes,
+		DependencyIndexes: file_api_atlas_v1_atlas_proto_depIdxs,
+		MessageInfos:      file_api_atlas_v1_atlas_proto_msgTypes,
+	}.Build()
+	File_api_atlas_v1_atlas_proto = out.File
+	file_api_atlas_v1_atlas_proto_goTypes = nil
+	file_api_atlas_v1_atlas_proto_depIdxs = nil
+}
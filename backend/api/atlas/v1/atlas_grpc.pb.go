@@ -0,0 +1,289 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/atlas/v1/atlas.proto
+
+// Typed access to the atlas for other services, served next to the REST api by cmd/server.
+// Regenerate the Go code from the backend directory with
+//   protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative api/atlas/v1/atlas.proto
+
+package atlasv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AtlasService_GetPokemon_FullMethodName         = "/atlas.v1.AtlasService/GetPokemon"
+	AtlasService_ListPokemons_FullMethodName       = "/atlas.v1.AtlasService/ListPokemons"
+	AtlasService_StreamPokemons_FullMethodName     = "/atlas.v1.AtlasService/StreamPokemons"
+	AtlasService_GetPokemonDetailed_FullMethodName = "/atlas.v1.AtlasService/GetPokemonDetailed"
+	AtlasService_SearchLocations_FullMethodName    = "/atlas.v1.AtlasService/SearchLocations"
+)
+
+// AtlasServiceClient is the client API for AtlasService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AtlasServiceClient interface {
+	// Pokemon by name, or by its species name in any language. NOT_FOUND for unknown pokemon
+	GetPokemon(ctx context.Context, in *GetPokemonRequest, opts ...grpc.CallOption) (*Pokemon, error)
+	// One page of pokemon by national dex number
+	ListPokemons(ctx context.Context, in *ListPokemonsRequest, opts ...grpc.CallOption) (*ListPokemonsResponse, error)
+	// Pokemon by national dex number, sent one by one while the pages are loaded
+	StreamPokemons(ctx context.Context, in *StreamPokemonsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Pokemon], error)
+	GetPokemonDetailed(ctx context.Context, in *GetPokemonDetailedRequest, opts ...grpc.CallOption) (*PokemonDetails, error)
+	// Locations whose name contains the search
+	SearchLocations(ctx context.Context, in *SearchLocationsRequest, opts ...grpc.CallOption) (*SearchLocationsResponse, error)
+}
+
+type atlasServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAtlasServiceClient(cc grpc.ClientConnInterface) AtlasServiceClient {
+	return &atlasServiceClient{cc}
+}
+
+func (c *atlasServiceClient) GetPokemon(ctx context.Context, in *GetPokemonRequest, opts ...grpc.CallOption) (*Pokemon, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Pokemon)
+	err := c.cc.Invoke(ctx, AtlasService_GetPokemon_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *atlasServiceClient) ListPokemons(ctx context.Context, in *ListPokemonsRequest, opts ...grpc.CallOption) (*ListPokemonsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPokemonsResponse)
+	err := c.cc.Invoke(ctx, AtlasService_ListPokemons_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *atlasServiceClient) StreamPokemons(ctx context.Context, in *StreamPokemonsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Pokemon], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AtlasService_ServiceDesc.Streams[0], AtlasService_StreamPokemons_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPokemonsRequest, Pokemon]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AtlasService_StreamPokemonsClient = grpc.ServerStreamingClient[Pokemon]
+
+func (c *atlasServiceClient) GetPokemonDetailed(ctx context.Context, in *GetPokemonDetailedRequest, opts ...grpc.CallOption) (*PokemonDetails, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PokemonDetails)
+	err := c.cc.Invoke(ctx, AtlasService_GetPokemonDetailed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *atlasServiceClient) SearchLocations(ctx context.Context, in *SearchLocationsRequest, opts ...grpc.CallOption) (*SearchLocationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchLocationsResponse)
+	err := c.cc.Invoke(ctx, AtlasService_SearchLocations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AtlasServiceServer is the server API for AtlasService service.
+// All implementations must embed UnimplementedAtlasServiceServer
+// for forward compatibility.
+type AtlasServiceServer interface {
+	// Pokemon by name, or by its species name in any language. NOT_FOUND for unknown pokemon
+	GetPokemon(context.Context, *GetPokemonRequest) (*Pokemon, error)
+	// One page of pokemon by national dex number
+	ListPokemons(context.Context, *ListPokemonsRequest) (*ListPokemonsResponse, error)
+	// Pokemon by national dex number, sent one by one while the pages are loaded
+	StreamPokemons(*StreamPokemonsRequest, grpc.ServerStreamingServer[Pokemon]) error
+	GetPokemonDetailed(context.Context, *GetPokemonDetailedRequest) (*PokemonDetails, error)
+	// Locations whose name contains the search
+	SearchLocations(context.Context, *SearchLocationsRequest) (*SearchLocationsResponse, error)
+	mustEmbedUnimplementedAtlasServiceServer()
+}
+
+// UnimplementedAtlasServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAtlasServiceServer struct{}
+
+func (UnimplementedAtlasServiceServer) GetPokemon(context.Context, *GetPokemonRequest) (*Pokemon, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPokemon not implemented")
+}
+func (UnimplementedAtlasServiceServer) ListPokemons(context.Context, *ListPokemonsRequest) (*ListPokemonsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPokemons not implemented")
+}
+func (UnimplementedAtlasServiceServer) StreamPokemons(*StreamPokemonsRequest, grpc.ServerStreamingServer[Pokemon]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPokemons not implemented")
+}
+func (UnimplementedAtlasServiceServer) GetPokemonDetailed(context.Context, *GetPokemonDetailedRequest) (*PokemonDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPokemonDetailed not implemented")
+}
+func (UnimplementedAtlasServiceServer) SearchLocations(context.Context, *SearchLocationsRequest) (*SearchLocationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchLocations not implemented")
+}
+func (UnimplementedAtlasServiceServer) mustEmbedUnimplementedAtlasServiceServer() {}
+func (UnimplementedAtlasServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAtlasServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AtlasServiceServer will
+// result in compilation errors.
+type UnsafeAtlasServiceServer interface {
+	mustEmbedUnimplementedAtlasServiceServer()
+}
+
+func RegisterAtlasServiceServer(s grpc.ServiceRegistrar, srv AtlasServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAtlasServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AtlasService_ServiceDesc, srv)
+}
+
+func _AtlasService_GetPokemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPokemonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AtlasServiceServer).GetPokemon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AtlasService_GetPokemon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AtlasServiceServer).GetPokemon(ctx, req.(*GetPokemonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AtlasService_ListPokemons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPokemonsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AtlasServiceServer).ListPokemons(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AtlasService_ListPokemons_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AtlasServiceServer).ListPokemons(ctx, req.(*ListPokemonsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AtlasService_StreamPokemons_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPokemonsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AtlasServiceServer).StreamPokemons(m, &grpc.GenericServerStream[StreamPokemonsRequest, Pokemon]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AtlasService_StreamPokemonsServer = grpc.ServerStreamingServer[Pokemon]
+
+func _AtlasService_GetPokemonDetailed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPokemonDetailedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AtlasServiceServer).GetPokemonDetailed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AtlasService_GetPokemonDetailed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AtlasServiceServer).GetPokemonDetailed(ctx, req.(*GetPokemonDetailedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AtlasService_SearchLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AtlasServiceServer).SearchLocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AtlasService_SearchLocations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AtlasServiceServer).SearchLocations(ctx, req.(*SearchLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AtlasService_ServiceDesc is the grpc.ServiceDesc for AtlasService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AtlasService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "atlas.v1.AtlasService",
+	HandlerType: (*AtlasServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPokemon",
+			Handler:    _AtlasService_GetPokemon_Handler,
+		},
+		{
+			MethodName: "ListPokemons",
+			Handler:    _AtlasService_ListPokemons_Handler,
+		},
+		{
+			MethodName: "GetPokemonDetailed",
+			Handler:    _AtlasService_GetPokemonDetailed_Handler,
+		},
+		{
+			MethodName: "SearchLocations",
+			Handler:    _AtlasService_SearchLocations_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamPokemons",
+			Handler:       _AtlasService_StreamPokemons_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/atlas/v1/atlas.proto",
+}
@@ -0,0 +1,8 @@
+package api
+
+import _ "embed"
+
+// OpenAPI 3 specification of the REST api, kept next to the gRPC proto
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
+	"net"
 	"net/http"
+	atlasv1 "poke-atlas/web-service/api/atlas/v1"
+	"poke-atlas/web-service/internal/graphqlapi"
+	"poke-atlas/web-service/internal/grpcapi"
 	"poke-atlas/web-service/internal/handlers"
+	"poke-atlas/web-service/internal/mediacache"
 	"poke-atlas/web-service/internal/pokeapi"
 	"poke-atlas/web-service/internal/repository"
 	"poke-atlas/web-service/internal/store"
@@ -11,8 +17,8 @@ import (
 	"log"
 	"os"
 
-	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -32,23 +38,47 @@ func main() {
 	if err != nil {
 		log.Fatal("Failed to initialize database:", err)
 	}
-	repository := repository.NewRepository(pokeAPIClient, database)
-	handler := handlers.NewHandler(repository)
 
-	router := gin.Default()
-	router.SetTrustedProxies(nil)
+	// Sprites and cries are stored next to the database unless configured otherwise
+	mediaCacheDir := os.Getenv("MEDIA_CACHE_DIR")
+	if mediaCacheDir == "" {
+		mediaCacheDir = "./media-cache"
+	}
+	mediaCache := mediacache.NewMediaCache(mediaCacheDir)
 
-	router.GET("/test", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "it works!",
-		})
-	})
+	repository := repository.NewRepository(pokeAPIClient, database, mediaCache)
+	handler := handlers.NewHandler(repository)
 
-	router.GET("/pokemon/:name", handler.GetPokemonHandler)
+	// Lookups by localized name need the names of every species, they are synced once in the background
+	go repository.SyncAllSpeciesNames(context.Background())
 
-	router.GET("/pokemons/:offset", handler.GetPokemonsHandler)
+	graphQLHandler, err := graphqlapi.NewHandler(repository)
+	if err != nil {
+		log.Fatal("Failed to build GraphQL schema:", err)
+	}
 
-	router.GET("pokemondetailed/:id", handler.GetPokemonDetailedHandler)
+	// The sync endpoints are disabled without a token as every call makes hundreds of requests upstream
+	router, err := NewRouter(handler, graphQLHandler, os.Getenv("ADMIN_TOKEN"))
+	if err != nil {
+		log.Fatal("Failed to build router:", err)
+	}
+
+	// The gRPC service shares the repository and runs next to the REST api
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		log.Fatal("Failed to listen for gRPC:", err)
+	}
+	grpcServer := grpc.NewServer()
+	atlasv1.RegisterAtlasServiceServer(grpcServer, grpcapi.NewServer(repository))
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatal("gRPC server stopped:", err)
+		}
+	}()
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"poke-atlas/web-service/internal/handlers"
+	"poke-atlas/web-service/internal/openapi"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Every route is served under this prefix, the routes from before versioning keep deprecated unversioned aliases
+const apiPrefix = "/api/v1"
+
+var (
+	legacyDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	legacySunsetAt     = time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
+)
+
+// Builds the router with the versioned routes and the legacy aliases of the original routes,
+// the middleware runs before every route, e.g. to validate against the OpenAPI spec in tests
+func NewRouter(handler *handlers.Handler, graphQLHandler gin.HandlerFunc, adminToken string, middleware ...gin.HandlerFunc) (*gin.Engine, error) {
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
+	specHandler, err := openapi.SpecHandler(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	router := gin.Default()
+	router.SetTrustedProxies(nil)
+	router.Use(middleware...)
+
+	registerRoutes(router.Group(apiPrefix), handler, graphQLHandler, specHandler, requireAdminToken(adminToken))
+	registerLegacyRoutes(router.Group("", deprecated), handler)
+
+	return router, nil
+}
+
+// Marks the response of a legacy path as deprecated and links the versioned path replacing it
+func deprecated(c *gin.Context) {
+	successor := apiPrefix + c.Request.URL.Path
+	if c.Request.URL.RawQuery != "" {
+		successor += "?" + c.Request.URL.RawQuery
+	}
+
+	c.Header("Deprecation", "@"+strconv.FormatInt(legacyDeprecatedAt.Unix(), 10))
+	c.Header("Sunset", legacySunsetAt.Format(http.TimeFormat))
+	c.Header("Link", "<"+successor+">; rel=\"successor-version\"")
+}
+
+// Only lets requests with the admin token as bearer token through, every request is refused without a token
+func requireAdminToken(adminToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if adminToken == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "this endpoint is disabled, set ADMIN_TOKEN to enable it"})
+			return
+		}
+
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "admin token required"})
+			return
+		}
+	}
+}
+
+func testHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"message": "it works!",
+	})
+}
+
+// Routes that were served without a version before, later routes only exist under the prefix
+func registerLegacyRoutes(routes gin.IRoutes, handler *handlers.Handler) {
+	routes.GET("/test", testHandler)
+
+	routes.GET("/pokemon/:name", handler.GetPokemonHandler)
+
+	routes.GET("/pokemons/:offset", handler.GetPokemonsHandler)
+
+	routes.GET("/pokemondetailed/:id", handler.GetPokemonDetailedHandler)
+}
+
+func registerRoutes(routes gin.IRoutes, handler *handlers.Handler, graphQLHandler gin.HandlerFunc, specHandler gin.HandlerFunc, admin gin.HandlerFunc) {
+	routes.GET("/test", testHandler)
+
+	routes.GET("/openapi.json", specHandler)
+
+	routes.GET("/docs", openapi.DocsHandler)
+
+	routes.GET("/pokemon/:name", handler.GetPokemonHandler)
+
+	routes.POST("/pokemon/batch", handler.GetPokemonBatchHandler)
+
+	routes.GET("/pokemon/:name/encounters", handler.GetPokemonEncountersHandler)
+
+	routes.GET("/pokemon/:name/evolution-tree", handler.GetPokemonEvolutionTreeHandler)
+
+	routes.GET("/pokemon/:name/flavor-text", handler.GetPokemonFlavorTextHandler)
+
+	routes.POST("/pokemon/:name/stats/calculate", handler.CalculateStatsHandler)
+
+	routes.POST("/pokemon/:name/stats/iv-range", handler.EstimateIVsHandler)
+
+	routes.GET("/pokemons/:offset", handler.GetPokemonsHandler)
+
+	routes.GET("/pokedexes", handler.GetPokedexesHandler)
+
+	routes.GET("/pokedexes/:name/entries", handler.GetPokedexEntriesHandler)
+
+	routes.GET("/games", handler.GetGamesHandler)
+
+	routes.GET("/generations/:id", handler.GetGenerationHandler)
+
+	routes.GET("/pokemondetailed/:id", handler.GetPokemonDetailedHandler)
+
+	routes.GET("/compare", handler.ComparePokemonHandler)
+
+	routes.POST("/graphql", graphQLHandler)
+
+	routes.GET("/graphql", graphQLHandler)
+
+	routes.GET("/evolution-chains/:id", handler.GetEvolutionChainHandler)
+
+	routes.GET("/species/:id/varieties", handler.GetSpeciesVarietiesHandler)
+
+	routes.POST("/species-names/sync", admin, handler.SyncSpeciesNamesHandler)
+
+	routes.GET("/items/:name", handler.GetItemHandler)
+
+	routes.GET("/natures", handler.GetNaturesHandler)
+
+	routes.GET("/characteristics", handler.GetCharacteristicsHandler)
+
+	routes.POST("/calc/damage", handler.CalculateDamageHandler)
+
+	routes.GET("/teams", handler.GetTeamsHandler)
+
+	routes.POST("/teams", handler.CreateTeamHandler)
+
+	routes.GET("/teams/:id", handler.GetTeamHandler)
+
+	routes.PUT("/teams/:id", handler.UpdateTeamHandler)
+
+	routes.DELETE("/teams/:id", handler.DeleteTeamHandler)
+
+	routes.GET("/teams/:id/analysis", handler.GetTeamAnalysisHandler)
+
+	routes.POST("/teams/import", handler.ImportTeamHandler)
+
+	routes.GET("/teams/:id/export", handler.ExportTeamHandler)
+
+	routes.GET("/saves", handler.GetSavesHandler)
+
+	routes.POST("/saves", handler.CreateSaveHandler)
+
+	routes.GET("/saves/:id", handler.GetSaveHandler)
+
+	routes.DELETE("/saves/:id", handler.DeleteSaveHandler)
+
+	routes.GET("/saves/:id/pokemon", handler.GetSavePokemonHandler)
+
+	routes.PUT("/saves/:id/pokemon/:name", handler.SetSavePokemonHandler)
+
+	routes.GET("/saves/:id/completion", handler.GetSaveCompletionHandler)
+
+	routes.GET("/saves/:id/obtainable", handler.GetObtainablePokemonHandler)
+
+	routes.GET("/sprites/:id/:variant", handler.GetSpriteHandler)
+
+	routes.POST("/sprites/sync", admin, handler.SyncSpritesHandler)
+
+	routes.GET("/cries/:id", handler.GetCryHandler)
+
+	routes.GET("/locations", handler.GetLocationsHandler)
+
+	routes.GET("/locations/:name", handler.GetLocationHandler)
+
+	routes.GET("/location-areas/:name", handler.GetLocationAreaHandler)
+}
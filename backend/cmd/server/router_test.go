@@ -0,0 +1,170 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"poke-atlas/web-service/internal/handlers"
+	"poke-atlas/web-service/internal/openapi"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testAdminToken = "secret"
+
+// Routes without a repository are enough to check the routing itself
+func newTestRouter(t *testing.T, middleware ...gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router, err := NewRouter(handlers.NewHandler(nil), func(c *gin.Context) {}, testAdminToken, middleware...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return router
+}
+
+func TestNewRouterServesLegacyAliases(t *testing.T) {
+	router := newTestRouter(t)
+
+	versioned := map[string]bool{}
+	var legacy []string
+	for _, route := range router.Routes() {
+		if path, ok := strings.CutPrefix(route.Path, apiPrefix); ok {
+			versioned[route.Method+" "+path] = true
+		} else {
+			legacy = append(legacy, route.Method+" "+route.Path)
+		}
+	}
+
+	expected := []string{"GET /test", "GET /pokemon/:name", "GET /pokemons/:offset", "GET /pokemondetailed/:id"}
+	slices.Sort(expected)
+	slices.Sort(legacy)
+	if !slices.Equal(legacy, expected) {
+		t.Errorf("expected legacy routes %v, got %v", expected, legacy)
+	}
+	for _, route := range legacy {
+		if !versioned[route] {
+			t.Errorf("legacy route %s has no versioned route", route)
+		}
+	}
+
+	// Routes added after versioning have no alias
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/teams", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("GET /teams = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
+
+func TestNewRouterDeprecatesLegacyPaths(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/test?verbose=1", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /test = %d", recorder.Code)
+	}
+	if deprecation := recorder.Header().Get("Deprecation"); deprecation != "@1792368000" {
+		t.Errorf("Deprecation = %q", deprecation)
+	}
+	if sunset := recorder.Header().Get("Sunset"); sunset != "Mon, 19 Apr 2027 00:00:00 GMT" {
+		t.Errorf("Sunset = %q", sunset)
+	}
+	if link := recorder.Header().Get("Link"); link != `</api/v1/test?verbose=1>; rel="successor-version"` {
+		t.Errorf("Link = %q", link)
+	}
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/test", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /api/v1/test = %d", recorder.Code)
+	}
+	for _, header := range []string{"Deprecation", "Sunset", "Link"} {
+		if value := recorder.Header().Get(header); value != "" {
+			t.Errorf("versioned route has %s header %q", header, value)
+		}
+	}
+}
+
+func TestRoutesAreDocumented(t *testing.T) {
+	router := newTestRouter(t)
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	param := regexp.MustCompile(`:(\w+)`)
+	for _, route := range router.Routes() {
+		path, ok := strings.CutPrefix(route.Path, apiPrefix)
+		if !ok {
+			continue
+		}
+		path = param.ReplaceAllString(path, "{$1}")
+
+		item := doc.Paths.Value(path)
+		if item == nil || item.GetOperation(route.Method) == nil {
+			t.Errorf("%s %s is not in the openapi spec", route.Method, path)
+		}
+	}
+}
+
+func TestRoutesMatchSpec(t *testing.T) {
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validation, err := openapi.ValidationMiddleware(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := newTestRouter(t, validation)
+
+	for _, path := range []string{"/api/v1/test", "/api/v1/openapi.json", "/api/v1/docs", "/test"} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != http.StatusOK {
+			t.Errorf("GET %s = %d: %s", path, recorder.Code, recorder.Body)
+		}
+	}
+}
+
+func TestSyncRoutesRequireAdminToken(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, path := range []string{"/api/v1/species-names/sync", "/api/v1/sprites/sync"} {
+		for header, status := range map[string]int{
+			"":                         http.StatusUnauthorized,
+			"Bearer wrong":             http.StatusUnauthorized,
+			"secret":                   http.StatusUnauthorized,
+			"Bearer " + testAdminToken: http.StatusBadRequest,
+		} {
+			// An invalid limit stops an authorized request before it reaches the missing repository
+			req := httptest.NewRequest(http.MethodPost, path+"?limit=0", nil)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+			if recorder.Code != status {
+				t.Errorf("POST %s with %q = %d, want %d", path, header, recorder.Code, status)
+			}
+		}
+	}
+
+	gin.SetMode(gin.TestMode)
+	disabled, err := NewRouter(handlers.NewHandler(nil), func(c *gin.Context) {}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/sprites/sync", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	disabled.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("POST /api/v1/sprites/sync without an admin token = %d, want %d", recorder.Code, http.StatusForbidden)
+	}
+}
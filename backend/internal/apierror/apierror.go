@@ -0,0 +1,16 @@
+package apierror
+
+import "fmt"
+
+// Invalid input of a request, as opposed to a failure fetching data. Handlers respond with 400 Bad Request
+type ValidationError struct {
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+func Validationf(format string, args ...any) error {
+	return ValidationError{Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,103 @@
+package batch
+
+import (
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/pokeapi"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Pokemon per request, every missing one is a request to pokeapi
+const MaxPokemon = 100
+
+// Validates the request, removes duplicates and normalizes the names to pokeapi slugs.
+// Numeric names are looked up as ids
+func ValidateRequest(req *model.Pokemon_batch_request) error {
+	ids := req.IDs
+	var names []string
+	for _, name := range req.Names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return apierror.Validationf("pokemon names cannot be empty")
+		}
+		// The name ends up in the pokeapi url, so it can't be a path or query
+		if !pokeapi.SlugPattern.MatchString(name) {
+			return apierror.Validationf("invalid pokemon name %q, names only contain letters, digits and dashes", name)
+		}
+		if id, err := strconv.Atoi(name); err == nil {
+			ids = append(ids, id)
+			continue
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	var uniqueIDs []int
+	for _, id := range ids {
+		if id < 1 {
+			return apierror.Validationf("invalid pokemon id %d", id)
+		}
+		if !slices.Contains(uniqueIDs, id) {
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+	ids = uniqueIDs
+
+	if len(ids)+len(names) == 0 {
+		return apierror.Validationf("at least one pokemon id or name is required")
+	}
+	if len(ids)+len(names) > MaxPokemon {
+		return apierror.Validationf("at most %d pokemon can be looked up at once", MaxPokemon)
+	}
+
+	req.IDs = ids
+	req.Names = names
+	return nil
+}
+
+// Ids and names of the request no summary matches
+func Missing(req model.Pokemon_batch_request, pokemon []model.Pokemon_summary) ([]int, []string) {
+	var ids []int
+	for _, id := range req.IDs {
+		if !slices.ContainsFunc(pokemon, func(p model.Pokemon_summary) bool { return p.ID == id }) {
+			ids = append(ids, id)
+		}
+	}
+
+	var names []string
+	for _, name := range req.Names {
+		if !slices.ContainsFunc(pokemon, func(p model.Pokemon_summary) bool { return p.Name == name }) {
+			names = append(names, name)
+		}
+	}
+
+	return ids, names
+}
+
+// Orders the summaries like the request and lists what wasn't found
+func Result(req model.Pokemon_batch_request, pokemon []model.Pokemon_summary) model.Pokemon_batch {
+	result := model.Pokemon_batch{Pokemon: []model.Pokemon_summary{}, NotFound: []string{}}
+
+	add := func(ref string, match func(p model.Pokemon_summary) bool) {
+		i := slices.IndexFunc(pokemon, match)
+		if i < 0 {
+			result.NotFound = append(result.NotFound, ref)
+			return
+		}
+		if !slices.ContainsFunc(result.Pokemon, func(p model.Pokemon_summary) bool { return p.ID == pokemon[i].ID }) {
+			result.Pokemon = append(result.Pokemon, pokemon[i])
+		}
+	}
+
+	for _, id := range req.IDs {
+		add(strconv.Itoa(id), func(p model.Pokemon_summary) bool { return p.ID == id })
+	}
+	for _, name := range req.Names {
+		add(name, func(p model.Pokemon_summary) bool { return p.Name == name })
+	}
+
+	return result
+}
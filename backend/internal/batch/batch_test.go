@@ -0,0 +1,75 @@
+package batch
+
+import (
+	"errors"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+	"testing"
+)
+
+func TestValidateRequest(t *testing.T) {
+	req := model.Pokemon_batch_request{IDs: []int{25, 6, 25}, Names: []string{" Pikachu", "pikachu", "mew"}}
+	if err := ValidateRequest(&req); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(req.IDs, []int{25, 6}) || !slices.Equal(req.Names, []string{"pikachu", "mew"}) {
+		t.Errorf("expected duplicates removed and names normalized, got %v %v", req.IDs, req.Names)
+	}
+
+	// Numeric names are ids
+	req = model.Pokemon_batch_request{IDs: []int{25}, Names: []string{"25", "6", "mew"}}
+	if err := ValidateRequest(&req); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(req.IDs, []int{25, 6}) || !slices.Equal(req.Names, []string{"mew"}) {
+		t.Errorf("expected numeric names as ids, got %v %v", req.IDs, req.Names)
+	}
+
+	tooMany := model.Pokemon_batch_request{}
+	for i := range MaxPokemon + 1 {
+		tooMany.IDs = append(tooMany.IDs, i+1)
+	}
+
+	for _, invalid := range []model.Pokemon_batch_request{
+		{},
+		{IDs: []int{0}},
+		{Names: []string{" "}},
+		{Names: []string{"0"}},
+		// Names that would reach other pokeapi resources
+		{Names: []string{"../item/master-ball"}},
+		{Names: []string{"item/master-ball"}},
+		{Names: []string{"x?y"}},
+		{Names: []string{".."}},
+		tooMany,
+	} {
+		if err := ValidateRequest(&invalid); !errors.As(err, &apierror.ValidationError{}) {
+			t.Errorf("%v: expected validation error, got %v", invalid, err)
+		}
+	}
+}
+
+func TestMissing(t *testing.T) {
+	req := model.Pokemon_batch_request{IDs: []int{25, 6}, Names: []string{"pikachu", "mew"}}
+	ids, names := Missing(req, []model.Pokemon_summary{{ID: 25, Name: "pikachu"}})
+
+	if !slices.Equal(ids, []int{6}) || !slices.Equal(names, []string{"mew"}) {
+		t.Errorf("expected 6 and mew to be missing, got %v %v", ids, names)
+	}
+}
+
+func TestResult(t *testing.T) {
+	req := model.Pokemon_batch_request{IDs: []int{6, 25, 9999}, Names: []string{"pikachu", "mew"}}
+	result := Result(req, []model.Pokemon_summary{{ID: 151, Name: "mew"}, {ID: 25, Name: "pikachu"}, {ID: 6, Name: "charizard"}})
+
+	var names []string
+	for _, p := range result.Pokemon {
+		names = append(names, p.Name)
+	}
+	if !slices.Equal(names, []string{"charizard", "pikachu", "mew"}) {
+		t.Errorf("expected request order without duplicates, got %v", names)
+	}
+	if !slices.Equal(result.NotFound, []string{"9999"}) {
+		t.Errorf("expected 9999 not found, got %v", result.NotFound)
+	}
+}
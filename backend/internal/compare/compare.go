@@ -0,0 +1,176 @@
+package compare
+
+import (
+	"maps"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/damage"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	MinPokemon = 2
+	MaxPokemon = 6
+)
+
+// Name of the row summing up the base stats
+const StatTotal = "total"
+
+// Parses a comma separated list of pokemon ids, e.g. "6,9,3"
+func ParseIDs(list string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		id, err := strconv.Atoi(part)
+		if err != nil || id < 1 {
+			return nil, apierror.Validationf("invalid pokemon id %q", part)
+		}
+		if slices.Contains(ids, id) {
+			return nil, apierror.Validationf("pokemon %d is listed twice", id)
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) < MinPokemon || len(ids) > MaxPokemon {
+		return nil, apierror.Validationf("between %d and %d pokemon ids are required", MinPokemon, MaxPokemon)
+	}
+
+	return ids, nil
+}
+
+// Compares the base stats and type matchups of the pokemon, moves learnable by all of them are given as sharedMoves
+func Compare(pokemon []model.Pokemon_details, sharedMoves []string, chart model.Type_chart) model.Comparison {
+	comparison := model.Comparison{
+		Pokemon:           pokemon,
+		Stats:             compareStats(pokemon),
+		SharedWeaknesses:  []string{},
+		SharedResistances: []string{},
+		Defense:           []model.Type_matchup{},
+		SharedMoves:       sharedMoves,
+	}
+	if comparison.SharedMoves == nil {
+		comparison.SharedMoves = []string{}
+	}
+
+	for _, t := range chartTypes(chart) {
+		matchup := model.Type_matchup{Type: t, Weak: []string{}, Resistant: []string{}, Immune: []string{}}
+
+		// Abilities aren't part of the comparison, so only the types decide the matchup
+		for _, p := range pokemon {
+			switch effectiveness := damage.Effectiveness(t, p.Types, "", chart); {
+			case effectiveness == 0:
+				matchup.Immune = append(matchup.Immune, p.Name)
+			case effectiveness < 1:
+				matchup.Resistant = append(matchup.Resistant, p.Name)
+			case effectiveness > 1:
+				matchup.Weak = append(matchup.Weak, p.Name)
+			}
+		}
+
+		if len(matchup.Weak) >= 2 {
+			comparison.SharedWeaknesses = append(comparison.SharedWeaknesses, t)
+		}
+		if len(matchup.Resistant)+len(matchup.Immune) >= 2 {
+			comparison.SharedResistances = append(comparison.SharedResistances, t)
+		}
+		comparison.Defense = append(comparison.Defense, matchup)
+	}
+
+	return comparison
+}
+
+// Moves contained in every list
+func SharedMoves(learnsets [][]string) []string {
+	if len(learnsets) == 0 {
+		return []string{}
+	}
+
+	shared := []string{}
+	for _, move := range learnsets[0] {
+		inAll := true
+		for _, learnset := range learnsets[1:] {
+			if !slices.Contains(learnset, move) {
+				inAll = false
+				break
+			}
+		}
+		if inAll && !slices.Contains(shared, move) {
+			shared = append(shared, move)
+		}
+	}
+
+	slices.Sort(shared)
+	return shared
+}
+
+// One entry per base stat followed by the total
+func compareStats(pokemon []model.Pokemon_details) []model.Stat_comparison {
+	comparisons := []model.Stat_comparison{}
+	if len(pokemon) == 0 {
+		return comparisons
+	}
+
+	for _, name := range append(slices.Clone(model.StatNames), StatTotal) {
+		comparison := model.Stat_comparison{Stat: name, Values: []model.Compared_stat{}}
+		for _, p := range pokemon {
+			comparison.Values = append(comparison.Values, model.Compared_stat{
+				PokemonID:   p.ID,
+				PokemonName: p.Name,
+				BaseStat:    baseStat(p, name),
+			})
+		}
+		markStats(comparison.Values)
+		comparisons = append(comparisons, comparison)
+	}
+
+	return comparisons
+}
+
+func markStats(values []model.Compared_stat) {
+	best, worst := values[0].BaseStat, values[0].BaseStat
+	for _, v := range values {
+		best = max(best, v.BaseStat)
+		worst = min(worst, v.BaseStat)
+	}
+
+	for i := range values {
+		values[i].Delta = values[i].BaseStat - values[0].BaseStat
+		if best != worst {
+			values[i].Best = values[i].BaseStat == best
+			values[i].Worst = values[i].BaseStat == worst
+		}
+	}
+}
+
+func baseStat(pokemon model.Pokemon_details, name string) int {
+	total := 0
+	for _, stat := range pokemon.Stats {
+		if stat.StatName == name {
+			return stat.BaseStat
+		}
+		total += stat.BaseStat
+	}
+
+	if name == StatTotal {
+		return total
+	}
+	return 0
+}
+
+// Every type appearing in the chart, types without any matchups like stellar are left out
+func chartTypes(chart model.Type_chart) []string {
+	types := map[string]bool{}
+	for attacking, defending := range chart {
+		types[attacking] = true
+		for t := range defending {
+			types[t] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(types))
+}
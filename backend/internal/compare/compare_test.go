@@ -0,0 +1,96 @@
+package compare
+
+import (
+	"encoding/json"
+	"errors"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+	"testing"
+)
+
+var chart = model.Type_chart{
+	"ground":   {"electric": 200, "fire": 200, "flying": 0, "grass": 50},
+	"water":    {"fire": 200, "ground": 200, "grass": 50, "water": 50},
+	"electric": {"water": 200, "flying": 200, "ground": 0, "electric": 50, "grass": 50},
+	"grass":    {"water": 200, "ground": 200, "fire": 50, "grass": 50, "flying": 50},
+	"fire":     {"grass": 200, "fire": 50, "water": 50},
+	"flying":   {"grass": 200, "electric": 50},
+}
+
+func pokemon(t *testing.T, data string) model.Pokemon_details {
+	var p model.Pokemon_details
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestParseIDs(t *testing.T) {
+	ids, err := ParseIDs("6, 9,3")
+	if err != nil || !slices.Equal(ids, []int{6, 9, 3}) {
+		t.Errorf("expected [6 9 3], got %v %v", ids, err)
+	}
+
+	for _, list := range []string{"", "6", "6,6", "6,charizard", "6,0", "1,2,3,4,5,6,7"} {
+		if _, err := ParseIDs(list); !errors.As(err, &apierror.ValidationError{}) {
+			t.Errorf("%q: expected validation error, got %v", list, err)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	charizard := pokemon(t, `{"id": 6, "name": "charizard", "types": ["fire", "flying"], "stats": [
+		{"stat_name": "hp", "base_stat": 78}, {"stat_name": "speed", "base_stat": 100}]}`)
+	blastoise := pokemon(t, `{"id": 9, "name": "blastoise", "types": ["water"], "stats": [
+		{"stat_name": "hp", "base_stat": 79}, {"stat_name": "speed", "base_stat": 78}]}`)
+	venusaur := pokemon(t, `{"id": 3, "name": "venusaur", "types": ["grass"], "stats": [
+		{"stat_name": "hp", "base_stat": 80}, {"stat_name": "speed", "base_stat": 80}]}`)
+
+	comparison := Compare([]model.Pokemon_details{charizard, blastoise, venusaur}, nil, chart)
+
+	if len(comparison.Stats) != 7 || comparison.Stats[0].Stat != model.StatHP || comparison.Stats[6].Stat != StatTotal {
+		t.Fatalf("expected the six stats and total, got %+v", comparison.Stats)
+	}
+
+	speed := comparison.Stats[5].Values
+	if speed[1].Delta != -22 || !speed[0].Best || !speed[1].Worst || speed[2].Best || speed[2].Worst {
+		t.Errorf("unexpected speed comparison %+v", speed)
+	}
+
+	total := comparison.Stats[6].Values
+	if total[0].BaseStat != 178 || total[2].BaseStat != 160 || total[2].Delta != -18 {
+		t.Errorf("unexpected totals %+v", total)
+	}
+
+	if !slices.Equal(comparison.SharedWeaknesses, []string{"electric"}) {
+		t.Errorf("expected shared weakness electric, got %v", comparison.SharedWeaknesses)
+	}
+	if !slices.Equal(comparison.SharedResistances, []string{"fire", "grass", "ground", "water"}) {
+		t.Errorf("unexpected shared resistances %v", comparison.SharedResistances)
+	}
+	if comparison.SharedMoves == nil {
+		t.Error("expected shared moves to be an empty list")
+	}
+}
+
+func TestCompareTies(t *testing.T) {
+	a := pokemon(t, `{"id": 1, "name": "a", "stats": [{"stat_name": "hp", "base_stat": 50}]}`)
+	b := pokemon(t, `{"id": 2, "name": "b", "stats": [{"stat_name": "hp", "base_stat": 50}]}`)
+
+	values := Compare([]model.Pokemon_details{a, b}, nil, chart).Stats[0].Values
+	if values[0].Best || values[0].Worst || values[1].Best || values[1].Worst {
+		t.Errorf("expected no markers for equal stats, got %+v", values)
+	}
+}
+
+func TestSharedMoves(t *testing.T) {
+	shared := SharedMoves([][]string{
+		{"tackle", "surf", "protect"},
+		{"protect", "tackle"},
+		{"tackle", "protect", "ember"},
+	})
+	if !slices.Equal(shared, []string{"protect", "tackle"}) {
+		t.Errorf("expected protect and tackle, got %v", shared)
+	}
+}
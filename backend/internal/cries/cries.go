@@ -0,0 +1,41 @@
+package cries
+
+import (
+	"fmt"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+	"strings"
+)
+
+const (
+	Latest = "latest"
+	// Cry of the original games, only pokemon of the first generations have one
+	Legacy = "legacy"
+)
+
+var Variants = []string{Latest, Legacy}
+
+// URLs of the variants the pokemon has
+func URLs(cries model.PokemonCries) map[string]string {
+	urls := map[string]string{}
+	if cries.Latest != "" {
+		urls[Latest] = cries.Latest
+	}
+	if cries.Legacy != "" {
+		urls[Legacy] = cries.Legacy
+	}
+	return urls
+}
+
+func ValidateVariant(variant string) error {
+	if !slices.Contains(Variants, variant) {
+		return apierror.Validationf("unknown cry variant %q, expected one of %s", variant, strings.Join(Variants, ", "))
+	}
+	return nil
+}
+
+// Path of the cry on this server, serving it from the media cache
+func ProxyPath(pokemonID int, variant string) string {
+	return fmt.Sprintf("/api/v1/cries/%d?variant=%s", pokemonID, variant)
+}
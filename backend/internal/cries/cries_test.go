@@ -0,0 +1,35 @@
+package cries
+
+import (
+	"errors"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"testing"
+)
+
+func TestURLs(t *testing.T) {
+	urls := URLs(model.PokemonCries{Latest: "latest.ogg"})
+	if len(urls) != 1 || urls[Latest] != "latest.ogg" {
+		t.Errorf("expected only the latest cry, got %v", urls)
+	}
+
+	urls = URLs(model.PokemonCries{Latest: "latest.ogg", Legacy: "legacy.ogg"})
+	if len(urls) != 2 || urls[Legacy] != "legacy.ogg" {
+		t.Errorf("expected both cries, got %v", urls)
+	}
+}
+
+func TestValidateVariant(t *testing.T) {
+	if err := ValidateVariant(Legacy); err != nil {
+		t.Errorf("expected legacy to be valid, got %v", err)
+	}
+	if err := ValidateVariant("original"); !errors.As(err, &apierror.ValidationError{}) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestProxyPath(t *testing.T) {
+	if path := ProxyPath(25, Legacy); path != "/api/v1/cries/25?variant=legacy" {
+		t.Errorf("unexpected path %q", path)
+	}
+}
@@ -0,0 +1,352 @@
+package damage
+
+import (
+	"fmt"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+)
+
+// Generation 5 onwards damage formula, modifiers are applied in 4096ths like the games do
+// (https://bulbapedia.bulbagarden.net/wiki/Damage).
+// Multi-hit moves are calculated per hit.
+
+const (
+	MaxMoves  = 4
+	MaxBoost  = 6
+	maxKOHits = 4
+)
+
+// A pokemon with its final stats before stat stages
+type Pokemon struct {
+	Level   int
+	Types   []string
+	Stats   model.Stat_spread
+	Boosts  model.Stat_spread
+	Ability string
+	Item    string
+	Status  string
+	// Defaults to the hp stat when 0
+	CurrentHP int
+}
+
+// Abilities making the pokemon immune to a move type
+var immunityAbilities = map[string]string{
+	"levitate":        "ground",
+	"flash-fire":      "fire",
+	"water-absorb":    "water",
+	"storm-drain":     "water",
+	"dry-skin":        "water",
+	"volt-absorb":     "electric",
+	"lightning-rod":   "electric",
+	"motor-drive":     "electric",
+	"sap-sipper":      "grass",
+	"earth-eater":     "ground",
+	"well-baked-body": "fire",
+}
+
+// Moves hitting more than one pokemon deal 75% damage in doubles
+var spreadTargets = []string{"all-opponents", "all-other-pokemon", "all-pokemon"}
+
+// Type effectiveness of a move type against the defending types and ability, 0 for immune
+func Effectiveness(moveType string, defendingTypes []string, ability string, chart model.Type_chart) float64 {
+	if immuneType, ok := immunityAbilities[ability]; ok && immuneType == moveType {
+		return 0
+	}
+
+	result := 1.0
+	for _, defending := range defendingTypes {
+		factor, ok := chart[moveType][defending]
+		if !ok {
+			factor = 100
+		}
+		result *= float64(factor) / 100
+	}
+
+	if ability == "wonder-guard" && result <= 1 {
+		return 0
+	}
+	return result
+}
+
+// Calculates the damage rolls of one move
+func Calculate(attacker Pokemon, defender Pokemon, move model.Move_details, field model.Damage_field, chart model.Type_chart) model.Move_damage {
+	result := model.Move_damage{Move: move, Rolls: make([]int, 16)}
+
+	if move.DamageClass == model.DamageClassStatus || move.Power == 0 {
+		result.Description = "no damage calculated for moves without a base power"
+		return result
+	}
+
+	effectiveness := Effectiveness(move.Type, defender.Types, defender.Ability, chart)
+	result.Effectiveness = effectiveness
+
+	if effectiveness == 0 {
+		result.Description = "the defender is immune"
+		return result
+	}
+
+	physical := move.DamageClass == model.DamageClassPhysical
+	crit := field.CriticalHit
+
+	power := basePower(attacker, move, field)
+	attack := attackStat(attacker, defender, move, physical, crit)
+	defense := defenseStat(defender, physical, crit, field)
+
+	base := (2*attacker.Level/5+2)*power*attack/defense/50 + 2
+
+	if field.Doubles && slices.Contains(spreadTargets, move.Target) {
+		base = pokeRound(base, 3072)
+	}
+	base = pokeRound(base, weatherModifier(move.Type, field.Weather))
+	if crit {
+		base = base * 3 / 2
+	}
+
+	stab := 4096
+	if slices.Contains(attacker.Types, move.Type) {
+		stab = 6144
+		if attacker.Ability == "adaptability" {
+			stab = 8192
+		}
+	}
+
+	burned := physical && attacker.Status == "burn" && attacker.Ability != "guts" && move.Name != "facade"
+	final := finalModifier(attacker, defender, move, field, effectiveness, physical, crit)
+
+	for i := range result.Rolls {
+		damage := base * (85 + i) / 100
+		damage = pokeRound(damage, stab)
+		damage = int(float64(damage) * effectiveness)
+		if burned {
+			damage = pokeRound(damage, 2048)
+		}
+		damage = max(pokeRound(damage, final), 1)
+		result.Rolls[i] = damage
+	}
+
+	hp := defender.Stats.HP
+	currentHP := defender.CurrentHP
+	if currentHP == 0 {
+		currentHP = hp
+	}
+
+	result.Min = result.Rolls[0]
+	result.Max = result.Rolls[len(result.Rolls)-1]
+	result.MinPercent = percent(result.Min, hp)
+	result.MaxPercent = percent(result.Max, hp)
+	result.HitsToKO, result.KOChance = koChance(result.Rolls, currentHP)
+	result.Description = describeKO(result.HitsToKO, result.KOChance)
+
+	return result
+}
+
+func basePower(attacker Pokemon, move model.Move_details, field model.Damage_field) int {
+	var mods []int
+
+	if attacker.Ability == "technician" && move.Power <= 60 {
+		mods = append(mods, 6144)
+	}
+	if field.HelpingHand {
+		mods = append(mods, 6144)
+	}
+	if attacker.Status != "" && move.Name == "facade" {
+		mods = append(mods, 8192)
+	}
+
+	terrainTypes := map[string]string{"electric": "electric", "grassy": "grass", "psychic": "psychic"}
+	if terrainTypes[field.Terrain] == move.Type && move.Type != "" {
+		mods = append(mods, 5325)
+	}
+	if field.Terrain == "misty" && move.Type == "dragon" {
+		mods = append(mods, 2048)
+	}
+
+	return max(pokeRound(move.Power, chainModifiers(mods)), 1)
+}
+
+func attackStat(attacker Pokemon, defender Pokemon, move model.Move_details, physical bool, crit bool) int {
+	stat := model.StatSpecialAttack
+	if physical {
+		stat = model.StatAttack
+	}
+
+	// Critical hits ignore the attacker's negative stages
+	boost := attacker.Boosts.Get(stat)
+	if crit && boost < 0 {
+		boost = 0
+	}
+	attack := applyBoost(attacker.Stats.Get(stat), boost)
+
+	var mods []int
+	if physical && (attacker.Ability == "huge-power" || attacker.Ability == "pure-power") {
+		mods = append(mods, 8192)
+	}
+	if physical && attacker.Ability == "guts" && attacker.Status != "" {
+		mods = append(mods, 6144)
+	}
+	if physical && attacker.Item == "choice-band" || !physical && attacker.Item == "choice-specs" {
+		mods = append(mods, 6144)
+	}
+	if defender.Ability == "thick-fat" && (move.Type == "fire" || move.Type == "ice") {
+		mods = append(mods, 2048)
+	}
+
+	return max(pokeRound(attack, chainModifiers(mods)), 1)
+}
+
+func defenseStat(defender Pokemon, physical bool, crit bool, field model.Damage_field) int {
+	stat := model.StatSpecialDefense
+	if physical {
+		stat = model.StatDefense
+	}
+
+	// Critical hits ignore the defender's positive stages
+	boost := defender.Boosts.Get(stat)
+	if crit && boost > 0 {
+		boost = 0
+	}
+	defense := applyBoost(defender.Stats.Get(stat), boost)
+
+	// Weather boosts are applied to the stat itself
+	if !physical && field.Weather == "sand" && slices.Contains(defender.Types, "rock") {
+		defense = defense * 3 / 2
+	}
+	if physical && field.Weather == "snow" && slices.Contains(defender.Types, "ice") {
+		defense = defense * 3 / 2
+	}
+
+	var mods []int
+	if defender.Item == "eviolite" {
+		mods = append(mods, 6144)
+	}
+	if !physical && defender.Item == "assault-vest" {
+		mods = append(mods, 6144)
+	}
+
+	return max(pokeRound(defense, chainModifiers(mods)), 1)
+}
+
+func weatherModifier(moveType string, weather string) int {
+	switch {
+	case weather == "sun" && moveType == "fire", weather == "rain" && moveType == "water":
+		return 6144
+	case weather == "sun" && moveType == "water", weather == "rain" && moveType == "fire":
+		return 2048
+	}
+	return 4096
+}
+
+func finalModifier(attacker Pokemon, defender Pokemon, move model.Move_details, field model.Damage_field, effectiveness float64, physical bool, crit bool) int {
+	var mods []int
+
+	// Screens don't apply to critical hits
+	screen := field.AuroraVeil || physical && field.Reflect || !physical && field.LightScreen
+	if screen && !crit {
+		if field.Doubles {
+			mods = append(mods, 2732)
+		} else {
+			mods = append(mods, 2048)
+		}
+	}
+
+	if (defender.Ability == "multiscale" || defender.Ability == "shadow-shield") && (defender.CurrentHP == 0 || defender.CurrentHP == defender.Stats.HP) {
+		mods = append(mods, 2048)
+	}
+	if slices.Contains([]string{"filter", "solid-rock", "prism-armor"}, defender.Ability) && effectiveness > 1 {
+		mods = append(mods, 3072)
+	}
+	if attacker.Ability == "tinted-lens" && effectiveness < 1 {
+		mods = append(mods, 8192)
+	}
+	if attacker.Ability == "sniper" && crit {
+		mods = append(mods, 6144)
+	}
+	if attacker.Item == "expert-belt" && effectiveness > 1 {
+		mods = append(mods, 4915)
+	}
+	if attacker.Item == "life-orb" {
+		mods = append(mods, 5324)
+	}
+
+	return chainModifiers(mods)
+}
+
+// Stat stages multiply by (2 + stage) / 2 when positive and 2 / (2 - stage) when negative
+func applyBoost(stat int, boost int) int {
+	boost = min(max(boost, -MaxBoost), MaxBoost)
+	if boost >= 0 {
+		return stat * (2 + boost) / 2
+	}
+	return stat * 2 / (2 - boost)
+}
+
+// Combines modifiers given in 4096ths into one, rounding after each like the games
+func chainModifiers(mods []int) int {
+	result := 4096
+	for _, mod := range mods {
+		result = (result*mod + 2048) >> 12
+	}
+	return result
+}
+
+// Applies a modifier in 4096ths, rounding halves down
+func pokeRound(value int, modifier int) int {
+	product := value * modifier
+	result := product / 4096
+	if product%4096 > 2048 {
+		result++
+	}
+	return result
+}
+
+func percent(damage int, hp int) float64 {
+	if hp == 0 {
+		return 0
+	}
+	return float64(damage*1000/hp) / 10
+}
+
+// Chance that n hits of equally likely rolls deal at least hp damage, for the fewest n with a chance above 0
+func koChance(rolls []int, hp int) (int, float64) {
+	// Number of roll combinations resulting in each total damage
+	totals := map[int]float64{0: 1}
+
+	for hits := 1; hits <= maxKOHits; hits++ {
+		next := map[int]float64{}
+		for total, count := range totals {
+			for _, roll := range rolls {
+				next[total+roll] += count
+			}
+		}
+		totals = next
+
+		var ko, all float64
+		for total, count := range totals {
+			all += count
+			if total >= hp {
+				ko += count
+			}
+		}
+		if ko > 0 {
+			return hits, ko / all
+		}
+	}
+
+	return 0, 0
+}
+
+func describeKO(hits int, chance float64) string {
+	if hits == 0 {
+		return fmt.Sprintf("not a KO within %d hits", maxKOHits)
+	}
+
+	name := fmt.Sprintf("%dHKO", hits)
+	if hits == 1 {
+		name = "OHKO"
+	}
+
+	if chance == 1 {
+		return "guaranteed " + name
+	}
+	return fmt.Sprintf("%.1f%% chance to %s", chance*100, name)
+}
@@ -0,0 +1,127 @@
+package damage
+
+import (
+	"poke-atlas/web-service/internal/model"
+	"slices"
+	"testing"
+)
+
+var chart = model.Type_chart{
+	"ice":    {"dragon": 200, "ground": 200, "fire": 50},
+	"fire":   {"grass": 200, "water": 50},
+	"ground": {"flying": 0, "electric": 200},
+}
+
+// Neutral level 50 pokemon with 100 in every stat, a power 80 move does 37 damage before the random roll
+var neutral = Pokemon{Level: 50, Types: []string{"normal"}, Stats: model.NewStatSpread(100)}
+
+var tackle80 = model.Move_details{Name: "test-move", Type: "ground", DamageClass: model.DamageClassPhysical, Power: 80}
+
+// Expected rolls worked out by hand from the formula on Bulbapedia
+func TestCalculateGolden(t *testing.T) {
+	burned := neutral
+	burned.Status = "burn"
+
+	fireAttacker := neutral
+	fireAttacker.Types = []string{"fire"}
+	ember80 := model.Move_details{Name: "test-fire", Type: "fire", DamageClass: model.DamageClassSpecial, Power: 80}
+
+	glaceon := Pokemon{Level: 75, Types: []string{"ice"}, Stats: model.Stat_spread{Attack: 123}}
+	garchomp := Pokemon{Level: 75, Types: []string{"dragon", "ground"}, Stats: model.Stat_spread{HP: 300, Defense: 163}}
+	iceFang := model.Move_details{Name: "ice-fang", Type: "ice", DamageClass: model.DamageClassPhysical, Power: 65}
+
+	tests := []struct {
+		name     string
+		attacker Pokemon
+		defender Pokemon
+		move     model.Move_details
+		field    model.Damage_field
+		expected []int
+	}{
+		{
+			name:     "neutral",
+			attacker: neutral, defender: neutral, move: tackle80,
+			expected: []int{31, 31, 32, 32, 32, 33, 33, 34, 34, 34, 35, 35, 35, 36, 36, 37},
+		},
+		{
+			name:     "burn",
+			attacker: burned, defender: neutral, move: tackle80,
+			expected: []int{15, 15, 16, 16, 16, 16, 16, 17, 17, 17, 17, 17, 17, 18, 18, 18},
+		},
+		{
+			name:     "burned critical hit ignores reflect",
+			attacker: burned, defender: neutral, move: tackle80,
+			field:    model.Damage_field{CriticalHit: true, Reflect: true},
+			expected: []int{23, 23, 23, 24, 24, 24, 25, 25, 25, 25, 26, 26, 26, 26, 27, 27},
+		},
+		{
+			name:     "reflect",
+			attacker: neutral, defender: neutral, move: tackle80,
+			field:    model.Damage_field{Reflect: true},
+			expected: []int{15, 15, 16, 16, 16, 16, 16, 17, 17, 17, 17, 17, 17, 18, 18, 18},
+		},
+		{
+			name:     "sun boosted stab",
+			attacker: fireAttacker, defender: neutral, move: ember80,
+			field:    model.Damage_field{Weather: "sun"},
+			expected: []int{69, 70, 70, 72, 72, 73, 75, 75, 76, 76, 78, 78, 79, 79, 81, 82},
+		},
+		{
+			// Bulbapedia's example, 168 to 196 damage
+			name:     "glaceon ice fang on garchomp",
+			attacker: glaceon, defender: garchomp, move: iceFang,
+			expected: []int{168, 168, 168, 172, 172, 172, 180, 180, 180, 184, 184, 184, 192, 192, 192, 196},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := Calculate(test.attacker, test.defender, test.move, test.field, chart)
+			if !slices.Equal(result.Rolls, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, result.Rolls)
+			}
+		})
+	}
+}
+
+func TestCalculateImmune(t *testing.T) {
+	flying := neutral
+	flying.Types = []string{"flying"}
+	levitating := neutral
+	levitating.Ability = "levitate"
+
+	for _, defender := range []Pokemon{flying, levitating} {
+		result := Calculate(neutral, defender, tackle80, model.Damage_field{}, chart)
+		if result.Effectiveness != 0 || result.Max != 0 {
+			t.Errorf("expected no damage, got %v", result.Rolls)
+		}
+	}
+}
+
+func TestCalculateKOChance(t *testing.T) {
+	defender := neutral
+
+	defender.CurrentHP = 37
+	result := Calculate(neutral, defender, tackle80, model.Damage_field{}, chart)
+	if result.HitsToKO != 1 || result.KOChance != 1.0/16 {
+		t.Errorf("expected 6.25%% chance to OHKO, got %s", result.Description)
+	}
+
+	defender.CurrentHP = 62
+	result = Calculate(neutral, defender, tackle80, model.Damage_field{}, chart)
+	if result.HitsToKO != 2 || result.KOChance != 1 || result.Description != "guaranteed 2HKO" {
+		t.Errorf("expected guaranteed 2HKO, got %s", result.Description)
+	}
+}
+
+func TestApplyBoost(t *testing.T) {
+	tests := []struct{ boost, expected int }{
+		{0, 100}, {1, 150}, {2, 200}, {6, 400}, {-1, 66}, {-2, 50}, {-6, 25}, {8, 400},
+	}
+
+	for _, test := range tests {
+		if result := applyBoost(100, test.boost); result != test.expected {
+			t.Errorf("boost %d: expected %d, got %d", test.boost, test.expected, result)
+		}
+	}
+}
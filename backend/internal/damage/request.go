@@ -0,0 +1,57 @@
+package damage
+
+import (
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/stats"
+)
+
+// Validates both sides of the request and fills in their default IVs
+func ValidateRequest(req *model.Damage_request) error {
+	if len(req.Moves) == 0 || len(req.Moves) > MaxMoves {
+		return apierror.Validationf("between 1 and %d moves are required", MaxMoves)
+	}
+
+	sides := []struct {
+		name    string
+		pokemon *model.Damage_pokemon
+	}{
+		{"attacker", &req.Attacker},
+		{"defender", &req.Defender},
+	}
+
+	for _, side := range sides {
+		if side.pokemon.ID < 1 {
+			return apierror.Validationf("%s: pokemon id is required", side.name)
+		}
+
+		statReq := StatRequest(*side.pokemon)
+		if err := stats.ValidateRequest(&statReq); err != nil {
+			return apierror.Validationf("%s: %v", side.name, err)
+		}
+		side.pokemon.IVs = statReq.IVs
+
+		for _, stat := range model.StatNames[1:] {
+			boost := side.pokemon.Boosts.Get(stat)
+			if boost < -MaxBoost || boost > MaxBoost {
+				return apierror.Validationf("%s: boost of %s must be between -%d and %d", side.name, stat, MaxBoost, MaxBoost)
+			}
+		}
+		if side.pokemon.CurrentHP < 0 {
+			return apierror.Validationf("%s: current hp can't be negative", side.name)
+		}
+	}
+
+	return nil
+}
+
+// Stat calculation of one side, damage is always calculated with the standard formula
+func StatRequest(p model.Damage_pokemon) model.Stat_calculation_request {
+	return model.Stat_calculation_request{
+		Level:   p.Level,
+		Nature:  p.Nature,
+		Formula: stats.FormulaStandard,
+		IVs:     p.IVs,
+		EVs:     p.EVs,
+	}
+}
@@ -0,0 +1,23 @@
+package flavortext
+
+import (
+	"strings"
+)
+
+// PokeAPI keeps the line breaks of the game text, including form feeds between pages
+// and soft hyphens where a word is split over two lines
+var lineBreakReplacer = strings.NewReplacer(
+	"\u00ad\n", "",
+	"\u00ad\f", "",
+	"\u00ad", "",
+	"-\n", "-",
+	"-\f", "-",
+	"\f", " ",
+	"\n", " ",
+	"\r", " ",
+)
+
+// Turns the raw text into a single line with single spaces
+func Normalize(text string) string {
+	return strings.Join(strings.Fields(lineBreakReplacer.Replace(text)), " ")
+}
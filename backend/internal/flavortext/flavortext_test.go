@@ -0,0 +1,19 @@
+package flavortext
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"When several of\nthese POKéMON\ngather, their\felectricity could\nbuild and cause\nlightning storms.": "When several of these POKéMON gather, their electricity could build and cause lightning storms.",
+		"It stores elec\u00ad\ntricity in its cheeks.":                                                         "It stores electricity in its cheeks.",
+		"A strange seed was\nplanted on its\nback at birth.":                                                   "A strange seed was planted on its back at birth.",
+		"It learns self-\ndestruct.":                                                                           "It learns self-destruct.",
+		"  Extra   spaces\f\n":                                                                                 "Extra spaces",
+	}
+
+	for raw, expected := range tests {
+		if result := Normalize(raw); result != expected {
+			t.Errorf("%q: expected %q, got %q", raw, expected, result)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	maxDepth      = 10
+	maxComplexity = 2000
+	// Assumed length of lists without a limit argument, e.g. the types of a pokemon or the nodes of an evolution chain
+	defaultListSize = 5
+)
+
+// Rejects queries nesting deeper than maxDepth or costing more than maxComplexity. Every field costs 1,
+// the fields below a list are counted once per element. The document must already be validated
+func checkComplexity(schema graphql.Schema, doc *ast.Document, operationName string, variables map[string]any) error {
+	c := complexity{fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch d := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				operation = d
+			}
+		case *ast.FragmentDefinition:
+			c.fragments[d.Name.Value] = d
+		}
+	}
+	if operation == nil {
+		return nil
+	}
+
+	cost, err := c.cost(operation.SelectionSet, schema.QueryType(), 1)
+	if err != nil {
+		return err
+	}
+	if cost > maxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the maximum of %d, request fewer fields or lower the limits", cost, maxComplexity)
+	}
+
+	return nil
+}
+
+type complexity struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+func (c complexity) cost(set *ast.SelectionSet, parent *graphql.Object, depth int) (int, error) {
+	if depth > maxDepth {
+		return 0, fmt.Errorf("query depth exceeds the maximum of %d", maxDepth)
+	}
+
+	total := 0
+	for _, selection := range set.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			// Introspection is answered from the schema without touching the database
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			definition, ok := parent.Fields()[s.Name.Value]
+			if !ok {
+				continue
+			}
+
+			childCost := 0
+			if object := namedObject(definition.Type); object != nil && s.SelectionSet != nil {
+				var err error
+				if childCost, err = c.cost(s.SelectionSet, object, depth+1); err != nil {
+					return 0, err
+				}
+			}
+
+			if isList(definition.Type) {
+				childCost *= c.listSize(s, definition)
+			}
+			total += 1 + childCost
+
+		case *ast.InlineFragment:
+			cost, err := c.cost(s.SelectionSet, parent, depth)
+			if err != nil {
+				return 0, err
+			}
+			total += cost
+
+		case *ast.FragmentSpread:
+			fragment, ok := c.fragments[s.Name.Value]
+			if !ok {
+				continue
+			}
+			cost, err := c.cost(fragment.SelectionSet, parent, depth)
+			if err != nil {
+				return 0, err
+			}
+			total += cost
+		}
+	}
+
+	return total, nil
+}
+
+// Elements a list field returns at most, from its limit argument if it has one
+func (c complexity) listSize(field *ast.Field, definition *graphql.FieldDefinition) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+		switch v := argument.Value.(type) {
+		case *ast.IntValue:
+			if limit, err := strconv.Atoi(v.Value); err == nil {
+				return max(limit, 1)
+			}
+		case *ast.Variable:
+			// Variables decoded from json are numbers
+			switch limit := c.variables[v.Name.Value].(type) {
+			case int:
+				return max(limit, 1)
+			case float64:
+				return max(int(limit), 1)
+			}
+		}
+	}
+
+	for _, argument := range definition.Args {
+		if limit, ok := argument.DefaultValue.(int); ok && argument.Name() == "limit" {
+			return limit
+		}
+	}
+	return defaultListSize
+}
+
+func namedObject(t graphql.Type) *graphql.Object {
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			t = wrapped.OfType
+		case *graphql.Object:
+			return wrapped
+		default:
+			return nil
+		}
+	}
+}
+
+func isList(t graphql.Type) bool {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*graphql.List)
+	return ok
+}
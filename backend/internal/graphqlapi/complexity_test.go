@@ -0,0 +1,65 @@
+package graphqlapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func check(t *testing.T, query string, variables map[string]any) error {
+	t.Helper()
+
+	schema, err := NewSchema(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if validation := graphql.ValidateDocument(&schema, doc, nil); !validation.IsValid {
+		t.Fatalf("invalid query: %v", validation.Errors)
+	}
+
+	return checkComplexity(schema, doc, "", variables)
+}
+
+func TestComplexityAllowsTypicalQueries(t *testing.T) {
+	queries := []string{
+		`{ pokemon(id: 6) { name stats { name baseStat } types { name doubleDamageFrom { name } } } }`,
+		`{ pokemons(limit: 100) { id name spriteUrl types { name } } }`,
+		`query Chain { pokemon(name: "eevee") { evolutionChain { root { pokemon { name } evolvesTo { summary pokemon { name spriteUrl } } } } } }`,
+		`{ pokemon(id: 25) { ...names } } fragment names on Pokemon { name localizedName(language: "de") }`,
+	}
+	for _, query := range queries {
+		if err := check(t, query, nil); err != nil {
+			t.Errorf("%s: %v", query, err)
+		}
+	}
+}
+
+func TestComplexityRejectsExpensiveQueries(t *testing.T) {
+	err := check(t, `{ pokemons(limit: 100) { moves(limit: 100) { name type { name } } } }`, nil)
+	if err == nil || !strings.Contains(err.Error(), "complexity") {
+		t.Errorf("expected complexity error, got %v", err)
+	}
+
+	// Limits given as variables count as well
+	query := `query Moves($limit: Int) { pokemons(limit: 100) { moves(limit: $limit) { name type { name } } } }`
+	if err := check(t, query, map[string]any{"limit": float64(100)}); err == nil {
+		t.Error("expected complexity error for the limit variable")
+	}
+	if err := check(t, query, map[string]any{"limit": float64(1)}); err != nil {
+		t.Errorf("expected a limit of 1 to be allowed, got %v", err)
+	}
+}
+
+func TestComplexityRejectsDeepQueries(t *testing.T) {
+	query := `{ pokemon(id: 1) { species { varieties { species { varieties { species { varieties { species { varieties { species { id } } } } } } } } } } }`
+	err := check(t, query, nil)
+	if err == nil || !strings.Contains(err.Error(), "depth") {
+		t.Errorf("expected depth error, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package graphqlapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"poke-atlas/web-service/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Serves GraphQL queries sent as json body or, for GET requests, as query, operationName and variables parameters
+func NewHandler(repo repository.Repository) (gin.HandlerFunc, error) {
+	schema, err := NewSchema(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		var req request
+		if c.Request.Method == http.MethodGet {
+			req.Query = c.Query("query")
+			req.OperationName = c.Query("operationName")
+			if variables := c.Query("variables"); variables != "" {
+				if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+					respondErrors(c, gqlerrors.FormatErrors(err))
+					return
+				}
+			}
+		} else if err := c.ShouldBindJSON(&req); err != nil {
+			respondErrors(c, gqlerrors.FormatErrors(err))
+			return
+		}
+
+		doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+		if err != nil {
+			respondErrors(c, gqlerrors.FormatErrors(err))
+			return
+		}
+
+		if validation := graphql.ValidateDocument(&schema, doc, nil); !validation.IsValid {
+			respondErrors(c, validation.Errors)
+			return
+		}
+
+		if err := checkComplexity(schema, doc, req.OperationName, req.Variables); err != nil {
+			respondErrors(c, gqlerrors.FormatErrors(err))
+			return
+		}
+
+		result := graphql.Execute(graphql.ExecuteParams{
+			Schema:        schema,
+			AST:           doc,
+			OperationName: req.OperationName,
+			Args:          req.Variables,
+			Context:       withLoaders(c.Request.Context(), repo),
+		})
+
+		// Errors of single fields come with the data of the rest of the query
+		c.JSON(http.StatusOK, result)
+	}, nil
+}
+
+// Requests that can't be executed at all, the errors are in the usual GraphQL response format
+func respondErrors(c *gin.Context, errs []gqlerrors.FormattedError) {
+	c.JSON(http.StatusBadRequest, graphql.Result{Errors: errs})
+}
@@ -0,0 +1,81 @@
+package graphqlapi
+
+import (
+	"slices"
+	"sync"
+)
+
+// Batches the keys requested by the resolvers of one level of a query into a single fetch and caches the results
+// for the rest of the request. Resolvers return the thunk of Load, graphql-go calls the thunks only after every field
+// of the level has been resolved, so the first thunk called fetches the keys of all of them
+type loader[K comparable, V any] struct {
+	mu      sync.Mutex
+	fetch   func(keys []K) (map[K]V, error)
+	pending []K
+	results map[K]V
+	errs    map[K]error
+}
+
+func newLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:   fetch,
+		results: map[K]V{},
+		errs:    map[K]error{},
+	}
+}
+
+// Queues the key and returns a thunk resolving to its value, or to nil if the fetch didn't return the key
+func (l *loader[K, V]) Load(key K) func() (any, error) {
+	l.mu.Lock()
+	_, loaded := l.results[key]
+	_, failed := l.errs[key]
+	if !loaded && !failed && !slices.Contains(l.pending, key) {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		value, ok, err := l.get(key)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// Caches a value loaded some other way, e.g. the pokemon of a listing
+func (l *loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.results[key] = value
+}
+
+// Value of the key, fetching the pending keys first if the key is one of them
+func (l *loader[K, V]) get(key K) (V, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if slices.Contains(l.pending, key) {
+		keys := l.pending
+		l.pending = nil
+
+		results, err := l.fetch(keys)
+		for _, k := range keys {
+			if err != nil {
+				l.errs[k] = err
+				continue
+			}
+			if value, ok := results[k]; ok {
+				l.results[k] = value
+			}
+		}
+	}
+
+	if err, ok := l.errs[key]; ok {
+		var zero V
+		return zero, false, err
+	}
+	value, ok := l.results[key]
+	return value, ok, nil
+}
@@ -0,0 +1,60 @@
+package graphqlapi
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLoaderBatchesKeys(t *testing.T) {
+	var batches [][]int
+	l := newLoader(func(keys []int) (map[int]string, error) {
+		batches = append(batches, keys)
+		results := map[int]string{}
+		for _, k := range keys {
+			if k != 3 {
+				results[k] = string(rune('a' + k))
+			}
+		}
+		return results, nil
+	})
+
+	one, two, oneAgain, missing := l.Load(1), l.Load(2), l.Load(1), l.Load(3)
+
+	if value, err := one(); value != "b" || err != nil {
+		t.Errorf("expected b, got %v %v", value, err)
+	}
+	if value, _ := two(); value != "c" {
+		t.Errorf("expected c, got %v", value)
+	}
+	if value, _ := oneAgain(); value != "b" {
+		t.Errorf("expected b, got %v", value)
+	}
+	if value, err := missing(); value != nil || err != nil {
+		t.Errorf("expected nil for a missing key, got %v %v", value, err)
+	}
+
+	// Cached keys aren't fetched again
+	if value, _ := l.Load(2)(); value != "c" {
+		t.Errorf("expected c, got %v", value)
+	}
+
+	if len(batches) != 1 || !slices.Equal(batches[0], []int{1, 2, 3}) {
+		t.Errorf("expected a single batch of 1, 2 and 3, got %v", batches)
+	}
+}
+
+func TestLoaderError(t *testing.T) {
+	fetchErr := errors.New("database is gone")
+	l := newLoader(func(keys []string) (map[string]int, error) {
+		return nil, fetchErr
+	})
+
+	a, b := l.Load("a"), l.Load("b")
+	if _, err := a(); !errors.Is(err, fetchErr) {
+		t.Errorf("expected the fetch error, got %v", err)
+	}
+	if _, err := b(); !errors.Is(err, fetchErr) {
+		t.Errorf("expected the fetch error for every key of the batch, got %v", err)
+	}
+}
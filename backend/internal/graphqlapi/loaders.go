@@ -0,0 +1,202 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/parallel"
+	"poke-atlas/web-service/internal/pokeapi"
+	"poke-atlas/web-service/internal/repository"
+)
+
+// Loaders of one request, resolvers find them in the context
+type loaders struct {
+	summaries      *loader[int, model.Pokemon_summary]
+	details        *loader[int, model.Pokemon_details]
+	learnsets      *loader[int, []string]
+	abilities      *loader[int, []string]
+	speciesIDs     *loader[int, int]
+	species        *loader[int, model.Species_varieties]
+	evolutionTrees *loader[int, model.Evolution_tree]
+	moves          *loader[string, model.Move_details]
+	typeChart      *loader[struct{}, model.Type_chart]
+	names          *loader[nameKey, string]
+}
+
+// A localized name, pokemon are given by id and everything else by name
+type nameKey struct {
+	Language  string
+	Resource  string
+	Name      string
+	PokemonID int
+}
+
+const (
+	resourcePokemon = "pokemon"
+	resourceType    = "type"
+	resourceMove    = "move"
+	resourceAbility = "ability"
+)
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, repo repository.Repository) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(ctx, repo))
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func newLoaders(ctx context.Context, repo repository.Repository) *loaders {
+	return &loaders{
+		// Stored pokemon are read in a single query, see GetPokemonBatch
+		summaries: newLoader(func(ids []int) (map[int]model.Pokemon_summary, error) {
+			result, err := repo.GetPokemonBatch(ctx, model.Pokemon_batch_request{IDs: ids})
+			if err != nil {
+				return nil, err
+			}
+			summaries := map[int]model.Pokemon_summary{}
+			for _, pokemon := range result.Pokemon {
+				summaries[pokemon.ID] = pokemon
+			}
+			return summaries, nil
+		}),
+		// Details are assembled from several tables and fetches, so they are only deduplicated
+		details: newLoader(func(ids []int) (map[int]model.Pokemon_details, error) {
+			return loadEach(ids, func(id int) (model.Pokemon_details, error) {
+				return repo.GetPokemonDetailed(ctx, id)
+			})
+		}),
+		learnsets: newLoader(func(ids []int) (map[int][]string, error) {
+			learnsets, err := repo.GetPokemonMoveNames(ctx, ids)
+			return withEmptyLists(ids, learnsets), err
+		}),
+		abilities: newLoader(func(ids []int) (map[int][]string, error) {
+			abilities, err := repo.GetPokemonAbilityNames(ctx, ids)
+			return withEmptyLists(ids, abilities), err
+		}),
+		speciesIDs: newLoader(func(ids []int) (map[int]int, error) {
+			return repo.GetPokemonSpeciesIDs(ctx, ids)
+		}),
+		species: newLoader(func(ids []int) (map[int]model.Species_varieties, error) {
+			return loadEach(ids, func(id int) (model.Species_varieties, error) {
+				return repo.GetSpeciesVarieties(ctx, id)
+			})
+		}),
+		evolutionTrees: newLoader(func(ids []int) (map[int]model.Evolution_tree, error) {
+			return loadEach(ids, func(id int) (model.Evolution_tree, error) {
+				return repo.GetPokemonEvolutionTree(ctx, id)
+			})
+		}),
+		moves: newLoader(func(names []string) (map[string]model.Move_details, error) {
+			return repo.GetMoves(ctx, names)
+		}),
+		typeChart: newLoader(func([]struct{}) (map[struct{}]model.Type_chart, error) {
+			chart, err := repo.GetTypeChart(ctx)
+			return map[struct{}]model.Type_chart{{}: chart}, err
+		}),
+		names: newLoader(func(keys []nameKey) (map[nameKey]string, error) {
+			return loadNames(ctx, repo, keys)
+		}),
+	}
+}
+
+// Pokemon without any moves or abilities are left out of the lookups, but still known
+func withEmptyLists(ids []int, lists map[int][]string) map[int][]string {
+	if lists == nil {
+		return nil
+	}
+	for _, id := range ids {
+		if _, ok := lists[id]; !ok {
+			lists[id] = []string{}
+		}
+	}
+	return lists
+}
+
+// Maximum resources loaded one by one at the same time, like the repository's concurrent fetches
+const maxConcurrentLoads = 5
+
+// Loads the keys one at a time for resources without a batch lookup, unknown keys are left out
+func loadEach[K comparable, V any](keys []K, load func(K) (V, error)) (map[K]V, error) {
+	values, errs := parallel.Map(keys, maxConcurrentLoads, load)
+
+	results := map[K]V{}
+	for i, key := range keys {
+		if errors.Is(errs[i], pokeapi.ErrNotFound) {
+			continue
+		}
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		results[key] = values[i]
+	}
+
+	return results, nil
+}
+
+// Localized names of the keys with one GetLocalizedNames call per language
+func loadNames(ctx context.Context, repo repository.Repository, keys []nameKey) (map[nameKey]string, error) {
+	refs := map[string]*repository.NameRefs{}
+	for _, key := range keys {
+		if refs[key.Language] == nil {
+			refs[key.Language] = &repository.NameRefs{}
+		}
+		r := refs[key.Language]
+		switch key.Resource {
+		case resourcePokemon:
+			r.PokemonIDs = append(r.PokemonIDs, key.PokemonID)
+		case resourceType:
+			r.Types = append(r.Types, key.Name)
+		case resourceMove:
+			r.Moves = append(r.Moves, key.Name)
+		case resourceAbility:
+			r.Abilities = append(r.Abilities, key.Name)
+		}
+	}
+
+	results := map[nameKey]string{}
+	for language, r := range refs {
+		names, err := repo.GetLocalizedNames(ctx, language, *r)
+		if err != nil {
+			return nil, err
+		}
+
+		// Pokemon names are keyed by pokemon name, which the summaries map back to the id
+		var pokemonNames map[int]string
+		if len(r.PokemonIDs) > 0 {
+			result, err := repo.GetPokemonBatch(ctx, model.Pokemon_batch_request{IDs: r.PokemonIDs})
+			if err != nil {
+				return nil, err
+			}
+			pokemonNames = map[int]string{}
+			for _, pokemon := range result.Pokemon {
+				pokemonNames[pokemon.ID] = pokemon.Name
+			}
+		}
+
+		for _, key := range keys {
+			if key.Language != language {
+				continue
+			}
+			var name string
+			var ok bool
+			switch key.Resource {
+			case resourcePokemon:
+				name, ok = names.Pokemon[pokemonNames[key.PokemonID]]
+			case resourceType:
+				name, ok = names.Types[key.Name]
+			case resourceMove:
+				name, ok = names.Moves[key.Name]
+			case resourceAbility:
+				name, ok = names.Abilities[key.Name]
+			}
+			if ok {
+				results[key] = name
+			}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,416 @@
+package graphqlapi
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/pokeapi"
+	"poke-atlas/web-service/internal/repository"
+	"slices"
+
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// Sources of the resolvers: pokemon and species are their id, types, moves and abilities their name,
+// evolution chains and their nodes the repository models
+
+// Builds the schema, the loaders are expected in the context of the request, see withLoaders
+func NewSchema(repo repository.Repository) (graphql.Schema, error) {
+	var pokemonType, speciesType, typeType, moveType, evolutionNodeType *graphql.Object
+
+	nonNullList := func(t graphql.Type) graphql.Type {
+		return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+	}
+	languageArgs := graphql.FieldConfigArgument{
+		"language": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "pokeapi language code, e.g. de or ja-Hrkt"},
+	}
+	limitArgs := graphql.FieldConfigArgument{
+		"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+		"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultListLimit},
+	}
+
+	statType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Stat",
+		Fields: graphql.Fields{
+			"name":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: value(func(s stat) any { return s.Name })},
+			"baseStat": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: value(func(s stat) any { return s.BaseStat })},
+			"effort":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: value(func(s stat) any { return s.Effort })},
+		},
+	})
+
+	criesType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Cries",
+		Description: "Paths of the cries served by the REST api",
+		Fields: graphql.Fields{
+			"latest": &graphql.Field{Type: graphql.String, Resolve: value(func(c model.Pokemon_cries) any { return optional(c.Latest) })},
+			"legacy": &graphql.Field{Type: graphql.String, Resolve: value(func(c model.Pokemon_cries) any { return optional(c.Legacy) })},
+		},
+	})
+
+	abilityType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Ability",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: value(func(name string) any { return name })},
+			"localizedName": &graphql.Field{Type: graphql.String, Args: languageArgs, Resolve: func(p graphql.ResolveParams) (any, error) {
+				return loadersFrom(p.Context).names.Load(nameKey{Language: p.Args["language"].(string), Resource: resourceAbility, Name: p.Source.(string)}), nil
+			}},
+		},
+	})
+
+	typeType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Type",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			relation := func(attacking bool, factor int) *graphql.Field {
+				return &graphql.Field{Type: nonNullList(typeType), Resolve: func(p graphql.ResolveParams) (any, error) {
+					name := p.Source.(string)
+					return field(loadersFrom(p.Context).typeChart, struct{}{}, func(chart model.Type_chart) any {
+						return damageRelation(chart, name, attacking, factor)
+					}), nil
+				}}
+			}
+
+			return graphql.Fields{
+				"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: value(func(name string) any { return name })},
+				"localizedName": &graphql.Field{Type: graphql.String, Args: languageArgs, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadersFrom(p.Context).names.Load(nameKey{Language: p.Args["language"].(string), Resource: resourceType, Name: p.Source.(string)}), nil
+				}},
+				"doubleDamageTo":   relation(true, 200),
+				"halfDamageTo":     relation(true, 50),
+				"noDamageTo":       relation(true, 0),
+				"doubleDamageFrom": relation(false, 200),
+				"halfDamageFrom":   relation(false, 50),
+				"noDamageFrom":     relation(false, 0),
+			}
+		}),
+	})
+
+	moveType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Move",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			moveField := func(t graphql.Output, get func(model.Move_details) any) *graphql.Field {
+				return &graphql.Field{Type: t, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return field(loadersFrom(p.Context).moves, p.Source.(string), get), nil
+				}}
+			}
+
+			return graphql.Fields{
+				"id":          moveField(graphql.Int, func(m model.Move_details) any { return m.ID }),
+				"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: value(func(name string) any { return name })},
+				"type":        moveField(typeType, func(m model.Move_details) any { return m.Type }),
+				"damageClass": moveField(graphql.String, func(m model.Move_details) any { return m.DamageClass }),
+				"target":      moveField(graphql.String, func(m model.Move_details) any { return m.Target }),
+				"power":       moveField(graphql.Int, func(m model.Move_details) any { return m.Power }),
+				"accuracy":    moveField(graphql.Int, func(m model.Move_details) any { return m.Accuracy }),
+				"pp":          moveField(graphql.Int, func(m model.Move_details) any { return m.PP }),
+				"priority":    moveField(graphql.Int, func(m model.Move_details) any { return m.Priority }),
+				"shortEffect": moveField(graphql.String, func(m model.Move_details) any { return m.ShortEffect }),
+				"localizedName": &graphql.Field{Type: graphql.String, Args: languageArgs, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadersFrom(p.Context).names.Load(nameKey{Language: p.Args["language"].(string), Resource: resourceMove, Name: p.Source.(string)}), nil
+				}},
+			}
+		}),
+	})
+
+	evolutionChainType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "EvolutionChain",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":              &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: value(func(t model.Evolution_tree) any { return t.ChainID })},
+				"babyTriggerItem": &graphql.Field{Type: graphql.String, Resolve: value(func(t model.Evolution_tree) any { return optional(t.BabyTriggerItem) })},
+				"root":            &graphql.Field{Type: graphql.NewNonNull(evolutionNodeType), Resolve: value(func(t model.Evolution_tree) any { return t.Root })},
+			}
+		}),
+	})
+
+	evolutionNodeType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "EvolutionNode",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"pokemon": &graphql.Field{Type: graphql.NewNonNull(pokemonType), Resolve: value(func(n model.Evolution_node) any { return n.PokemonID })},
+				"isBaby":  &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: value(func(n model.Evolution_node) any { return n.IsBaby })},
+				"summary": &graphql.Field{
+					Type:        graphql.String,
+					Description: "How the pokemon evolves from its parent, e.g. \"Level 16\"",
+					Resolve:     value(func(n model.Evolution_node) any { return optional(n.Summary) }),
+				},
+				"evolvesTo": &graphql.Field{Type: nonNullList(evolutionNodeType), Resolve: value(func(n model.Evolution_node) any { return n.EvolvesTo })},
+			}
+		}),
+	})
+
+	speciesType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Species",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: value(func(id int) any { return id })},
+				"name": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return field(loadersFrom(p.Context).species, p.Source.(int), func(s model.Species_varieties) any { return s.Name }), nil
+				}},
+				"varieties": &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(pokemonType)), Resolve: func(p graphql.ResolveParams) (any, error) {
+					return field(loadersFrom(p.Context).species, p.Source.(int), func(s model.Species_varieties) any {
+						var ids []int
+						for _, variety := range s.Varieties {
+							ids = append(ids, variety.PokemonID)
+						}
+						return ids
+					}), nil
+				}},
+				// Species ids match the ids of their default pokemon
+				"evolutionChain": &graphql.Field{Type: evolutionChainType, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadersFrom(p.Context).evolutionTrees.Load(p.Source.(int)), nil
+				}},
+			}
+		}),
+	})
+
+	pokemonType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Pokemon",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			summaryField := func(t graphql.Output, get func(model.Pokemon_summary) any) *graphql.Field {
+				return &graphql.Field{Type: t, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return field(loadersFrom(p.Context).summaries, p.Source.(int), get), nil
+				}}
+			}
+			detailsField := func(t graphql.Output, get func(model.Pokemon_details) any) *graphql.Field {
+				return &graphql.Field{Type: t, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return field(loadersFrom(p.Context).details, p.Source.(int), get), nil
+				}}
+			}
+
+			return graphql.Fields{
+				"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: value(func(id int) any { return id })},
+				"name":      summaryField(graphql.String, func(s model.Pokemon_summary) any { return s.Name }),
+				"height":    summaryField(graphql.Int, func(s model.Pokemon_summary) any { return s.Height }),
+				"weight":    summaryField(graphql.Int, func(s model.Pokemon_summary) any { return s.Weight }),
+				"spriteUrl": summaryField(graphql.String, func(s model.Pokemon_summary) any { return optional(s.SpriteUrl) }),
+				"types":     summaryField(graphql.NewList(graphql.NewNonNull(typeType)), func(s model.Pokemon_summary) any { return s.Types }),
+				"stats": detailsField(graphql.NewList(graphql.NewNonNull(statType)), func(d model.Pokemon_details) any {
+					var stats []stat
+					for _, s := range d.Stats {
+						stats = append(stats, stat{Name: s.StatName, BaseStat: s.BaseStat, Effort: s.Effort})
+					}
+					return stats
+				}),
+				"cries": detailsField(criesType, func(d model.Pokemon_details) any { return d.Cries }),
+				"abilities": &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(abilityType)), Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadersFrom(p.Context).abilities.Load(p.Source.(int)), nil
+				}},
+				"moves": &graphql.Field{
+					Type:        graphql.NewList(graphql.NewNonNull(moveType)),
+					Description: "Moves the pokemon can learn in any version group, in alphabetical order",
+					Args:        limitArgs,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						offset, limit, err := pageArgs(p.Args)
+						if err != nil {
+							return nil, err
+						}
+						return field(loadersFrom(p.Context).learnsets, p.Source.(int), func(moves []string) any {
+							return moves[min(offset, len(moves)):min(offset+limit, len(moves))]
+						}), nil
+					},
+				},
+				"species": &graphql.Field{Type: speciesType, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadersFrom(p.Context).speciesIDs.Load(p.Source.(int)), nil
+				}},
+				"evolutionChain": &graphql.Field{Type: evolutionChainType, Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadersFrom(p.Context).evolutionTrees.Load(p.Source.(int)), nil
+				}},
+				"localizedName": &graphql.Field{
+					Type:        graphql.String,
+					Description: "Name of the species, varieties that aren't the default of their species have none",
+					Args:        languageArgs,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return loadersFrom(p.Context).names.Load(nameKey{Language: p.Args["language"].(string), Resource: resourcePokemon, PokemonID: p.Source.(int)}), nil
+					},
+				},
+			}
+		}),
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"pokemon": &graphql.Field{
+				Type:        pokemonType,
+				Description: "Pokemon by id or by name, names may be species names in any language",
+				Args: graphql.FieldConfigArgument{
+					"id":   &graphql.ArgumentConfig{Type: graphql.Int},
+					"name": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					l := loadersFrom(p.Context)
+					if name, ok := p.Args["name"].(string); ok {
+						pokemon, err := repo.GetPokemon(p.Context, name)
+						if errors.Is(err, pokeapi.ErrNotFound) {
+							return nil, nil
+						}
+						if err != nil {
+							return nil, err
+						}
+						l.summaries.Prime(pokemon.ID, pokemon)
+						return pokemon.ID, nil
+					}
+
+					id, ok := p.Args["id"].(int)
+					if !ok {
+						return nil, errors.New("either id or name is required")
+					}
+					return field(l.summaries, id, func(s model.Pokemon_summary) any { return s.ID }), nil
+				},
+			},
+			"pokemons": &graphql.Field{
+				Type:        nonNullList(pokemonType),
+				Description: "Pokemon by national dex number",
+				Args:        limitArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					offset, limit, err := pageArgs(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					pokemons, err := repo.GetPokemons(p.Context, offset, limit)
+					if err != nil {
+						return nil, err
+					}
+
+					l := loadersFrom(p.Context)
+					var ids []int
+					for _, pokemon := range pokemons {
+						l.summaries.Prime(pokemon.ID, pokemon)
+						ids = append(ids, pokemon.ID)
+					}
+					return ids, nil
+				},
+			},
+			"species": &graphql.Field{
+				Type: speciesType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(int)
+					return field(loadersFrom(p.Context).species, id, func(s model.Species_varieties) any { return s.SpeciesID }), nil
+				},
+			},
+			"type": &graphql.Field{
+				Type: typeType,
+				Args: graphql.FieldConfigArgument{"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					name := p.Args["name"].(string)
+					return field(loadersFrom(p.Context).typeChart, struct{}{}, func(chart model.Type_chart) any {
+						if !slices.Contains(chartTypes(chart), name) {
+							return nil
+						}
+						return name
+					}), nil
+				},
+			},
+			"types": &graphql.Field{
+				Type: nonNullList(typeType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return field(loadersFrom(p.Context).typeChart, struct{}{}, func(chart model.Type_chart) any { return chartTypes(chart) }), nil
+				},
+			},
+			"move": &graphql.Field{
+				Type: moveType,
+				Args: graphql.FieldConfigArgument{"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return field(loadersFrom(p.Context).moves, p.Args["name"].(string), func(m model.Move_details) any { return m.Name }), nil
+				},
+			},
+			"evolutionChain": &graphql.Field{
+				Type: evolutionChainType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					tree, err := repo.GetEvolutionTree(p.Context, p.Args["id"].(int))
+					if errors.Is(err, pokeapi.ErrNotFound) {
+						return nil, nil
+					}
+					return tree, err
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+type stat struct {
+	Name     string
+	BaseStat int
+	Effort   int
+}
+
+// Resolver reading the field from the source
+func value[T any](get func(T) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(T)), nil
+	}
+}
+
+// Thunk resolving to the field of the loaded value, or to nil if the key is unknown
+func field[K comparable, V any](l *loader[K, V], key K, get func(V) any) func() (any, error) {
+	load := l.Load(key)
+	return func() (any, error) {
+		v, err := load()
+		if err != nil || v == nil {
+			return nil, err
+		}
+		return get(v.(V)), nil
+	}
+}
+
+// Empty strings are null
+func optional(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func pageArgs(args map[string]any) (int, int, error) {
+	offset, _ := args["offset"].(int)
+	limit, _ := args["limit"].(int)
+	if offset < 0 {
+		return 0, 0, errors.New("offset cannot be negative")
+	}
+	if limit < 1 || limit > maxListLimit {
+		return 0, 0, fmt.Errorf("limit must be between 1 and %d", maxListLimit)
+	}
+	return offset, limit, nil
+}
+
+// Types the type deals the damage factor to when attacking, or receives it from when defending
+func damageRelation(chart model.Type_chart, name string, attacking bool, factor int) []string {
+	related := []string{}
+	for _, other := range chartTypes(chart) {
+		attacker, defender := name, other
+		if !attacking {
+			attacker, defender = other, name
+		}
+
+		f, ok := chart[attacker][defender]
+		if !ok {
+			f = 100
+		}
+		if f == factor {
+			related = append(related, other)
+		}
+	}
+	return related
+}
+
+// Every type appearing in the chart, types without any matchups like stellar are left out
+func chartTypes(chart model.Type_chart) []string {
+	types := map[string]bool{}
+	for attacking, defending := range chart {
+		types[attacking] = true
+		for t := range defending {
+			types[t] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(types))
+}
@@ -0,0 +1,75 @@
+package grpcapi
+
+import (
+	atlasv1 "poke-atlas/web-service/api/atlas/v1"
+	"poke-atlas/web-service/internal/model"
+)
+
+func toPokemon(pokemon model.Pokemon_summary) *atlasv1.Pokemon {
+	return &atlasv1.Pokemon{
+		Id:        int32(pokemon.ID),
+		Name:      pokemon.Name,
+		Weight:    int32(pokemon.Weight),
+		Height:    int32(pokemon.Height),
+		SpriteUrl: pokemon.SpriteUrl,
+		Types:     pokemon.Types,
+	}
+}
+
+func toPokemonDetails(pokemon model.Pokemon_details) *atlasv1.PokemonDetails {
+	details := &atlasv1.PokemonDetails{
+		Id:        int32(pokemon.ID),
+		Name:      pokemon.Name,
+		Weight:    int32(pokemon.Weight),
+		Height:    int32(pokemon.Height),
+		SpriteUrl: pokemon.SpriteUrl,
+		Types:     pokemon.Types,
+		Cries: &atlasv1.Cries{
+			Latest: pokemon.Cries.Latest,
+			Legacy: pokemon.Cries.Legacy,
+		},
+	}
+
+	for _, stat := range pokemon.Stats {
+		details.Stats = append(details.Stats, &atlasv1.Stat{
+			Name:     stat.StatName,
+			BaseStat: int32(stat.BaseStat),
+			Effort:   int32(stat.Effort),
+		})
+	}
+
+	for _, evolution := range pokemon.EvolutionChain {
+		details.Evolutions = append(details.Evolutions, &atlasv1.Evolution{
+			PokemonId:     int32(evolution.PokemonID),
+			PokemonName:   evolution.PokemonName,
+			EvolvesToId:   int32(evolution.EvolvesToID),
+			EvolvesToName: evolution.EvolvesToName,
+			Summary:       evolution.Summary,
+		})
+	}
+
+	for _, item := range pokemon.HeldItems {
+		heldItem := &atlasv1.HeldItem{Item: item.Item, SpriteUrl: item.SpriteUrl}
+		for _, version := range item.Versions {
+			heldItem.Versions = append(heldItem.Versions, &atlasv1.HeldItemVersion{
+				Version: version.Version,
+				Rarity:  int32(version.Rarity),
+			})
+		}
+		details.HeldItems = append(details.HeldItems, heldItem)
+	}
+
+	for _, form := range pokemon.Forms {
+		details.Forms = append(details.Forms, &atlasv1.Form{
+			FormId:    int32(form.FormID),
+			PokemonId: int32(form.PokemonID),
+			Name:      form.Name,
+			FormName:  form.FormName,
+			Category:  form.Category,
+			IsDefault: form.IsDefault,
+			SpriteUrl: form.SpriteUrl,
+		})
+	}
+
+	return details
+}
@@ -0,0 +1,168 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	atlasv1 "poke-atlas/web-service/api/atlas/v1"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/pokeapi"
+	"poke-atlas/web-service/internal/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+	// Streams cover the national dex and its forms, pages are loaded one at a time
+	maxStreamLimit = 2000
+	streamPageSize = 100
+)
+
+// Serves the AtlasService with the same repository as the REST api
+type Server struct {
+	atlasv1.UnimplementedAtlasServiceServer
+	repo repository.Repository
+}
+
+func NewServer(repository repository.Repository) *Server {
+	return &Server{
+		repo: repository,
+	}
+}
+
+func (s *Server) GetPokemon(ctx context.Context, req *atlasv1.GetPokemonRequest) (*atlasv1.Pokemon, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	pokemon, err := s.repo.GetPokemon(ctx, req.GetName())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toPokemon(pokemon), nil
+}
+
+func (s *Server) ListPokemons(ctx context.Context, req *atlasv1.ListPokemonsRequest) (*atlasv1.ListPokemonsResponse, error) {
+	limit, err := limitParam(req.GetLimit(), defaultLimit, maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset cannot be negative")
+	}
+
+	pokemons, err := s.repo.GetPokemons(ctx, int(req.GetOffset()), limit)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	response := &atlasv1.ListPokemonsResponse{}
+	for _, pokemon := range pokemons {
+		response.Pokemons = append(response.Pokemons, toPokemon(pokemon))
+	}
+	return response, nil
+}
+
+func (s *Server) StreamPokemons(req *atlasv1.StreamPokemonsRequest, stream atlasv1.AtlasService_StreamPokemonsServer) error {
+	limit, err := limitParam(req.GetLimit(), maxStreamLimit, maxStreamLimit)
+	if err != nil {
+		return err
+	}
+	if req.GetOffset() < 0 {
+		return status.Error(codes.InvalidArgument, "offset cannot be negative")
+	}
+
+	ctx := stream.Context()
+	end := int(req.GetOffset()) + limit
+
+	for offset := int(req.GetOffset()); offset < end; offset += streamPageSize {
+		pageSize := min(streamPageSize, end-offset)
+
+		pokemons, err := s.repo.GetPokemons(ctx, offset, pageSize)
+		// Pages past the last pokemon are not found
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return statusError(err)
+		}
+
+		for _, pokemon := range pokemons {
+			if err := stream.Send(toPokemon(pokemon)); err != nil {
+				return err
+			}
+		}
+
+		if len(pokemons) < pageSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) GetPokemonDetailed(ctx context.Context, req *atlasv1.GetPokemonDetailedRequest) (*atlasv1.PokemonDetails, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be greater than 0")
+	}
+
+	pokemon, err := s.repo.GetPokemonDetailed(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toPokemonDetails(pokemon), nil
+}
+
+func (s *Server) SearchLocations(ctx context.Context, req *atlasv1.SearchLocationsRequest) (*atlasv1.SearchLocationsResponse, error) {
+	limit, err := limitParam(req.GetLimit(), defaultLimit, maxLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := s.repo.SearchLocations(ctx, req.GetSearch(), limit)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	response := &atlasv1.SearchLocationsResponse{}
+	for _, location := range locations {
+		response.Locations = append(response.Locations, &atlasv1.Location{
+			Id:     int32(location.ID),
+			Name:   location.Name,
+			Region: location.Region,
+		})
+	}
+	return response, nil
+}
+
+// Limit of a request, 0 means the default
+func limitParam(limit int32, defaultLimit int, maxLimit int) (int, error) {
+	if limit == 0 {
+		return defaultLimit, nil
+	}
+	if limit < 0 || int(limit) > maxLimit {
+		return 0, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxLimit)
+	}
+	return int(limit), nil
+}
+
+// Maps repository errors to status codes like the REST handlers map them to http statuses
+func statusError(err error) error {
+	switch {
+	case errors.As(err, &apierror.ValidationError{}):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, pokeapi.ErrNotFound), errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
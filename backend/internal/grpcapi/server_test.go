@@ -0,0 +1,196 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	atlasv1 "poke-atlas/web-service/api/atlas/v1"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/pokeapi"
+	"poke-atlas/web-service/internal/repository"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Repository with a fixed list of pokemon, only the methods the pokemon rpcs use are implemented
+type fakeRepository struct {
+	repository.Repository
+	pokemons []model.Pokemon_summary
+	// Offset and limit of every GetPokemons call
+	pages [][2]int
+}
+
+func (r *fakeRepository) GetPokemon(ctx context.Context, name string) (model.Pokemon_summary, error) {
+	for _, pokemon := range r.pokemons {
+		if pokemon.Name == name {
+			return pokemon, nil
+		}
+	}
+	return model.Pokemon_summary{}, fmt.Errorf("pokemon %s: %w", name, pokeapi.ErrNotFound)
+}
+
+func (r *fakeRepository) GetPokemons(ctx context.Context, offset int, limit int) ([]model.Pokemon_summary, error) {
+	r.pages = append(r.pages, [2]int{offset, limit})
+	if offset >= len(r.pokemons) {
+		return nil, pokeapi.ErrNotFound
+	}
+	return r.pokemons[offset:min(offset+limit, len(r.pokemons))], nil
+}
+
+func newFakeRepository(count int) *fakeRepository {
+	repo := &fakeRepository{}
+	for i := range count {
+		repo.pokemons = append(repo.pokemons, model.Pokemon_summary{ID: i + 1, Name: fmt.Sprintf("pokemon-%d", i+1), Types: []string{"normal"}})
+	}
+	return repo
+}
+
+// Client of a server listening in memory, both are stopped when the test ends
+func newTestClient(t *testing.T, repo repository.Repository) atlasv1.AtlasServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	atlasv1.RegisterAtlasServiceServer(server, NewServer(repo))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return atlasv1.NewAtlasServiceClient(conn)
+}
+
+func TestGetPokemon(t *testing.T) {
+	client := newTestClient(t, newFakeRepository(3))
+	ctx := context.Background()
+
+	pokemon, err := client.GetPokemon(ctx, &atlasv1.GetPokemonRequest{Name: "pokemon-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pokemon.GetId() != 2 || pokemon.GetName() != "pokemon-2" || len(pokemon.GetTypes()) != 1 {
+		t.Errorf("unexpected pokemon %v", pokemon)
+	}
+
+	if _, err := client.GetPokemon(ctx, &atlasv1.GetPokemonRequest{Name: "missingno"}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown pokemon code = %v, want NotFound", status.Code(err))
+	}
+	if _, err := client.GetPokemon(ctx, &atlasv1.GetPokemonRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty name code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestListPokemons(t *testing.T) {
+	repo := newFakeRepository(30)
+	client := newTestClient(t, repo)
+	ctx := context.Background()
+
+	response, err := client.ListPokemons(ctx, &atlasv1.ListPokemonsRequest{Offset: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.GetPokemons()) != defaultLimit || response.GetPokemons()[0].GetId() != 6 {
+		t.Errorf("expected %d pokemon from id 6, got %v", defaultLimit, response.GetPokemons())
+	}
+
+	for _, invalid := range []*atlasv1.ListPokemonsRequest{{Offset: -1}, {Limit: maxLimit + 1}} {
+		if _, err := client.ListPokemons(ctx, invalid); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v code = %v, want InvalidArgument", invalid, status.Code(err))
+		}
+	}
+}
+
+func TestStreamPokemons(t *testing.T) {
+	repo := newFakeRepository(230)
+	client := newTestClient(t, repo)
+
+	stream, err := client.StreamPokemons(context.Background(), &atlasv1.StreamPokemonsRequest{Offset: 10, Limit: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int32
+	for {
+		pokemon, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, pokemon.GetId())
+	}
+
+	if len(ids) != 220 || ids[0] != 11 || ids[len(ids)-1] != 230 {
+		t.Errorf("expected pokemon 11 to 230, got %d starting at %v", len(ids), ids[:min(1, len(ids))])
+	}
+	// The short third page ends the stream without asking for another one
+	expectedPages := [][2]int{{10, streamPageSize}, {110, streamPageSize}, {210, streamPageSize}}
+	if fmt.Sprint(repo.pages) != fmt.Sprint(expectedPages) {
+		t.Errorf("expected pages %v, got %v", expectedPages, repo.pages)
+	}
+}
+
+func TestStreamPokemonsPastTheEnd(t *testing.T) {
+	client := newTestClient(t, newFakeRepository(5))
+
+	stream, err := client.StreamPokemons(context.Background(), &atlasv1.StreamPokemonsRequest{Offset: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected an empty stream, got %v", err)
+	}
+}
+
+func TestLimitParam(t *testing.T) {
+	limit, err := limitParam(0, defaultLimit, maxLimit)
+	if err != nil || limit != defaultLimit {
+		t.Fatalf("limitParam(0) = %d, %v", limit, err)
+	}
+
+	limit, err = limitParam(maxLimit, defaultLimit, maxLimit)
+	if err != nil || limit != maxLimit {
+		t.Fatalf("limitParam(%d) = %d, %v", maxLimit, limit, err)
+	}
+
+	for _, invalid := range []int32{-1, maxLimit + 1} {
+		if _, err := limitParam(invalid, defaultLimit, maxLimit); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("limitParam(%d) code = %v, want InvalidArgument", invalid, status.Code(err))
+		}
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{fmt.Errorf("stats: %w", apierror.ValidationError{Message: "invalid"}), codes.InvalidArgument},
+		{fmt.Errorf("pokemon 99999: %w", pokeapi.ErrNotFound), codes.NotFound},
+		{sql.ErrNoRows, codes.NotFound},
+		{errors.New("database is locked"), codes.Internal},
+	}
+
+	for _, test := range tests {
+		if code := status.Code(statusError(test.err)); code != test.code {
+			t.Errorf("statusError(%v) code = %v, want %v", test.err, code, test.code)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/damage"
+	"poke-atlas/web-service/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CalculateDamageHandler(c *gin.Context) {
+	var req model.Damage_request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	if err := damage.ValidateRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.repo.CalculateDamage(c.Request.Context(), req)
+
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/compare"
+	"poke-atlas/web-service/internal/pokeapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ComparePokemonHandler(c *gin.Context) {
+	// Comma separated, e.g. ?ids=6,9,3
+	ids, err := compare.ParseIDs(c.Query("ids"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comparison, err := h.repo.ComparePokemon(c.Request.Context(), ids)
+
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pokemon not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/saves"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateSaveHandler(c *gin.Context) {
+	var save model.Save
+	if err := c.ShouldBindJSON(&save); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	if err := saves.ValidateSave(&save); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.repo.CreateSave(c.Request.Context(), save)
+
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/stats"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) EstimateIVsHandler(c *gin.Context) {
+	id, ok := h.pokemonIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req model.Iv_range_request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	if err := stats.ValidateIVRangeRequest(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.repo.EstimateIVs(c.Request.Context(), id, req)
+
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ExportTeamHandler(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Showdown's paste format is the only export format so far
+	format := c.DefaultQuery("format", "showdown")
+	if format != "showdown" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported format " + format})
+		return
+	}
+
+	text, err := h.repo.ExportTeam(c.Request.Context(), id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.String(http.StatusOK, text)
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/cries"
+	"poke-atlas/web-service/internal/pokeapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetCryHandler(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	cry, err := h.repo.GetCry(c.Request.Context(), id, c.DefaultQuery("variant", cries.Latest))
+
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pokemon doesn't have this cry variant"})
+		return
+	}
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pokemon not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	serveCachedMedia(c, cry.Path, cry.Hash, cry.ContentType)
+}
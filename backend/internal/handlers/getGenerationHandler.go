@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetGenerationHandler(c *gin.Context) {
+	// Accepts the id like 1 or the name like generation-i
+	generation := c.Param("id")
+
+	details, err := h.repo.GetGeneration(c.Request.Context(), generation)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "generation not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetLocationAreaHandler(c *gin.Context) {
+	name := c.Param("name")
+
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "location area name is required"})
+		return
+	}
+
+	// Empty version returns pokemon of all game versions
+	version := c.Query("version")
+
+	area, err := h.repo.GetLocationArea(c.Request.Context(), name, version)
+
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, area)
+}
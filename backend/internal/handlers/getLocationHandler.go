@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetLocationHandler(c *gin.Context) {
+	name := c.Param("name")
+
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "location name is required"})
+		return
+	}
+
+	// Empty version returns pokemon of all game versions
+	version := c.Query("version")
+
+	location, err := h.repo.GetLocation(c.Request.Context(), name, version)
+
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
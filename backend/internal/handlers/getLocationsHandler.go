@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetLocationsHandler(c *gin.Context) {
+	// Empty search lists every location
+	search := c.Query("search")
+
+	// Limit defaults to 20 if no parameter is given
+	limitStr := c.DefaultQuery("limit", "20")
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a valid integer"})
+		return
+	}
+	if limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a greater than 0"})
+		return
+	}
+
+	locations, err := h.repo.SearchLocations(c.Request.Context(), search, limit)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, locations)
+}
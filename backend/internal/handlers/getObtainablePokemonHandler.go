@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetObtainablePokemonHandler(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	obtainable, err := h.repo.GetObtainablePokemon(c.Request.Context(), id, c.Query("generation"))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "save not found"})
+		return
+	}
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, obtainable)
+}
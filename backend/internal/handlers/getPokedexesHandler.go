@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetPokedexesHandler(c *gin.Context) {
+	pokedexes, err := h.repo.GetPokedexes(c.Request.Context(), c.Query("version_group"))
+
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, pokedexes)
+}
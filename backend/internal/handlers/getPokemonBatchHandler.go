@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+	"poke-atlas/web-service/internal/batch"
+	"poke-atlas/web-service/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetPokemonBatchHandler(c *gin.Context) {
+	var req model.Pokemon_batch_request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	if err := batch.ValidateRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.repo.GetPokemonBatch(c.Request.Context(), req)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
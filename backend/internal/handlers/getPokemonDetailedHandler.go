@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"poke-atlas/web-service/internal/repository"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -20,6 +21,11 @@ func (h *Handler) GetPokemonDetailedHandler(c *gin.Context) {
 		return
 	}
 
+	language, ok := languageParam(c)
+	if !ok {
+		return
+	}
+
 	pokemon, err := h.repo.GetPokemonDetailed(c.Request.Context(), id)
 
 	if err != nil {
@@ -27,5 +33,17 @@ func (h *Handler) GetPokemonDetailedHandler(c *gin.Context) {
 		return
 	}
 
+	if language != "" {
+		refs := repository.NameRefs{PokemonIDs: []int{pokemon.ID}, Types: pokemon.Types}
+		for _, evolution := range pokemon.EvolutionChain {
+			refs.PokemonIDs = append(refs.PokemonIDs, evolution.PokemonID, evolution.EvolvesToID)
+		}
+		for _, stat := range pokemon.Stats {
+			refs.Stats = append(refs.Stats, stat.StatName)
+		}
+
+		pokemon.LocalizedNames = h.localizedNames(c, language, refs)
+	}
+
 	c.JSON(http.StatusOK, pokemon)
 }
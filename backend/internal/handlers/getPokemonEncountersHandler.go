@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetPokemonEncountersHandler(c *gin.Context) {
+	id, ok := h.pokemonIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Empty version returns encounters of all game versions
+	version := c.Query("version")
+
+	encounters, err := h.repo.GetPokemonEncounters(c.Request.Context(), id, version)
+
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, encounters)
+}
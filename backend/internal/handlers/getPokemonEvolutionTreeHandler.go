@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetPokemonEvolutionTreeHandler(c *gin.Context) {
+	id, ok := h.pokemonIDParam(c)
+	if !ok {
+		return
+	}
+
+	tree, err := h.repo.GetPokemonEvolutionTree(c.Request.Context(), id)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetPokemonFlavorTextHandler(c *gin.Context) {
+	id, ok := h.pokemonIDParam(c)
+	if !ok {
+		return
+	}
+
+	// Empty version returns the entries of all game versions, language defaults to english
+	version := c.Query("version")
+	language, ok := languageParam(c)
+	if !ok {
+		return
+	}
+	if language == "" {
+		language = "en"
+	}
+
+	flavorText, err := h.repo.GetPokemonFlavorText(c.Request.Context(), id, version, language)
+
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, flavorText)
+}
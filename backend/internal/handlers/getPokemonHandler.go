@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"poke-atlas/web-service/internal/pokeapi"
+	"poke-atlas/web-service/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,12 +18,27 @@ func (h *Handler) GetPokemonHandler(c *gin.Context) {
 		return
 	}
 
+	language, ok := languageParam(c)
+	if !ok {
+		return
+	}
+
+	// Accepts the pokemon name or the localized name of its species, e.g. "Glurak"
 	pokemon, err := h.repo.GetPokemon(c.Request.Context(), name)
 
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pokemon not found"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	pokemon.LocalizedNames = h.localizedNames(c, language, repository.NameRefs{
+		PokemonIDs: []int{pokemon.ID},
+		Types:      pokemon.Types,
+	})
+
 	c.JSON(http.StatusOK, pokemon)
 }
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"net/http"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/repository"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -36,7 +38,31 @@ func (h *Handler) GetPokemonsHandler(c *gin.Context) {
 		return
 	}
 
+	language, ok := languageParam(c)
+	if !ok {
+		return
+	}
+
 	pokemons, err := h.repo.GetPokemons(c.Request.Context(), offset, limit)
 
+	if language != "" {
+		var refs repository.NameRefs
+		for _, pokemon := range pokemons {
+			refs.PokemonIDs = append(refs.PokemonIDs, pokemon.ID)
+			refs.Types = append(refs.Types, pokemon.Types...)
+		}
+
+		// Names of the whole page are fetched at once, every pokemon carries only its own
+		if names := h.localizedNames(c, language, refs); names != nil {
+			for i, pokemon := range pokemons {
+				pokemons[i].LocalizedNames = &model.Localized_names{
+					Language: names.Language,
+					Pokemon:  pickNames(names.Pokemon, pokemon.Name),
+					Types:    pickNames(names.Types, pokemon.Types...),
+				}
+			}
+		}
+	}
+
 	c.JSON(http.StatusOK, pokemons)
 }
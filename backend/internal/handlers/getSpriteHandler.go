@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/pokeapi"
+	"poke-atlas/web-service/internal/spriteimage"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetSpriteHandler(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	// Without size and format the original sprite is served
+	options, err := spriteimage.ParseOptions(c.Query("size"), c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sprite, err := h.repo.GetSprite(c.Request.Context(), id, c.Query("form"), c.Param("variant"), options)
+
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pokemon doesn't have this sprite variant"})
+		return
+	}
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pokemon not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	serveCachedMedia(c, sprite.Path, sprite.Hash, sprite.ContentType)
+}
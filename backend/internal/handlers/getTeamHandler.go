@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetTeamHandler(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	language, ok := languageParam(c)
+	if !ok {
+		return
+	}
+
+	team, err := h.repo.GetTeam(c.Request.Context(), id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	team.LocalizedNames = h.teamNames(c, language, team)
+
+	c.JSON(http.StatusOK, team)
+}
+
+// Display names of the pokemon, abilities and moves of the members of every team, fetched at once
+func (h *Handler) teamNames(c *gin.Context, language string, teams ...model.Team) *model.Localized_names {
+	if language == "" {
+		return nil
+	}
+
+	var refs repository.NameRefs
+	for _, team := range teams {
+		for _, member := range team.Members {
+			refs.PokemonIDs = append(refs.PokemonIDs, member.PokemonID)
+			refs.Abilities = append(refs.Abilities, member.Ability)
+			refs.Moves = append(refs.Moves, member.Moves...)
+		}
+	}
+
+	return h.localizedNames(c, language, refs)
+}
+
+// Names of the members of one team out of the names of several teams
+func pickTeamNames(names *model.Localized_names, team model.Team) *model.Localized_names {
+	if names == nil {
+		return nil
+	}
+
+	var pokemon, abilities, moves []string
+	for _, member := range team.Members {
+		pokemon = append(pokemon, member.PokemonName)
+		abilities = append(abilities, member.Ability)
+		moves = append(moves, member.Moves...)
+	}
+
+	return &model.Localized_names{
+		Language:  names.Language,
+		Pokemon:   pickNames(names.Pokemon, pokemon...),
+		Abilities: pickNames(names.Abilities, abilities...),
+		Moves:     pickNames(names.Moves, moves...),
+	}
+}
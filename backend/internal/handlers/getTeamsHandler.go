@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetTeamsHandler(c *gin.Context) {
+	language, ok := languageParam(c)
+	if !ok {
+		return
+	}
+
+	teams, err := h.repo.GetTeams(c.Request.Context())
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	names := h.teamNames(c, language, teams...)
+	for i, team := range teams {
+		teams[i].LocalizedNames = pickTeamNames(names, team)
+	}
+
+	c.JSON(http.StatusOK, teams)
+}
@@ -1,7 +1,17 @@
 package handlers
 
 import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"poke-atlas/web-service/internal/localization"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/pokeapi"
 	"poke-atlas/web-service/internal/repository"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
@@ -13,3 +23,113 @@ func NewHandler(repository repository.Repository) *Handler {
 		repo: repository,
 	}
 }
+
+// Reads the :name route parameter, which accepts either a pokemon id or a pokemon name.
+// Writes the error response and returns false if the pokemon can't be resolved
+func (h *Handler) pokemonIDParam(c *gin.Context) (int, bool) {
+	param := c.Param("name")
+
+	if param == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "pokemon id or name is required"})
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(param)
+	if err == nil {
+		if id <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "id must be a greater than 0"})
+			return 0, false
+		}
+		return id, true
+	}
+
+	pokemon, err := h.repo.GetPokemon(c.Request.Context(), param)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pokemon not found"})
+		return 0, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return 0, false
+	}
+
+	return pokemon.ID, true
+}
+
+// Reads the :id route parameter of a team, save or pokemon.
+// Writes the error response and returns false if it isn't a valid id
+func idParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must be a valid integer"})
+		return 0, false
+	}
+	if id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must be a greater than 0"})
+		return 0, false
+	}
+	return id, true
+}
+
+// Reads the language of the response from ?lang= or else the Accept-Language header, empty if neither asks for a supported one.
+// Writes the error response and returns false for an unsupported ?lang=
+func languageParam(c *gin.Context) (string, bool) {
+	c.Header("Vary", "Accept-Language")
+
+	language, err := localization.FromRequest(c.Query("lang"), c.GetHeader("Accept-Language"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", false
+	}
+	return language, true
+}
+
+// Display names of the slugs in the language, nil without a language.
+// The response is served without them if the names can't be fetched
+func (h *Handler) localizedNames(c *gin.Context, language string, refs repository.NameRefs) *model.Localized_names {
+	if language == "" {
+		return nil
+	}
+
+	names, err := h.repo.GetLocalizedNames(c.Request.Context(), language, refs)
+	if err != nil {
+		log.Printf("Failed to fetch %s names: %v", language, err)
+		return nil
+	}
+	return &names
+}
+
+// Subset of the localized names for the slugs
+func pickNames(names map[string]string, slugs ...string) map[string]string {
+	picked := map[string]string{}
+	for _, slug := range slugs {
+		if name, ok := names[slug]; ok {
+			picked[slug] = name
+		}
+	}
+	return picked
+}
+
+// Serves a file of the media cache. The path of a sprite or cry stays the same when the file changes upstream
+// or the cache is refilled, so clients only keep it for a day and then revalidate it with the hash as ETag
+func serveCachedMedia(c *gin.Context, path string, hash string, contentType string) {
+	file, err := os.Open(path)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Header("ETag", `"`+hash+`"`)
+
+	// ServeContent answers conditional and range requests
+	http.ServeContent(c.Writer, c.Request, "", info.ModTime(), file)
+}
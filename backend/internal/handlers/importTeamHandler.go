@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/showdown"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ImportTeamHandler(c *gin.Context) {
+	var req model.Team_import_request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	team, err := h.repo.ImportTeam(c.Request.Context(), req)
+
+	var parseErr showdown.ParseError
+	if errors.As(err, &parseErr) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team paste", "errors": parseErr.Errors})
+		return
+	}
+	if errors.As(err, &apierror.ValidationError{}) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, team)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) SetSavePokemonHandler(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+
+	var status model.Save_pokemon_status
+	if err := c.ShouldBindJSON(&status); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	pokemonID, ok := h.pokemonIDParam(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.repo.SetSavePokemon(c.Request.Context(), id, pokemonID, status)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "save not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/pokeapi"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Species per sync request, each of them is one request to pokeapi
+const maxSpeciesNameSyncLimit = 200
+
+func (h *Handler) SyncSpeciesNamesHandler(c *gin.Context) {
+	// Limit defaults to 100 and offset to 0 if no parameters are given
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a valid integer"})
+		return
+	}
+	if limit <= 0 || limit > maxSpeciesNameSyncLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxSpeciesNameSyncLimit)})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a valid integer"})
+		return
+	}
+	if offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "offset cannot be negative"})
+		return
+	}
+
+	result, err := h.repo.SyncSpeciesNames(c.Request.Context(), offset, limit)
+
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "species not found, the range goes past the last species of the national dex"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
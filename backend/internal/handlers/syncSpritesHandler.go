@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"poke-atlas/web-service/internal/pokeapi"
+	"poke-atlas/web-service/internal/sprites"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Pokemon per sync request, every one of them may need several requests to pokeapi and github
+const maxSpriteSyncLimit = 200
+
+func (h *Handler) SyncSpritesHandler(c *gin.Context) {
+	// Limit defaults to 20 and offset to 0 if no parameters are given
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a valid integer"})
+		return
+	}
+	if limit <= 0 || limit > maxSpriteSyncLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxSpriteSyncLimit)})
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a valid integer"})
+		return
+	}
+	if offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "offset cannot be negative"})
+		return
+	}
+
+	// Comma separated, defaults to the front default sprite
+	variants, err := sprites.ParseVariants(c.Query("variants"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.repo.SyncSprites(c.Request.Context(), offset, limit, variants)
+
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "pokemon not found, ids above the national dex are forms starting at 10001"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
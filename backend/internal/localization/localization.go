@@ -0,0 +1,113 @@
+package localization
+
+import (
+	"poke-atlas/web-service/internal/apierror"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Language codes of the names in pokeapi
+var Languages = []string{"en", "de", "fr", "es", "it", "ja", "ja-Hrkt", "roomaji", "ko", "zh-Hans", "zh-Hant"}
+
+// Chinese regions writing in traditional characters, others default to simplified
+var traditionalChineseRegions = []string{"tw", "hk", "mo"}
+
+// Language of the request, the lang query parameter takes precedence over the Accept-Language header.
+// Returns an empty language when neither asks for a supported one, an unsupported lang parameter is an apierror.ValidationError
+func FromRequest(lang string, acceptLanguage string) (string, error) {
+	if lang != "" {
+		language, ok := Canonical(lang)
+		if !ok {
+			return "", apierror.Validationf("unsupported language %q, expected one of %s", lang, strings.Join(Languages, ", "))
+		}
+		return language, nil
+	}
+
+	return ParseAcceptLanguage(acceptLanguage), nil
+}
+
+// Returns the pokeapi code of the language, matched case-insensitively
+func Canonical(lang string) (string, bool) {
+	for _, language := range Languages {
+		if strings.EqualFold(language, lang) {
+			return language, true
+		}
+	}
+	return "", false
+}
+
+// Picks the supported language with the highest quality, e.g. "de-DE,de;q=0.9,en;q=0.8" gives "de".
+// Regional tags fall back to their language, returns an empty language if nothing matches
+func ParseAcceptLanguage(header string) string {
+	type tag struct {
+		language string
+		quality  float64
+	}
+
+	var tags []tag
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		language := strings.TrimSpace(fields[0])
+		if language == "" || language == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if q, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				parsed, err := strconv.ParseFloat(q, 64)
+				if err != nil {
+					parsed = 0
+				}
+				quality = parsed
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		tags = append(tags, tag{language, quality})
+	}
+
+	// Tags of the same quality keep the order of the header
+	slices.SortStableFunc(tags, func(a, b tag) int {
+		switch {
+		case a.quality > b.quality:
+			return -1
+		case a.quality < b.quality:
+			return 1
+		}
+		return 0
+	})
+
+	for _, t := range tags {
+		if language, ok := match(t.language); ok {
+			return language
+		}
+	}
+	return ""
+}
+
+func match(tag string) (string, bool) {
+	if language, ok := Canonical(tag); ok {
+		return language, true
+	}
+
+	subtags := strings.Split(strings.ToLower(tag), "-")
+	if subtags[0] == "zh" {
+		for _, subtag := range subtags[1:] {
+			if subtag == "hant" || slices.Contains(traditionalChineseRegions, subtag) {
+				return "zh-Hant", true
+			}
+		}
+		return "zh-Hans", true
+	}
+
+	return Canonical(subtags[0])
+}
+
+// Form of a name used for lookups, case and surrounding or repeated spaces are ignored
+func SearchName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
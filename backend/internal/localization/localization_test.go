@@ -0,0 +1,62 @@
+package localization
+
+import (
+	"errors"
+	"poke-atlas/web-service/internal/apierror"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                           "",
+		"de":                         "de",
+		"de-DE,de;q=0.9,en;q=0.8":    "de",
+		"en;q=0.5, fr;q=0.9":         "fr",
+		"nl-NL,nl;q=0.9,en-US;q=0.8": "en",
+		"nl, *;q=0.5":                "",
+		"fr;q=0, es":                 "es",
+		"zh-TW":                      "zh-Hant",
+		"zh-Hant-HK":                 "zh-Hant",
+		"zh-CN,zh;q=0.9":             "zh-Hans",
+		"ja-hrkt":                    "ja-Hrkt",
+		"ja-JP":                      "ja",
+		"ko;q=abc, it;q=0.1":         "it",
+	}
+
+	for header, expected := range tests {
+		if result := ParseAcceptLanguage(header); result != expected {
+			t.Errorf("%q: expected %q, got %q", header, expected, result)
+		}
+	}
+}
+
+func TestFromRequest(t *testing.T) {
+	language, err := FromRequest("DE", "fr")
+	if err != nil || language != "de" {
+		t.Errorf("expected lang parameter to take precedence, got %q, %v", language, err)
+	}
+
+	language, err = FromRequest("", "fr-FR")
+	if err != nil || language != "fr" {
+		t.Errorf("expected language from the header, got %q, %v", language, err)
+	}
+
+	_, err = FromRequest("klingon", "")
+	if !errors.As(err, &apierror.ValidationError{}) {
+		t.Errorf("expected validation error for unsupported language, got %v", err)
+	}
+}
+
+func TestSearchName(t *testing.T) {
+	tests := map[string]string{
+		"Glurak":        "glurak",
+		"  Mr.   Mime ": "mr. mime",
+		"ÉVOLI":         "évoli",
+	}
+
+	for name, expected := range tests {
+		if result := SearchName(name); result != expected {
+			t.Errorf("%q: expected %q, got %q", name, expected, result)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package mediacache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Content-addressed file store, every file is named after the SHA-256 of its content
+// so identical images of different pokemon are stored once
+type Cache struct {
+	dir string
+}
+
+func NewMediaCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Stores the data and returns its hash, data that is already stored is not written again
+func (c *Cache) Put(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	if c.Has(hash) {
+		return hash, nil
+	}
+
+	path := c.Path(hash)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	// Written to a temporary file first so readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(path), hash+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// Files are spread over subdirectories by the first two characters of the hash
+func (c *Cache) Path(hash string) string {
+	if len(hash) < 2 {
+		return filepath.Join(c.dir, hash)
+	}
+	return filepath.Join(c.dir, hash[:2], hash)
+}
+
+func (c *Cache) Has(hash string) bool {
+	_, err := os.Stat(c.Path(hash))
+	return err == nil
+}
+
+func (c *Cache) Open(hash string) (*os.File, error) {
+	file, err := os.Open(c.Path(hash))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("media %s is missing from the cache: %w", hash, err)
+	}
+	return file, err
+}
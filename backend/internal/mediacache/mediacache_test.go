@@ -0,0 +1,48 @@
+package mediacache
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestPut(t *testing.T) {
+	cache := NewMediaCache(t.TempDir())
+
+	hash, err := cache.Put([]byte("sprite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != "4a046e33ecf7aced9bfd000747bb1fda7836c8ceeff662af33c2a2c288b4e78c" {
+		t.Errorf("expected the sha256 of the data, got %q", hash)
+	}
+
+	if !cache.Has(hash) {
+		t.Fatal("expected the data to be cached")
+	}
+	data, err := os.ReadFile(cache.Path(hash))
+	if err != nil || string(data) != "sprite" {
+		t.Errorf("expected the stored data, got %q, %v", data, err)
+	}
+
+	again, err := cache.Put([]byte("sprite"))
+	if err != nil || again != hash {
+		t.Errorf("expected identical data to have the same hash, got %q, %v", again, err)
+	}
+
+	other, err := cache.Put([]byte("artwork"))
+	if err != nil || other == hash {
+		t.Errorf("expected different data to have another hash, got %q, %v", other, err)
+	}
+}
+
+func TestOpenMissing(t *testing.T) {
+	cache := NewMediaCache(t.TempDir())
+
+	if cache.Has("0000") {
+		t.Error("expected empty cache")
+	}
+	if _, err := cache.Open("0000"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package model
+
+// Side by side comparison of pokemon, values are listed in the requested order
+type Comparison struct {
+	Pokemon []Pokemon_details `json:"pokemon"`
+	// One entry per base stat in the usual order followed by the base stat total
+	Stats []Stat_comparison `json:"stats"`
+	// Attacking types at least two of the pokemon are weak to
+	SharedWeaknesses []string `json:"shared_weaknesses"`
+	// Attacking types at least two of the pokemon resist or are immune to
+	SharedResistances []string       `json:"shared_resistances"`
+	Defense           []Type_matchup `json:"defense"`
+	// Moves every pokemon can learn in any version group
+	SharedMoves []string `json:"shared_moves"`
+}
+
+type Stat_comparison struct {
+	Stat   string          `json:"stat"`
+	Values []Compared_stat `json:"values"`
+}
+
+type Compared_stat struct {
+	PokemonID   int    `json:"pokemon_id"`
+	PokemonName string `json:"pokemon_name"`
+	BaseStat    int    `json:"base_stat"`
+	// Difference to the first pokemon of the comparison
+	Delta int `json:"delta"`
+	// Ties are all marked, nothing is marked when every pokemon has the same value
+	Best  bool `json:"best"`
+	Worst bool `json:"worst"`
+}
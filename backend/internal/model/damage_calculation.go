@@ -0,0 +1,67 @@
+package model
+
+type Damage_pokemon struct {
+	ID     int    `json:"id"`
+	Level  int    `json:"level"`
+	Nature string `json:"nature"`
+	// IVs default to 31 when not given
+	IVs *Stat_spread `json:"ivs"`
+	EVs Stat_spread  `json:"evs"`
+	// Stat stages from -6 to +6, hp is ignored
+	Boosts  Stat_spread `json:"boosts"`
+	Ability string      `json:"ability"`
+	Item    string      `json:"item"`
+	// Non volatile status like burn or paralysis, empty when healthy
+	Status string `json:"status"`
+	// Only used for the defender, 0 means full hp
+	CurrentHP int `json:"current_hp"`
+}
+
+type Damage_field struct {
+	// sun, rain, sand or snow
+	Weather string `json:"weather"`
+	// electric, grassy, psychic or misty, every pokemon is assumed grounded
+	Terrain     string `json:"terrain"`
+	Reflect     bool   `json:"reflect"`
+	LightScreen bool   `json:"light_screen"`
+	AuroraVeil  bool   `json:"aurora_veil"`
+	HelpingHand bool   `json:"helping_hand"`
+	// Spread moves deal 75% damage and screens are weaker in doubles
+	Doubles     bool `json:"doubles"`
+	CriticalHit bool `json:"critical_hit"`
+}
+
+type Damage_request struct {
+	Attacker Damage_pokemon `json:"attacker"`
+	Defender Damage_pokemon `json:"defender"`
+	Moves    []string       `json:"moves"`
+	Field    Damage_field   `json:"field"`
+}
+
+type Damage_combatant struct {
+	PokemonID   int         `json:"pokemon_id"`
+	PokemonName string      `json:"pokemon_name"`
+	Types       []string    `json:"types"`
+	Stats       Stat_spread `json:"stats"`
+}
+
+type Move_damage struct {
+	Move          Move_details `json:"move"`
+	Effectiveness float64      `json:"effectiveness"`
+	// Damage of each of the 16 random rolls, from lowest to highest
+	Rolls      []int   `json:"rolls"`
+	Min        int     `json:"min"`
+	Max        int     `json:"max"`
+	MinPercent float64 `json:"min_percent"`
+	MaxPercent float64 `json:"max_percent"`
+	// Fewest hits that can KO within 4 hits and the chance of it, 0 if the move can't
+	HitsToKO    int     `json:"hits_to_ko"`
+	KOChance    float64 `json:"ko_chance"`
+	Description string  `json:"description"`
+}
+
+type Damage_result struct {
+	Attacker Damage_combatant `json:"attacker"`
+	Defender Damage_combatant `json:"defender"`
+	Moves    []Move_damage    `json:"moves"`
+}
@@ -0,0 +1,21 @@
+package model
+
+// PokeAPI response of /pokemon/{id}/encounters
+type LocationAreaEncounter struct {
+	LocationArea   NamedResource            `json:"location_area"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
+
+type VersionEncounterDetail struct {
+	Version          NamedResource `json:"version"`
+	MaxChance        int           `json:"max_chance"`
+	EncounterDetails []Encounter   `json:"encounter_details"`
+}
+
+type Encounter struct {
+	MinLevel        int             `json:"min_level"`
+	MaxLevel        int             `json:"max_level"`
+	ConditionValues []NamedResource `json:"condition_values"`
+	Chance          int             `json:"chance"`
+	Method          NamedResource   `json:"method"`
+}
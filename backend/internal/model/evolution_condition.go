@@ -0,0 +1,185 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition for evolving into the next pokemon in the chain, mirrors EvolutionDetail with resources flattened to names
+type Evolution_condition struct {
+	Trigger               string `json:"trigger"`
+	Item                  string `json:"item,omitempty"`
+	HeldItem              string `json:"held_item,omitempty"`
+	Gender                *int   `json:"gender,omitempty"`
+	KnownMove             string `json:"known_move,omitempty"`
+	KnownMoveType         string `json:"known_move_type,omitempty"`
+	Location              string `json:"location,omitempty"`
+	MinLevel              *int   `json:"min_level,omitempty"`
+	MinHappiness          *int   `json:"min_happiness,omitempty"`
+	MinBeauty             *int   `json:"min_beauty,omitempty"`
+	MinAffection          *int   `json:"min_affection,omitempty"`
+	NeedsMultiplayer      bool   `json:"needs_multiplayer"`
+	NeedsOverworldRain    bool   `json:"needs_overworld_rain"`
+	PartySpecies          string `json:"party_species,omitempty"`
+	PartyType             string `json:"party_type,omitempty"`
+	RelativePhysicalStats *int   `json:"relative_physical_stats,omitempty"`
+	TimeOfDay             string `json:"time_of_day,omitempty"`
+	TradeSpecies          string `json:"trade_species,omitempty"`
+	TurnUpsideDown        bool   `json:"turn_upside_down"`
+	UsedMove              string `json:"used_move,omitempty"`
+	MinMoveCount          *int   `json:"min_move_count,omitempty"`
+	MinSteps              *int   `json:"min_steps,omitempty"`
+	MinDamageTaken        *int   `json:"min_damage_taken,omitempty"`
+}
+
+// Human-readable summary of alternative evolution conditions, e.g. "Level up holding Razor Fang at night"
+func EvolutionSummary(conditions []Evolution_condition) string {
+	summaries := make([]string, 0, len(conditions))
+	for i, c := range conditions {
+		summary := c.Summary()
+		if i > 0 {
+			summary = strings.ToLower(summary[:1]) + summary[1:]
+		}
+		summaries = append(summaries, summary)
+	}
+	return strings.Join(summaries, " or ")
+}
+
+func (c Evolution_condition) Summary() string {
+	var parts []string
+
+	switch c.Trigger {
+	case "level-up":
+		if c.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("Level %d", *c.MinLevel))
+		} else {
+			parts = append(parts, "Level up")
+		}
+	case "trade":
+		parts = append(parts, "Trade")
+	case "use-item":
+		parts = append(parts, "Use "+DisplayName(c.Item))
+	case "shed":
+		parts = append(parts, "Level up with an empty party slot and a spare Poké Ball")
+	case "spin":
+		parts = append(parts, "Spin around")
+	case "tower-of-darkness":
+		parts = append(parts, "Train in the Tower of Darkness")
+	case "tower-of-waters":
+		parts = append(parts, "Train in the Tower of Waters")
+	case "three-critical-hits":
+		parts = append(parts, "Land three critical hits in one battle")
+	case "take-damage":
+		parts = append(parts, "Walk under the stone bridge in Dusty Bowl")
+	case "":
+		parts = append(parts, "Unknown")
+	default:
+		parts = append(parts, DisplayName(c.Trigger))
+	}
+
+	// Level requirement of other triggers, e.g. spinning or level up evolutions from other games
+	if c.MinLevel != nil && c.Trigger != "level-up" {
+		parts = append(parts, fmt.Sprintf("from level %d", *c.MinLevel))
+	}
+	if c.Item != "" && c.Trigger != "use-item" {
+		parts = append(parts, "using "+DisplayName(c.Item))
+	}
+	if c.TradeSpecies != "" {
+		parts = append(parts, "for "+DisplayName(c.TradeSpecies))
+	}
+	if c.HeldItem != "" {
+		parts = append(parts, "holding "+DisplayName(c.HeldItem))
+	}
+	if c.KnownMove != "" {
+		parts = append(parts, "knowing "+DisplayName(c.KnownMove))
+	}
+	if c.KnownMoveType != "" {
+		parts = append(parts, "knowing a "+DisplayName(c.KnownMoveType)+"-type move")
+	}
+	if c.MinHappiness != nil {
+		parts = append(parts, fmt.Sprintf("with at least %d friendship", *c.MinHappiness))
+	}
+	if c.MinBeauty != nil {
+		parts = append(parts, fmt.Sprintf("with at least %d beauty", *c.MinBeauty))
+	}
+	if c.MinAffection != nil {
+		parts = append(parts, fmt.Sprintf("with at least %d affection", *c.MinAffection))
+	}
+	if c.PartySpecies != "" {
+		parts = append(parts, "with "+DisplayName(c.PartySpecies)+" in the party")
+	}
+	if c.PartyType != "" {
+		parts = append(parts, "with a "+DisplayName(c.PartyType)+"-type pokemon in the party")
+	}
+	if c.RelativePhysicalStats != nil {
+		switch *c.RelativePhysicalStats {
+		case 1:
+			parts = append(parts, "with Attack higher than Defense")
+		case -1:
+			parts = append(parts, "with Attack lower than Defense")
+		case 0:
+			parts = append(parts, "with Attack equal to Defense")
+		}
+	}
+	if c.UsedMove != "" {
+		if c.MinMoveCount != nil {
+			parts = append(parts, fmt.Sprintf("after using %s %d times", DisplayName(c.UsedMove), *c.MinMoveCount))
+		} else {
+			parts = append(parts, "after using "+DisplayName(c.UsedMove))
+		}
+	}
+	if c.MinSteps != nil {
+		parts = append(parts, fmt.Sprintf("after walking %d steps", *c.MinSteps))
+	}
+	if c.MinDamageTaken != nil {
+		parts = append(parts, fmt.Sprintf("after taking at least %d damage", *c.MinDamageTaken))
+	}
+	if c.Gender != nil {
+		switch *c.Gender {
+		case 1:
+			parts = append(parts, "(female)")
+		case 2:
+			parts = append(parts, "(male)")
+		}
+	}
+	if c.Location != "" {
+		parts = append(parts, "at "+DisplayName(c.Location))
+	}
+	if c.NeedsOverworldRain {
+		parts = append(parts, "while it is raining")
+	}
+	if c.NeedsMultiplayer {
+		parts = append(parts, "in multiplayer")
+	}
+	if c.TurnUpsideDown {
+		parts = append(parts, "with the console upside down")
+	}
+
+	switch c.TimeOfDay {
+	case "":
+	case "day":
+		parts = append(parts, "during the day")
+	case "night":
+		parts = append(parts, "at night")
+	default:
+		parts = append(parts, "at "+c.TimeOfDay)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Turns pokeapi names into display names, e.g. "razor-fang" returns "Razor Fang"
+func DisplayName(name string) string {
+	words := strings.Split(name, "-")
+	for i, word := range words {
+		words[i] = capitalize(word)
+	}
+	return strings.Join(words, " ")
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
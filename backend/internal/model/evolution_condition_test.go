@@ -0,0 +1,58 @@
+package model
+
+import "testing"
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestEvolutionSummary(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []Evolution_condition
+		expected   string
+	}{
+		{
+			name:       "level",
+			conditions: []Evolution_condition{{Trigger: "level-up", MinLevel: intPtr(16)}},
+			expected:   "Level 16",
+		},
+		{
+			name:       "held item at night",
+			conditions: []Evolution_condition{{Trigger: "level-up", HeldItem: "razor-fang", TimeOfDay: "night"}},
+			expected:   "Level up holding Razor Fang at night",
+		},
+		{
+			name:       "trade for species",
+			conditions: []Evolution_condition{{Trigger: "trade", TradeSpecies: "shelmet"}},
+			expected:   "Trade for Shelmet",
+		},
+		{
+			name:       "friendship during the day",
+			conditions: []Evolution_condition{{Trigger: "level-up", MinHappiness: intPtr(160), TimeOfDay: "day"}},
+			expected:   "Level up with at least 160 friendship during the day",
+		},
+		{
+			name: "alternatives",
+			conditions: []Evolution_condition{
+				{Trigger: "level-up", Location: "eterna-forest"},
+				{Trigger: "use-item", Item: "leaf-stone"},
+			},
+			expected: "Level up at Eterna Forest or use Leaf Stone",
+		},
+		{
+			name:       "no conditions",
+			conditions: nil,
+			expected:   "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			summary := EvolutionSummary(test.conditions)
+			if summary != test.expected {
+				t.Errorf("expected '%s', got '%s'", test.expected, summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+// Evolution chain as a nested tree, mirrors ChainLink
+type Evolution_tree struct {
+	ChainID         int            `json:"chain_id"`
+	BabyTriggerItem string         `json:"baby_trigger_item,omitempty"`
+	Root            Evolution_node `json:"root"`
+}
+
+type Evolution_node struct {
+	PokemonID   int      `json:"pokemon_id"`
+	PokemonName string   `json:"pokemon_name"`
+	SpriteUrl   string   `json:"sprite_url"`
+	Types       []string `json:"types"`
+	IsBaby      bool     `json:"is_baby"`
+	// Conditions for evolving into this pokemon from its parent, empty for the root
+	Conditions []Evolution_condition `json:"conditions"`
+	Summary    string                `json:"summary,omitempty"`
+	EvolvesTo  []Evolution_node      `json:"evolves_to"`
+}
@@ -0,0 +1,14 @@
+package model
+
+type Pokemon_flavor_text struct {
+	PokemonID   int                 `json:"pokemon_id"`
+	SpeciesName string              `json:"species_name"`
+	Entries     []Flavor_text_entry `json:"entries"`
+}
+
+// Versions sharing the same text are listed in one entry
+type Flavor_text_entry struct {
+	Text     string   `json:"text"`
+	Language string   `json:"language"`
+	Versions []string `json:"versions"`
+}
@@ -0,0 +1,22 @@
+package model
+
+// A version group, the versions of a game that share their data like sword and shield
+type Game struct {
+	VersionGroup string   `json:"version_group"`
+	Order        int      `json:"order"`
+	Generation   string   `json:"generation"`
+	Versions     []string `json:"versions"`
+	Pokedexes    []string `json:"pokedexes"`
+}
+
+// Everything introduced in a generation
+type Generation_details struct {
+	ID             int      `json:"id"`
+	Name           string   `json:"name"`
+	MainRegion     string   `json:"main_region"`
+	VersionGroups  []string `json:"version_groups"`
+	PokemonSpecies []string `json:"pokemon_species"`
+	Moves          []string `json:"moves"`
+	Abilities      []string `json:"abilities"`
+	Types          []string `json:"types"`
+}
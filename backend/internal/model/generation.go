@@ -0,0 +1,22 @@
+package model
+
+// PokeAPI response of /generation/{id}
+type Generation struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	MainRegion     NamedResource   `json:"main_region"`
+	PokemonSpecies []NamedResource `json:"pokemon_species"`
+	VersionGroups  []NamedResource `json:"version_groups"`
+	Moves          []NamedResource `json:"moves"`
+	Abilities      []NamedResource `json:"abilities"`
+	Types          []NamedResource `json:"types"`
+}
+
+// PokeAPI response of /version-group/{name}, e.g. red-blue groups the versions red and blue
+type VersionGroup struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	Order      int             `json:"order"`
+	Generation NamedResource   `json:"generation"`
+	Versions   []NamedResource `json:"versions"`
+}
@@ -0,0 +1,29 @@
+package model
+
+// PokeAPI response of /item/{name}
+type Item struct {
+	ID            int                 `json:"id"`
+	Name          string              `json:"name"`
+	Cost          int                 `json:"cost"`
+	FlingPower    *int                `json:"fling_power"`
+	FlingEffect   *NamedResource      `json:"fling_effect"`
+	Category      NamedResource       `json:"category"`
+	EffectEntries []VerboseEffect     `json:"effect_entries"`
+	Sprites       ItemSprites         `json:"sprites"`
+	HeldByPokemon []ItemHolderPokemon `json:"held_by_pokemon"`
+}
+
+type VerboseEffect struct {
+	Effect      string        `json:"effect"`
+	ShortEffect string        `json:"short_effect"`
+	Language    NamedResource `json:"language"`
+}
+
+type ItemSprites struct {
+	Default string `json:"default"`
+}
+
+type ItemHolderPokemon struct {
+	Pokemon        NamedResource            `json:"pokemon"`
+	VersionDetails []PokemonHeldItemVersion `json:"version_details"`
+}
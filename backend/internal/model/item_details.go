@@ -0,0 +1,27 @@
+package model
+
+type Item_details struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Cost        int    `json:"cost"`
+	FlingPower  *int   `json:"fling_power"`
+	FlingEffect string `json:"fling_effect,omitempty"`
+	Effect      string `json:"effect"`
+	ShortEffect string `json:"short_effect"`
+	SpriteUrl   string `json:"sprite_url"`
+	// Wild pokemon holding the item, grouped by game version
+	HeldBy []Item_holder_version `json:"held_by"`
+}
+
+type Item_holder_version struct {
+	Version string        `json:"version"`
+	Pokemon []Item_holder `json:"pokemon"`
+}
+
+type Item_holder struct {
+	PokemonID   int    `json:"pokemon_id"`
+	PokemonName string `json:"pokemon_name"`
+	SpriteUrl   string `json:"sprite_url"`
+	Rarity      int    `json:"rarity"`
+}
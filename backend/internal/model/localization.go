@@ -0,0 +1,39 @@
+package model
+
+// Name of a pokeapi resource in one language
+type Name struct {
+	Name     string        `json:"name"`
+	Language NamedResource `json:"language"`
+}
+
+// PokeAPI response of /stat/{name}
+type Stat struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Names []Name `json:"names"`
+}
+
+// PokeAPI response of /ability/{name}
+type Ability struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Names []Name `json:"names"`
+}
+
+// Display names of the slugs in a response, keyed by slug.
+// Slugs without a name in the language are left out, alternate forms use the slug of the pokemon
+type Localized_names struct {
+	Language  string            `json:"language"`
+	Pokemon   map[string]string `json:"pokemon,omitempty"`
+	Types     map[string]string `json:"types,omitempty"`
+	Moves     map[string]string `json:"moves,omitempty"`
+	Abilities map[string]string `json:"abilities,omitempty"`
+	Stats     map[string]string `json:"stats,omitempty"`
+}
+
+type Species_name_sync struct {
+	Species int `json:"species"`
+	// Species whose names were already stored
+	Cached  int `json:"cached"`
+	Fetched int `json:"fetched"`
+}
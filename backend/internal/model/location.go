@@ -0,0 +1,22 @@
+package model
+
+// PokeAPI response of /location/{name}
+type Location struct {
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Region *NamedResource  `json:"region"`
+	Areas  []NamedResource `json:"areas"`
+}
+
+// PokeAPI response of /location-area/{name}
+type LocationArea struct {
+	ID                int                `json:"id"`
+	Name              string             `json:"name"`
+	Location          NamedResource      `json:"location"`
+	PokemonEncounters []PokemonEncounter `json:"pokemon_encounters"`
+}
+
+type PokemonEncounter struct {
+	Pokemon        NamedResource            `json:"pokemon"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
@@ -0,0 +1,33 @@
+package model
+
+type Location_summary struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Region string `json:"region,omitempty"`
+}
+
+type Location_details struct {
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Region string          `json:"region,omitempty"`
+	Areas  []Location_area `json:"areas"`
+}
+
+// Pokemon obtainable in a location area, grouped by game version
+type Location_area struct {
+	Name     string                  `json:"name"`
+	Location string                  `json:"location,omitempty"`
+	Versions []Location_area_version `json:"versions"`
+}
+
+type Location_area_version struct {
+	Version string                  `json:"version"`
+	Pokemon []Location_area_pokemon `json:"pokemon"`
+}
+
+type Location_area_pokemon struct {
+	PokemonID   int                `json:"pokemon_id"`
+	PokemonName string             `json:"pokemon_name"`
+	SpriteUrl   string             `json:"sprite_url"`
+	Encounters  []Encounter_detail `json:"encounters"`
+}
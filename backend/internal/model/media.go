@@ -0,0 +1,21 @@
+package model
+
+// File of the media cache, fetched once from its original url
+type Cached_media struct {
+	URL         string `json:"url"`
+	Hash        string `json:"hash"`
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+	// Location of the file in the media cache
+	Path string `json:"-"`
+}
+
+type Sprite_sync struct {
+	Pokemon  int      `json:"pokemon"`
+	Variants []string `json:"variants"`
+	// Sprites that were already cached
+	Cached  int `json:"cached"`
+	Fetched int `json:"fetched"`
+	// Variants the pokemon don't have, e.g. female sprites of species without gender differences
+	Unavailable int `json:"unavailable"`
+}
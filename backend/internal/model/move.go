@@ -0,0 +1,37 @@
+package model
+
+// PokeAPI response of /move/{name}
+type Move struct {
+	ID            int             `json:"id"`
+	Name          string          `json:"name"`
+	Power         *int            `json:"power"`
+	Accuracy      *int            `json:"accuracy"`
+	PP            *int            `json:"pp"`
+	Priority      int             `json:"priority"`
+	Type          NamedResource   `json:"type"`
+	DamageClass   NamedResource   `json:"damage_class"`
+	Target        NamedResource   `json:"target"`
+	Meta          *MoveMeta       `json:"meta"`
+	EffectEntries []VerboseEffect `json:"effect_entries"`
+	Names         []Name          `json:"names"`
+}
+
+type MoveMeta struct {
+	CritRate int  `json:"crit_rate"`
+	MinHits  *int `json:"min_hits"`
+	MaxHits  *int `json:"max_hits"`
+}
+
+// PokeAPI response of /type/{name}
+type Type struct {
+	ID              int           `json:"id"`
+	Name            string        `json:"name"`
+	DamageRelations TypeRelations `json:"damage_relations"`
+	Names           []Name        `json:"names"`
+}
+
+type TypeRelations struct {
+	NoDamageTo     []NamedResource `json:"no_damage_to"`
+	HalfDamageTo   []NamedResource `json:"half_damage_to"`
+	DoubleDamageTo []NamedResource `json:"double_damage_to"`
+}
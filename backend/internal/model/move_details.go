@@ -0,0 +1,28 @@
+package model
+
+// Damage classes of moves
+const (
+	DamageClassPhysical = "physical"
+	DamageClassSpecial  = "special"
+	DamageClassStatus   = "status"
+)
+
+// Power, accuracy and pp are 0 for moves without a fixed value
+type Move_details struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	DamageClass string `json:"damage_class"`
+	Target      string `json:"target"`
+	Power       int    `json:"power"`
+	Accuracy    int    `json:"accuracy"`
+	PP          int    `json:"pp"`
+	Priority    int    `json:"priority"`
+	CritRate    int    `json:"crit_rate"`
+	MinHits     int    `json:"min_hits,omitempty"`
+	MaxHits     int    `json:"max_hits,omitempty"`
+	ShortEffect string `json:"short_effect"`
+}
+
+// Damage factor in percent of an attacking type against a defending type, missing entries are 100
+type Type_chart map[string]map[string]int
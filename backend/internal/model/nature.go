@@ -0,0 +1,44 @@
+package model
+
+// PokeAPI response of /nature/{name}
+type Nature struct {
+	ID            int            `json:"id"`
+	Name          string         `json:"name"`
+	IncreasedStat *NamedResource `json:"increased_stat"`
+	DecreasedStat *NamedResource `json:"decreased_stat"`
+	LikesFlavor   *NamedResource `json:"likes_flavor"`
+	HatesFlavor   *NamedResource `json:"hates_flavor"`
+}
+
+// PokeAPI response of /characteristic/{id}
+type Characteristic struct {
+	ID             int                         `json:"id"`
+	GeneModulo     int                         `json:"gene_modulo"`
+	PossibleValues []int                       `json:"possible_values"`
+	HighestStat    NamedResource               `json:"highest_stat"`
+	Descriptions   []CharacteristicDescription `json:"descriptions"`
+}
+
+type CharacteristicDescription struct {
+	Description string        `json:"description"`
+	Language    NamedResource `json:"language"`
+}
+
+// Neutral natures have no increased or decreased stat
+type Nature_details struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	IncreasedStat string `json:"increased_stat,omitempty"`
+	DecreasedStat string `json:"decreased_stat,omitempty"`
+	LikesFlavor   string `json:"likes_flavor,omitempty"`
+	HatesFlavor   string `json:"hates_flavor,omitempty"`
+}
+
+// Characteristic shown in the summary screen, hints the stat with the highest IV and its value modulo 5
+type Characteristic_details struct {
+	ID             int    `json:"id"`
+	HighestStat    string `json:"highest_stat"`
+	GeneModulo     int    `json:"gene_modulo"`
+	PossibleValues []int  `json:"possible_values"`
+	Description    string `json:"description"`
+}
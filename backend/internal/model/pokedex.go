@@ -0,0 +1,16 @@
+package model
+
+// PokeAPI response of /pokedex/{name}
+type Pokedex struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	IsMainSeries   bool            `json:"is_main_series"`
+	Region         *NamedResource  `json:"region"`
+	VersionGroups  []NamedResource `json:"version_groups"`
+	PokemonEntries []PokedexEntry  `json:"pokemon_entries"`
+}
+
+type PokedexEntry struct {
+	EntryNumber    int           `json:"entry_number"`
+	PokemonSpecies NamedResource `json:"pokemon_species"`
+}
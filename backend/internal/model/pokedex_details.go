@@ -0,0 +1,28 @@
+package model
+
+type Pokedex_summary struct {
+	Name          string   `json:"name"`
+	Region        string   `json:"region"`
+	IsMainSeries  bool     `json:"is_main_series"`
+	VersionGroups []string `json:"version_groups"`
+	EntryCount    int      `json:"entry_count"`
+}
+
+// A page of the entries of a pokedex ordered by their regional number
+type Pokedex_entries struct {
+	Pokedex string          `json:"pokedex"`
+	Region  string          `json:"region"`
+	Total   int             `json:"total"`
+	Offset  int             `json:"offset"`
+	Limit   int             `json:"limit"`
+	Entries []Pokedex_entry `json:"entries"`
+}
+
+// The pokemon of an entry is the default pokemon of its species
+type Pokedex_entry struct {
+	EntryNumber int      `json:"entry_number"`
+	PokemonID   int      `json:"pokemon_id"`
+	Name        string   `json:"name"`
+	SpriteUrl   string   `json:"sprite_url"`
+	Types       []string `json:"types"`
+}
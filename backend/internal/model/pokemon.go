@@ -1,5 +1,10 @@
 package model
 
+import (
+	"strconv"
+	"strings"
+)
+
 type Pokemon struct {
 	ID                     int                  `json:"id"`
 	Name                   string               `json:"name"`
@@ -28,6 +33,13 @@ type NamedResource struct {
 	URL  string `json:"url"`
 }
 
+// Extracts the resource ID from the url, e.g. ".../pokemon/25/" returns 25
+func (r NamedResource) ID() int {
+	parts := strings.Split(strings.TrimSuffix(r.URL, "/"), "/")
+	id, _ := strconv.Atoi(parts[len(parts)-1])
+	return id
+}
+
 type PokemonAbility struct {
 	Slot     int           `json:"slot"`
 	IsHidden bool          `json:"is_hidden"`
@@ -44,11 +56,6 @@ type HeldItem struct {
 	VersionDetails []PokemonHeldItemVersion `json:"version_details"`
 }
 
-type Item struct {
-	Name string `json:"name"`
-	Url  string `json:"url"`
-}
-
 type PokemonHeldItemVersion struct {
 	Rarity  int           `json:"rarity"`
 	Version NamedResource `json:"version"`
@@ -82,14 +89,24 @@ type PokemonAbilityPast struct {
 }
 
 type PokemonSprites struct {
-	FrontDefault     string `json:"front_default"`
-	FrontShiny       string `json:"front_shiny"`
-	FrontFemale      string `json:"front_female"`
-	FrontFemaleShiny string `json:"front_female_shiny"`
-	BackDefault      string `json:"back_default"`
-	BackShiny        string `json:"back_shiny"`
-	BackFemale       string `json:"back_female"`
-	BackShinyFemale  string `json:"back_shiny_female"`
+	FrontDefault     string              `json:"front_default"`
+	FrontShiny       string              `json:"front_shiny"`
+	FrontFemale      string              `json:"front_female"`
+	FrontShinyFemale string              `json:"front_shiny_female"`
+	BackDefault      string              `json:"back_default"`
+	BackShiny        string              `json:"back_shiny"`
+	BackFemale       string              `json:"back_female"`
+	BackShinyFemale  string              `json:"back_shiny_female"`
+	Other            PokemonOtherSprites `json:"other"`
+}
+
+type PokemonOtherSprites struct {
+	OfficialArtwork PokemonArtwork `json:"official-artwork"`
+}
+
+type PokemonArtwork struct {
+	FrontDefault string `json:"front_default"`
+	FrontShiny   string `json:"front_shiny"`
 }
 
 type PokemonCries struct {
@@ -0,0 +1,14 @@
+package model
+
+// Pokemon to look up at once, by id, by name or both
+type Pokemon_batch_request struct {
+	IDs   []int    `json:"ids"`
+	Names []string `json:"names"`
+}
+
+type Pokemon_batch struct {
+	// Ids first, then names, each in the requested order. Pokemon requested twice are listed once
+	Pokemon []Pokemon_summary `json:"pokemon"`
+	// Requested ids and names pokeapi doesn't know
+	NotFound []string `json:"not_found"`
+}
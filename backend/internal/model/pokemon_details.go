@@ -9,6 +9,20 @@ type Pokemon_details struct {
 	Types          []string          `json:"types"`
 	Stats          []pokemon_stat    `json:"stats"`
 	EvolutionChain []evolution_chain `json:"evolution_chain"`
+	HeldItems      []held_item       `json:"held_items"`
+	// Index of the pokemon in the games of every version it appears in, ordered by release
+	GameIndices []game_index `json:"game_indices"`
+	// Every variety and form of the pokemon's species, e.g. regional, mega and cosmetic forms
+	Forms []Pokemon_form `json:"forms"`
+	// Paths of the cries served by this api, variants the pokemon doesn't have are left out
+	Cries Pokemon_cries `json:"cries"`
+	// Only present when a language is requested
+	LocalizedNames *Localized_names `json:"localized_names,omitempty"`
+}
+
+type Pokemon_cries struct {
+	Latest string `json:"latest,omitempty"`
+	Legacy string `json:"legacy,omitempty"`
 }
 
 type pokemon_stat struct {
@@ -24,4 +38,24 @@ type evolution_chain struct {
 	EvolvesToName string `json:"evolves_to_name"`
 	MinLevel      int    `json:"min_level"`
 	TriggerName   string `json:"trigger_name"`
+	// Every alternative way of evolving, e.g. Leafeon evolves with a Leaf Stone or by leveling up near a Moss Rock
+	Details []Evolution_condition `json:"details"`
+	Summary string                `json:"summary"`
+}
+
+// Item the pokemon may hold when encountered in the wild
+type held_item struct {
+	Item      string              `json:"item"`
+	SpriteUrl string              `json:"sprite_url"`
+	Versions  []held_item_version `json:"versions"`
+}
+
+type held_item_version struct {
+	Version string `json:"version"`
+	Rarity  int    `json:"rarity"`
+}
+
+type game_index struct {
+	Version   string `json:"version"`
+	GameIndex int    `json:"game_index"`
 }
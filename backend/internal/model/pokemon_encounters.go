@@ -0,0 +1,23 @@
+package model
+
+type Pokemon_encounters struct {
+	PokemonID   int                   `json:"pokemon_id"`
+	PokemonName string                `json:"pokemon_name"`
+	Version     string                `json:"version,omitempty"`
+	Locations   []Location_encounters `json:"locations"`
+}
+
+// Encounters grouped by the location area (route, cave floor etc.) they happen in
+type Location_encounters struct {
+	LocationArea string             `json:"location_area"`
+	Encounters   []Encounter_detail `json:"encounters"`
+}
+
+type Encounter_detail struct {
+	Version    string   `json:"version"`
+	Method     string   `json:"method"`
+	MinLevel   int      `json:"min_level"`
+	MaxLevel   int      `json:"max_level"`
+	Chance     int      `json:"chance"`
+	Conditions []string `json:"conditions"`
+}
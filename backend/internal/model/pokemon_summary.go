@@ -7,4 +7,6 @@ type Pokemon_summary struct {
 	Height    int      `json:"height"`
 	SpriteUrl string   `json:"sprite_url"`
 	Types     []string `json:"types"`
+	// Only present when a language is requested
+	LocalizedNames *Localized_names `json:"localized_names,omitempty"`
 }
@@ -0,0 +1,53 @@
+package model
+
+// A playthrough of one game version, tracking the pokemon seen and caught in it
+type Save struct {
+	ID          int    `json:"id"`
+	TrainerName string `json:"trainer_name"`
+	Version     string `json:"version"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// Caught implies seen and shiny implies caught
+type Save_pokemon_status struct {
+	Seen   bool `json:"seen"`
+	Caught bool `json:"caught"`
+	Shiny  bool `json:"shiny"`
+}
+
+type Save_entry struct {
+	SpeciesID   int    `json:"species_id"`
+	SpeciesName string `json:"species_name"`
+	SpriteUrl   string `json:"sprite_url"`
+	Seen        bool   `json:"seen"`
+	Caught      bool   `json:"caught"`
+	Shiny       bool   `json:"shiny"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type Completion struct {
+	// Generation name, or "all" for the national dex
+	Generation    string  `json:"generation"`
+	Total         int     `json:"total"`
+	Seen          int     `json:"seen"`
+	Caught        int     `json:"caught"`
+	Shiny         int     `json:"shiny"`
+	CaughtPercent float64 `json:"caught_percent"`
+}
+
+type Save_completion struct {
+	SaveID int `json:"save_id"`
+	// Set when the completion only counts the species of a regional pokedex
+	Pokedex     string       `json:"pokedex,omitempty"`
+	Total       Completion   `json:"total"`
+	Generations []Completion `json:"generations"`
+}
+
+// Uncaught pokemon with wild encounters in the version of a save
+type Obtainable_pokemon struct {
+	PokemonID     int      `json:"pokemon_id"`
+	PokemonName   string   `json:"pokemon_name"`
+	SpriteUrl     string   `json:"sprite_url"`
+	Seen          bool     `json:"seen"`
+	LocationAreas []string `json:"location_areas"`
+}
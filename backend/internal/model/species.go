@@ -0,0 +1,53 @@
+package model
+
+// PokeAPI response of /pokemon-species/{id}
+type PokemonSpecies struct {
+	ID                   int                     `json:"id"`
+	Name                 string                  `json:"name"`
+	Order                int                     `json:"order"`
+	IsBaby               bool                    `json:"is_baby"`
+	IsLegendary          bool                    `json:"is_legendary"`
+	IsMythical           bool                    `json:"is_mythical"`
+	HasGenderDifferences bool                    `json:"has_gender_differences"`
+	FormsSwitchable      bool                    `json:"forms_switchable"`
+	Generation           NamedResource           `json:"generation"`
+	EvolutionChain       NamedResource           `json:"evolution_chain"`
+	Varieties            []PokemonSpeciesVariety `json:"varieties"`
+	FlavorTextEntries    []FlavorText            `json:"flavor_text_entries"`
+	Names                []Name                  `json:"names"`
+}
+
+// Pokedex entry text of one version in one language, the text keeps the line breaks of the game
+type FlavorText struct {
+	FlavorText string        `json:"flavor_text"`
+	Language   NamedResource `json:"language"`
+	Version    NamedResource `json:"version"`
+}
+
+type PokemonSpeciesVariety struct {
+	IsDefault bool          `json:"is_default"`
+	Pokemon   NamedResource `json:"pokemon"`
+}
+
+// PokeAPI response of /pokemon-form/{name}
+type PokemonForm struct {
+	ID           int                `json:"id"`
+	Name         string             `json:"name"`
+	Order        int                `json:"order"`
+	FormOrder    int                `json:"form_order"`
+	IsDefault    bool               `json:"is_default"`
+	IsBattleOnly bool               `json:"is_battle_only"`
+	IsMega       bool               `json:"is_mega"`
+	FormName     string             `json:"form_name"`
+	Pokemon      NamedResource      `json:"pokemon"`
+	Sprites      PokemonFormSprites `json:"sprites"`
+	VersionGroup NamedResource      `json:"version_group"`
+	Types        []PokemonType      `json:"types"`
+}
+
+type PokemonFormSprites struct {
+	FrontDefault string `json:"front_default"`
+	FrontShiny   string `json:"front_shiny"`
+	BackDefault  string `json:"back_default"`
+	BackShiny    string `json:"back_shiny"`
+}
@@ -0,0 +1,73 @@
+package model
+
+import "strings"
+
+// A species (e.g. raichu) has one or more varieties, which are separate pokemon (raichu, raichu-alola).
+// Every variety has one or more forms, which only differ in looks (e.g. unown-a, unown-b)
+type Species_varieties struct {
+	SpeciesID int               `json:"species_id"`
+	Name      string            `json:"name"`
+	Varieties []Pokemon_variety `json:"varieties"`
+}
+
+type Pokemon_variety struct {
+	PokemonID int            `json:"pokemon_id"`
+	Name      string         `json:"name"`
+	IsDefault bool           `json:"is_default"`
+	Category  string         `json:"category"`
+	SpriteUrl string         `json:"sprite_url"`
+	Types     []string       `json:"types"`
+	Forms     []Pokemon_form `json:"forms"`
+}
+
+type Pokemon_form struct {
+	FormID         int    `json:"form_id"`
+	PokemonID      int    `json:"pokemon_id"`
+	Name           string `json:"name"`
+	FormName       string `json:"form_name"`
+	Category       string `json:"category"`
+	IsDefault      bool   `json:"is_default"`
+	IsBattleOnly   bool   `json:"is_battle_only"`
+	IsMega         bool   `json:"is_mega"`
+	SpriteUrl      string `json:"sprite_url"`
+	ShinySpriteUrl string `json:"shiny_sprite_url"`
+}
+
+// Form categories
+const (
+	FormDefault    = "default"
+	FormRegional   = "regional"
+	FormMega       = "mega"
+	FormGigantamax = "gigantamax"
+	FormBattle     = "battle"
+	FormAlternate  = "alternate"
+	FormCosmetic   = "cosmetic"
+)
+
+var regionalForms = []string{"alola", "galar", "hisui", "paldea"}
+
+// Categorizes a form. Non-default forms of a pokemon only differ in looks and are cosmetic,
+// default forms of non-default varieties are categorized by their form name
+func FormCategory(form Pokemon_form, isDefaultVariety bool) string {
+	if !form.IsDefault {
+		return FormCosmetic
+	}
+	if isDefaultVariety {
+		return FormDefault
+	}
+	if form.IsMega || strings.HasPrefix(form.FormName, "mega") {
+		return FormMega
+	}
+	if form.FormName == "gmax" {
+		return FormGigantamax
+	}
+	for _, region := range regionalForms {
+		if form.FormName == region || strings.HasPrefix(form.FormName, region+"-") {
+			return FormRegional
+		}
+	}
+	if form.IsBattleOnly {
+		return FormBattle
+	}
+	return FormAlternate
+}
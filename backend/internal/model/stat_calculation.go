@@ -0,0 +1,48 @@
+package model
+
+type Stat_calculation_request struct {
+	Level   int    `json:"level"`
+	Nature  string `json:"nature"`
+	Formula string `json:"formula"`
+	// IVs default to 31 when not given
+	IVs *Stat_spread `json:"ivs"`
+	EVs Stat_spread  `json:"evs"`
+	// Awakening values and friendship of the lets-go formula
+	AVs        Stat_spread `json:"avs"`
+	Friendship int         `json:"friendship"`
+	// Stat points of the champions formula
+	StatPoints Stat_spread `json:"stat_points"`
+}
+
+type Calculated_stats struct {
+	PokemonID   int         `json:"pokemon_id"`
+	PokemonName string      `json:"pokemon_name"`
+	Level       int         `json:"level"`
+	Nature      string      `json:"nature"`
+	Formula     string      `json:"formula"`
+	BaseStats   Stat_spread `json:"base_stats"`
+	Stats       Stat_spread `json:"stats"`
+}
+
+// Stats seen in game at one level, EVs are the ones known at that point
+type Stat_snapshot struct {
+	Level int         `json:"level"`
+	EVs   Stat_spread `json:"evs"`
+	Stats Stat_spread `json:"stats"`
+}
+
+// Snapshots of the same pokemon at different levels narrow down the IVs
+type Iv_range_request struct {
+	Nature    string          `json:"nature"`
+	Snapshots []Stat_snapshot `json:"snapshots"`
+}
+
+type Iv_ranges struct {
+	PokemonID   int         `json:"pokemon_id"`
+	PokemonName string      `json:"pokemon_name"`
+	Nature      string      `json:"nature"`
+	BaseStats   Stat_spread `json:"base_stats"`
+	// Lowest and highest IV per stat matching every snapshot
+	Min Stat_spread `json:"min"`
+	Max Stat_spread `json:"max"`
+}
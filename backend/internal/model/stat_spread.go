@@ -0,0 +1,67 @@
+package model
+
+// Stat names used by pokeapi
+const (
+	StatHP             = "hp"
+	StatAttack         = "attack"
+	StatDefense        = "defense"
+	StatSpecialAttack  = "special-attack"
+	StatSpecialDefense = "special-defense"
+	StatSpeed          = "speed"
+)
+
+var StatNames = []string{StatHP, StatAttack, StatDefense, StatSpecialAttack, StatSpecialDefense, StatSpeed}
+
+// One value per stat, used for base stats, IVs, EVs and final stats
+type Stat_spread struct {
+	HP             int `json:"hp"`
+	Attack         int `json:"attack"`
+	Defense        int `json:"defense"`
+	SpecialAttack  int `json:"special_attack"`
+	SpecialDefense int `json:"special_defense"`
+	Speed          int `json:"speed"`
+}
+
+func (s Stat_spread) Get(stat string) int {
+	switch stat {
+	case StatHP:
+		return s.HP
+	case StatAttack:
+		return s.Attack
+	case StatDefense:
+		return s.Defense
+	case StatSpecialAttack:
+		return s.SpecialAttack
+	case StatSpecialDefense:
+		return s.SpecialDefense
+	case StatSpeed:
+		return s.Speed
+	}
+	return 0
+}
+
+func (s *Stat_spread) Set(stat string, value int) {
+	switch stat {
+	case StatHP:
+		s.HP = value
+	case StatAttack:
+		s.Attack = value
+	case StatDefense:
+		s.Defense = value
+	case StatSpecialAttack:
+		s.SpecialAttack = value
+	case StatSpecialDefense:
+		s.SpecialDefense = value
+	case StatSpeed:
+		s.Speed = value
+	}
+}
+
+func (s Stat_spread) Total() int {
+	return s.HP + s.Attack + s.Defense + s.SpecialAttack + s.SpecialDefense + s.Speed
+}
+
+// Uniform spread, e.g. all IVs 31
+func NewStatSpread(value int) Stat_spread {
+	return Stat_spread{HP: value, Attack: value, Defense: value, SpecialAttack: value, SpecialDefense: value, Speed: value}
+}
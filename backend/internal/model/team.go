@@ -0,0 +1,75 @@
+package model
+
+type Team_member struct {
+	PokemonID int `json:"pokemon_id"`
+	// Name and sprite are filled in from the stored pokemon
+	PokemonName string `json:"pokemon_name"`
+	SpriteUrl   string `json:"sprite_url"`
+	// Defaults to 50 when not given
+	Level   int      `json:"level"`
+	Ability string   `json:"ability"`
+	Item    string   `json:"item"`
+	Nature  string   `json:"nature"`
+	Moves   []string `json:"moves"`
+	// IVs default to 31 when not given
+	IVs *Stat_spread `json:"ivs"`
+	EVs Stat_spread  `json:"evs"`
+}
+
+// Teams are created and updated with the same shape, the id and timestamps are ignored
+type Team struct {
+	ID        int           `json:"id"`
+	Name      string        `json:"name"`
+	Members   []Team_member `json:"members"`
+	CreatedAt string        `json:"created_at"`
+	UpdatedAt string        `json:"updated_at"`
+	// Only present when a language is requested
+	LocalizedNames *Localized_names `json:"localized_names,omitempty"`
+}
+
+// How every member of a team takes one attacking type
+type Type_matchup struct {
+	Type      string   `json:"type"`
+	Weak      []string `json:"weak"`
+	Resistant []string `json:"resistant"`
+	Immune    []string `json:"immune"`
+}
+
+// Best effectiveness of the team's damaging moves against one defending type
+type Type_coverage struct {
+	Type          string  `json:"type"`
+	Effectiveness float64 `json:"effectiveness"`
+	// Moves hitting the type super effectively, as "pokemon: move"
+	Moves []string `json:"moves"`
+}
+
+type Speed_tier struct {
+	PokemonID   int    `json:"pokemon_id"`
+	PokemonName string `json:"pokemon_name"`
+	Speed       int    `json:"speed"`
+}
+
+type Team_analysis struct {
+	TeamID int    `json:"team_id"`
+	Name   string `json:"name"`
+	// Attacking types at least two members are weak to and fewer members resist
+	SharedWeaknesses []string       `json:"shared_weaknesses"`
+	Defense          []Type_matchup `json:"defense"`
+	// Defending types no damaging move hits super effectively
+	UncoveredTypes []string        `json:"uncovered_types"`
+	Coverage       []Type_coverage `json:"coverage"`
+	// Fastest member first
+	SpeedTiers []Speed_tier `json:"speed_tiers"`
+}
+
+// Error of one line of an imported paste
+type Line_error struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type Team_import_request struct {
+	// Defaults to the name in the paste header
+	Name string `json:"name"`
+	Text string `json:"text"`
+}
@@ -0,0 +1,232 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"poke-atlas/web-service/internal/handlers"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/pokeapi"
+	"poke-atlas/web-service/internal/repository"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+var bulbasaur = model.Pokemon_summary{
+	ID:        1,
+	Name:      "bulbasaur",
+	Weight:    69,
+	Height:    7,
+	SpriteUrl: "https://example/1.png",
+	Types:     []string{"grass", "poison"},
+}
+
+// Shaped like the stored details, lists are empty instead of null
+const bulbasaurDetails = `{
+	"id": 1, "name": "bulbasaur", "weight": 69, "height": 7, "sprite_url": "/api/v1/sprites/1/front-default",
+	"types": ["grass", "poison"],
+	"stats": [
+		{"stat_name": "hp", "effort": 0, "base_stat": 45},
+		{"stat_name": "attack", "effort": 0, "base_stat": 49},
+		{"stat_name": "defense", "effort": 0, "base_stat": 49},
+		{"stat_name": "special-attack", "effort": 1, "base_stat": 65},
+		{"stat_name": "special-defense", "effort": 0, "base_stat": 65},
+		{"stat_name": "speed", "effort": 0, "base_stat": 45}
+	],
+	"evolution_chain": [
+		{"pokemon_id": 1, "pokemon_name": "bulbasaur", "evolves_to_id": 2, "evolves_to_name": "ivysaur", "min_level": 16, "trigger_name": "level-up",
+		 "details": [{"trigger": "level-up", "min_level": 16, "needs_multiplayer": false, "needs_overworld_rain": false, "turn_upside_down": false}]}
+	],
+	"held_items": [],
+	"game_indices": [{"version": "red", "game_index": 153}],
+	"forms": [
+		{"form_id": 1, "pokemon_id": 1, "name": "bulbasaur", "form_name": "", "category": "default", "is_default": true,
+		 "is_battle_only": false, "is_mega": false, "sprite_url": "/api/v1/sprites/1/front-default?form=bulbasaur", "shiny_sprite_url": "/api/v1/sprites/1/front-shiny?form=bulbasaur"}
+	],
+	"cries": {"latest": "/api/v1/cries/1?variant=latest"}
+}`
+
+// Serves bulbasaur, every other method panics through the nil embedded interface
+type fakeRepository struct {
+	repository.Repository
+	details model.Pokemon_details
+}
+
+func (r fakeRepository) GetPokemon(ctx context.Context, name string) (model.Pokemon_summary, error) {
+	if name != bulbasaur.Name {
+		return model.Pokemon_summary{}, fmt.Errorf("pokemon %s: %w", name, pokeapi.ErrNotFound)
+	}
+	return bulbasaur, nil
+}
+
+func (r fakeRepository) GetPokemons(ctx context.Context, offset int, limit int) ([]model.Pokemon_summary, error) {
+	return []model.Pokemon_summary{bulbasaur}, nil
+}
+
+func (r fakeRepository) GetPokemonDetailed(ctx context.Context, id int) (model.Pokemon_details, error) {
+	details := r.details
+	for i := range details.EvolutionChain {
+		details.EvolutionChain[i].Summary = model.EvolutionSummary(details.EvolutionChain[i].Details)
+	}
+	return details, nil
+}
+
+func (r fakeRepository) GetPokemonBatch(ctx context.Context, req model.Pokemon_batch_request) (model.Pokemon_batch, error) {
+	return model.Pokemon_batch{Pokemon: []model.Pokemon_summary{bulbasaur}, NotFound: []string{}}, nil
+}
+
+func (r fakeRepository) GetLocalizedNames(ctx context.Context, language string, refs repository.NameRefs) (model.Localized_names, error) {
+	return model.Localized_names{
+		Language: language,
+		Pokemon:  map[string]string{"bulbasaur": "Bisasam"},
+		Types:    map[string]string{"grass": "Pflanze", "poison": "Gift"},
+	}, nil
+}
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	doc, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validation, err := ValidationMiddleware(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var details model.Pokemon_details
+	if err := json.Unmarshal([]byte(bulbasaurDetails), &details); err != nil {
+		t.Fatal(err)
+	}
+	handler := handlers.NewHandler(fakeRepository{details: details})
+
+	router := gin.New()
+	router.Use(validation)
+	routes := router.Group("/api/v1")
+	routes.GET("/pokemon/:name", handler.GetPokemonHandler)
+	routes.POST("/pokemon/batch", handler.GetPokemonBatchHandler)
+	routes.GET("/pokemons/:offset", handler.GetPokemonsHandler)
+	routes.GET("/pokemondetailed/:id", handler.GetPokemonDetailedHandler)
+	return router
+}
+
+func serve(router *gin.Engine, method string, path string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestLoad(t *testing.T) {
+	doc, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := SpecHandler(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	handler(c)
+
+	var served map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &served); err != nil {
+		t.Fatalf("served spec is not json: %v", err)
+	}
+	if served["openapi"] != "3.0.3" {
+		t.Errorf("openapi = %v, want 3.0.3", served["openapi"])
+	}
+}
+
+func TestResponsesMatchSpec(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/api/v1/pokemon/bulbasaur", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/pokemon/bulbasaur?lang=de", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/pokemon/missingno", "", http.StatusNotFound},
+		{http.MethodPost, "/api/v1/pokemon/batch", `{"ids": [1], "names": ["bulbasaur"]}`, http.StatusOK},
+		{http.MethodGet, "/api/v1/pokemons/0?limit=1", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/pokemons/0?lang=de", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/pokemondetailed/1", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/pokemondetailed/1?lang=de", "", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		recorder := serve(router, test.method, test.path, test.body)
+		if recorder.Code != test.status {
+			t.Errorf("%s %s = %d, want %d: %s", test.method, test.path, recorder.Code, test.status, recorder.Body)
+		}
+	}
+}
+
+func TestValidationMiddlewareRejectsInvalidRequests(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/api/v1/pokemons/-1", ""},
+		{http.MethodGet, "/api/v1/pokemons/0?limit=0", ""},
+		{http.MethodGet, "/api/v1/pokemon/bulbasaur?lang=xx", ""},
+		{http.MethodPost, "/api/v1/pokemon/batch", `{"ids": ["bulbasaur"]}`},
+	}
+
+	for _, test := range tests {
+		recorder := serve(router, test.method, test.path, test.body)
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("%s %s = %d, want %d: %s", test.method, test.path, recorder.Code, http.StatusBadRequest, recorder.Body)
+		}
+	}
+}
+
+func TestValidationMiddlewareRejectsInvalidResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validation, err := ValidationMiddleware(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.Use(validation)
+	// Missing the types the frontend relies on
+	router.GET("/pokemon/:name", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": 1, "name": "bulbasaur", "weight": 69, "height": 7, "sprite_url": ""})
+	})
+	// Status that isn't documented
+	router.GET("/pokemondetailed/:id", func(c *gin.Context) {
+		c.JSON(http.StatusTeapot, gin.H{"error": "teapot"})
+	})
+	router.GET("/undocumented", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	for _, path := range []string{"/pokemon/bulbasaur", "/pokemondetailed/1", "/undocumented"} {
+		recorder := serve(router, http.MethodGet, path, "")
+		if recorder.Code != http.StatusInternalServerError {
+			t.Errorf("GET %s = %d, want %d: %s", path, recorder.Code, http.StatusInternalServerError, recorder.Body)
+		}
+	}
+}
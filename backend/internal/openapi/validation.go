@@ -0,0 +1,107 @@
+package openapi
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// Holds the response back until it is validated
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+// Validates every request and response against the spec. It is meant for tests so handler changes
+// can't silently drift from the documented contract: invalid requests are answered with 400
+// and responses that don't match the spec are replaced with a 500 explaining the mismatch
+func ValidationMiddleware(doc *openapi3.T) (gin.HandlerFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": c.Request.Method + " " + c.Request.URL.Path + " is not in the openapi spec: " + err.Error()})
+			return
+		}
+
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				// Defaults are left to the handlers so validation doesn't change the request
+				SkipSettingDefaults: true,
+				// The admin token is checked by the router
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), requestInput); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		original := c.Writer
+		writer := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = original
+
+		// Media and text bodies are not described by schemas, only their status and content type are checked
+		mediaType, _, _ := mime.ParseMediaType(writer.Header().Get("Content-Type"))
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 writer.status,
+			Header:                 writer.Header(),
+			Options: &openapi3filter.Options{
+				IncludeResponseStatus: true,
+				ExcludeResponseBody:   mediaType != "application/json",
+			},
+		}
+		responseInput.SetBodyBytes(writer.body.Bytes())
+
+		if err := openapi3filter.ValidateResponse(c.Request.Context(), responseInput); err != nil {
+			original.Header().Set("Content-Type", "application/json; charset=utf-8")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "response doesn't match the openapi spec: " + err.Error()})
+			return
+		}
+
+		original.WriteHeader(writer.status)
+		original.Write(writer.body.Bytes())
+	}, nil
+}
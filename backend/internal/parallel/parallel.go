@@ -0,0 +1,36 @@
+package parallel
+
+import "sync"
+
+// Calls fetch for every item with at most limit calls running at once. Every call runs to completion,
+// results and errors are in the order of the items so callers decide which errors to skip
+func Map[T any, R any](items []T, limit int, fetch func(T) (R, error)) ([]R, []error) {
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(limit, 1))
+
+	for i, item := range items {
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i], errs[i] = fetch(item)
+		}(i, item)
+	}
+	wg.Wait()
+
+	return results, errs
+}
+
+// The error of the first failing item, nil if every item succeeded
+func FirstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
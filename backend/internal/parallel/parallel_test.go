@@ -0,0 +1,67 @@
+package parallel
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMapKeepsOrder(t *testing.T) {
+	items := []int{5, 1, 4, 2, 3}
+	results, errs := Map(items, 2, func(n int) (int, error) {
+		// Later items finish first
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n * 10, nil
+	})
+
+	if !slices.Equal(results, []int{50, 10, 40, 20, 30}) {
+		t.Errorf("results = %v", results)
+	}
+	if err := FirstError(errs); err != nil {
+		t.Errorf("FirstError = %v", err)
+	}
+}
+
+func TestMapLimitsConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	Map(make([]int, 20), 3, func(int) (struct{}, error) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return struct{}{}, nil
+	})
+
+	if peak.Load() > 3 {
+		t.Errorf("%d calls ran at once, limit is 3", peak.Load())
+	}
+}
+
+func TestMapRunsEveryItem(t *testing.T) {
+	errOdd := errors.New("odd")
+	var calls atomic.Int32
+	_, errs := Map([]int{2, 3, 4, 5}, 2, func(n int) (int, error) {
+		calls.Add(1)
+		if n%2 == 1 {
+			return 0, errOdd
+		}
+		return n, nil
+	})
+
+	if calls.Load() != 4 {
+		t.Errorf("%d calls, want 4", calls.Load())
+	}
+	if errs[0] != nil || errs[1] != errOdd || errs[2] != nil || errs[3] != errOdd {
+		t.Errorf("errs = %v", errs)
+	}
+	if err := FirstError(errs); err != errOdd {
+		t.Errorf("FirstError = %v, want %v", err, errOdd)
+	}
+}
@@ -3,17 +3,59 @@ package pokeapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
 	"poke-atlas/web-service/internal/model"
-	"sync"
+	"poke-atlas/web-service/internal/parallel"
+	"regexp"
 )
 
 type PokeAPIClient interface {
 	GetPokemon(ctx context.Context, name string) (model.Pokemon, error)
 	GetPokemons(ctx context.Context, offset int, limit int) ([]model.Pokemon, error)
-	GetEvolutionChain(ctx context.Context, pokemonID int) (model.Evolution_chain, error)
+	GetEvolutionChain(ctx context.Context, speciesID int) (model.Evolution_chain, error)
+	GetEvolutionChainByID(ctx context.Context, chainID int) (model.Evolution_chain, error)
+	GetPokemonEncounters(ctx context.Context, pokemonID int) ([]model.LocationAreaEncounter, error)
+	GetPokemonSpecies(ctx context.Context, speciesID int) (model.PokemonSpecies, error)
+	GetPokemonSpeciesByName(ctx context.Context, name string) (model.PokemonSpecies, error)
+	GetPokemonForm(ctx context.Context, name string) (model.PokemonForm, error)
+	GetItem(ctx context.Context, name string) (model.Item, error)
+	GetNatures(ctx context.Context) ([]model.Nature, error)
+	GetCharacteristics(ctx context.Context) ([]model.Characteristic, error)
+	GetMove(ctx context.Context, name string) (model.Move, error)
+	GetTypes(ctx context.Context) ([]model.Type, error)
+	GetStats(ctx context.Context) ([]model.Stat, error)
+	GetAbility(ctx context.Context, name string) (model.Ability, error)
+	GetGenerations(ctx context.Context) ([]model.Generation, error)
+	GetVersionGroups(ctx context.Context) ([]model.VersionGroup, error)
+	GetPokedexes(ctx context.Context) ([]model.Pokedex, error)
+	GetLocations(ctx context.Context) ([]model.NamedResource, error)
+	GetLocation(ctx context.Context, name string) (model.Location, error)
+	GetLocationArea(ctx context.Context, name string) (model.LocationArea, error)
+	GetMedia(ctx context.Context, url string) ([]byte, string, error)
+}
+
+// Names pokeapi could know as a resource, anything else must not end up in a request url
+var SlugPattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// Matches a StatusError with status 404 in errors.Is
+var ErrNotFound = errors.New("not found in PokeAPI")
+
+// Returned when PokeAPI responds with another status than 200
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("PokeAPI returned status %d: %s", e.StatusCode, e.Body)
+}
+
+func (e StatusError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
 }
 
 type pokeAPIClient struct {
@@ -45,7 +87,7 @@ func (c *pokeAPIClient) GetPokemon(ctx context.Context, name string) (model.Poke
 
 	if response.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(response.Body)
-		return model.Pokemon{}, fmt.Errorf("PokeAPI returned status %d: %s", response.StatusCode, string(body))
+		return model.Pokemon{}, StatusError{StatusCode: response.StatusCode, Body: string(body)}
 	}
 
 	body, err := io.ReadAll(response.Body)
@@ -72,151 +114,349 @@ func (c *pokeAPIClient) GetPokemons(ctx context.Context, offset int, limit int)
 		"https://pokeapi.co/api/v2/pokemon?offset=%d&limit=%d", offset, limit,
 	)
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL, nil)
+	return getAll[model.Pokemon](ctx, c, listURL)
+}
+
+func (c *pokeAPIClient) GetEvolutionChain(ctx context.Context, speciesID int) (model.Evolution_chain, error) {
+	// Step 1: Get pokemon species to find evolution chain URL
+	speciesURL := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-species/%d", speciesID)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, speciesURL, nil)
 	if err != nil {
-		return nil, err
+		return model.Evolution_chain{}, fmt.Errorf("creating species request: %w", err)
 	}
 
 	response, err := c.client.Do(request)
 	if err != nil {
-		return nil, err
+		return model.Evolution_chain{}, fmt.Errorf("fetching species: %w", err)
 	}
 	defer response.Body.Close()
 
-	body, err := io.ReadAll(response.Body)
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return model.Evolution_chain{}, fmt.Errorf("species API returned status %d: %s", response.StatusCode, string(body))
+	}
+
+	var speciesData struct {
+		EvolutionChain struct {
+			URL string `json:"url"`
+		} `json:"evolution_chain"`
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(&speciesData); err != nil {
+		return model.Evolution_chain{}, fmt.Errorf("decoding species data: %w", err)
+	}
+
+	// Step 2: Fetch the evolution chain using the URL
+	chainRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, speciesData.EvolutionChain.URL, nil)
 	if err != nil {
-		return nil, err
+		return model.Evolution_chain{}, fmt.Errorf("creating chain request: %w", err)
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Pokeapi returned status %d: %s", response.StatusCode, string(body))
+	chainResponse, err := c.client.Do(chainRequest)
+	if err != nil {
+		return model.Evolution_chain{}, fmt.Errorf("fetching evolution chain: %w", err)
 	}
+	defer chainResponse.Body.Close()
 
-	type PokemonListResponse struct {
-		Results []struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"results"`
+	if chainResponse.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(chainResponse.Body)
+		return model.Evolution_chain{}, fmt.Errorf("evolution chain API returned status %d: %s", chainResponse.StatusCode, string(body))
 	}
 
-	var list PokemonListResponse
-	if err := json.Unmarshal(body, &list); err != nil {
-		return nil, err
+	var chain model.Evolution_chain
+	if err := json.NewDecoder(chainResponse.Body).Decode(&chain); err != nil {
+		return model.Evolution_chain{}, fmt.Errorf("decoding evolution chain: %w", err)
 	}
 
-	type result struct {
-		pokemon model.Pokemon
-		err     error
+	return chain, nil
+}
+
+func (c *pokeAPIClient) GetEvolutionChainByID(ctx context.Context, chainID int) (model.Evolution_chain, error) {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/evolution-chain/%d", chainID)
+
+	var chain model.Evolution_chain
+	if err := c.getJSON(ctx, url, &chain); err != nil {
+		return model.Evolution_chain{}, fmt.Errorf("fetching evolution chain: %w", err)
 	}
 
-	// Fetch pokemon data concurrently using goroutines
-	results := make(chan result, len(list.Results))
-	sem := make(chan struct{}, 5) // Limit max concurrent goroutines to 5
+	return chain, nil
+}
 
-	var wg sync.WaitGroup
+func (c *pokeAPIClient) GetPokemonEncounters(ctx context.Context, pokemonID int) ([]model.LocationAreaEncounter, error) {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%d/encounters", pokemonID)
 
-	for _, entry := range list.Results {
-		wg.Add(1)
+	var encounters []model.LocationAreaEncounter
+	if err := c.getJSON(ctx, url, &encounters); err != nil {
+		return nil, fmt.Errorf("fetching encounters: %w", err)
+	}
 
-		go func(url string) {
-			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
+	return encounters, nil
+}
 
-			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-			if err != nil {
-				results <- result{err: err}
-				return
-			}
+func (c *pokeAPIClient) GetPokemonSpecies(ctx context.Context, speciesID int) (model.PokemonSpecies, error) {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-species/%d", speciesID)
 
-			resp, err := c.client.Do(req)
-			if err != nil {
-				results <- result{err: err}
-				return
-			}
-			defer resp.Body.Close()
+	var species model.PokemonSpecies
+	if err := c.getJSON(ctx, url, &species); err != nil {
+		return model.PokemonSpecies{}, fmt.Errorf("fetching species: %w", err)
+	}
 
-			if resp.StatusCode != http.StatusOK {
-				results <- result{err: fmt.Errorf("pokemon status %d", resp.StatusCode)}
-				return
-			}
+	return species, nil
+}
 
-			var p model.Pokemon
-			if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
-				results <- result{err: err}
-				return
-			}
+func (c *pokeAPIClient) GetPokemonSpeciesByName(ctx context.Context, name string) (model.PokemonSpecies, error) {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-species/%s", name)
 
-			results <- result{pokemon: p}
-		}(entry.URL)
+	var species model.PokemonSpecies
+	if err := c.getJSON(ctx, url, &species); err != nil {
+		return model.PokemonSpecies{}, fmt.Errorf("fetching species: %w", err)
 	}
 
-	wg.Wait()
-	close(results)
+	return species, nil
+}
 
-	// Collect results
-	pokemons := make([]model.Pokemon, 0, len(list.Results))
-	for res := range results {
-		if res.err != nil {
-			return nil, res.err
-		}
-		pokemons = append(pokemons, res.pokemon)
+func (c *pokeAPIClient) GetPokemonForm(ctx context.Context, name string) (model.PokemonForm, error) {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-form/%s", name)
+
+	var form model.PokemonForm
+	if err := c.getJSON(ctx, url, &form); err != nil {
+		return model.PokemonForm{}, fmt.Errorf("fetching pokemon form: %w", err)
 	}
 
-	return pokemons, nil
+	return form, nil
 }
 
-func (c *pokeAPIClient) GetEvolutionChain(ctx context.Context, pokemonID int) (model.Evolution_chain, error) {
-	// Step 1: Get pokemon species to find evolution chain URL
-	speciesURL := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-species/%d", pokemonID)
+func (c *pokeAPIClient) GetItem(ctx context.Context, name string) (model.Item, error) {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/item/%s", name)
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, speciesURL, nil)
+	var item model.Item
+	if err := c.getJSON(ctx, url, &item); err != nil {
+		return model.Item{}, fmt.Errorf("fetching item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (c *pokeAPIClient) GetNatures(ctx context.Context) ([]model.Nature, error) {
+	natures, err := getAll[model.Nature](ctx, c, "https://pokeapi.co/api/v2/nature?offset=0&limit=100")
 	if err != nil {
-		return model.Evolution_chain{}, fmt.Errorf("creating species request: %w", err)
+		return nil, fmt.Errorf("fetching natures: %w", err)
+	}
+
+	return natures, nil
+}
+
+func (c *pokeAPIClient) GetCharacteristics(ctx context.Context) ([]model.Characteristic, error) {
+	characteristics, err := getAll[model.Characteristic](ctx, c, "https://pokeapi.co/api/v2/characteristic?offset=0&limit=100")
+	if err != nil {
+		return nil, fmt.Errorf("fetching characteristics: %w", err)
+	}
+
+	return characteristics, nil
+}
+
+func (c *pokeAPIClient) GetMove(ctx context.Context, name string) (model.Move, error) {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/move/%s", name)
+
+	var move model.Move
+	if err := c.getJSON(ctx, url, &move); err != nil {
+		return model.Move{}, fmt.Errorf("fetching move: %w", err)
+	}
+
+	return move, nil
+}
+
+func (c *pokeAPIClient) GetTypes(ctx context.Context) ([]model.Type, error) {
+	types, err := getAll[model.Type](ctx, c, "https://pokeapi.co/api/v2/type?offset=0&limit=100")
+	if err != nil {
+		return nil, fmt.Errorf("fetching types: %w", err)
+	}
+
+	return types, nil
+}
+
+func (c *pokeAPIClient) GetStats(ctx context.Context) ([]model.Stat, error) {
+	stats, err := getAll[model.Stat](ctx, c, "https://pokeapi.co/api/v2/stat?offset=0&limit=100")
+	if err != nil {
+		return nil, fmt.Errorf("fetching stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (c *pokeAPIClient) GetAbility(ctx context.Context, name string) (model.Ability, error) {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/ability/%s", name)
+
+	var ability model.Ability
+	if err := c.getJSON(ctx, url, &ability); err != nil {
+		return model.Ability{}, fmt.Errorf("fetching ability: %w", err)
+	}
+
+	return ability, nil
+}
+
+func (c *pokeAPIClient) GetGenerations(ctx context.Context) ([]model.Generation, error) {
+	generations, err := getAll[model.Generation](ctx, c, "https://pokeapi.co/api/v2/generation?offset=0&limit=100")
+	if err != nil {
+		return nil, fmt.Errorf("fetching generations: %w", err)
+	}
+
+	return generations, nil
+}
+
+func (c *pokeAPIClient) GetVersionGroups(ctx context.Context) ([]model.VersionGroup, error) {
+	versionGroups, err := getAll[model.VersionGroup](ctx, c, "https://pokeapi.co/api/v2/version-group?offset=0&limit=100")
+	if err != nil {
+		return nil, fmt.Errorf("fetching version groups: %w", err)
+	}
+
+	return versionGroups, nil
+}
+
+func (c *pokeAPIClient) GetPokedexes(ctx context.Context) ([]model.Pokedex, error) {
+	pokedexes, err := getAll[model.Pokedex](ctx, c, "https://pokeapi.co/api/v2/pokedex?offset=0&limit=100")
+	if err != nil {
+		return nil, fmt.Errorf("fetching pokedexes: %w", err)
+	}
+
+	return pokedexes, nil
+}
+
+// Returns the names of every location in pokeapi
+func (c *pokeAPIClient) GetLocations(ctx context.Context) ([]model.NamedResource, error) {
+	url := "https://pokeapi.co/api/v2/location?offset=0&limit=100000"
+
+	var list struct {
+		Results []model.NamedResource `json:"results"`
+	}
+	if err := c.getJSON(ctx, url, &list); err != nil {
+		return nil, fmt.Errorf("fetching locations: %w", err)
+	}
+
+	return list.Results, nil
+}
+
+func (c *pokeAPIClient) GetLocation(ctx context.Context, name string) (model.Location, error) {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/location/%s", name)
+
+	var location model.Location
+	if err := c.getJSON(ctx, url, &location); err != nil {
+		return model.Location{}, fmt.Errorf("fetching location: %w", err)
+	}
+
+	return location, nil
+}
+
+func (c *pokeAPIClient) GetLocationArea(ctx context.Context, name string) (model.LocationArea, error) {
+	url := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%s", name)
+
+	var area model.LocationArea
+	if err := c.getJSON(ctx, url, &area); err != nil {
+		return model.LocationArea{}, fmt.Errorf("fetching location area: %w", err)
+	}
+
+	return area, nil
+}
+
+// Larger files are not cached, official artwork is well below this
+const maxMediaSize = 10 << 20
+
+// Content types of the files pokeapi links to, detecting them would give application/ogg for cries
+var mediaTypes = map[string]string{
+	".png": "image/png",
+	".gif": "image/gif",
+	".svg": "image/svg+xml",
+	".ogg": "audio/ogg",
+}
+
+// Fetches a sprite or cry linked from pokeapi, returns the data with its content type
+func (c *pokeAPIClient) GetMedia(ctx context.Context, url string) ([]byte, string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating request: %w", err)
 	}
 
 	response, err := c.client.Do(request)
 	if err != nil {
-		return model.Evolution_chain{}, fmt.Errorf("fetching species: %w", err)
+		return nil, "", fmt.Errorf("fetching media: %w", err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(response.Body)
-		return model.Evolution_chain{}, fmt.Errorf("species API returned status %d: %s", response.StatusCode, string(body))
+		return nil, "", StatusError{StatusCode: response.StatusCode, Body: string(body)}
 	}
 
-	var speciesData struct {
-		EvolutionChain struct {
-			URL string `json:"url"`
-		} `json:"evolution_chain"`
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxMediaSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("reading media: %w", err)
+	}
+	if len(data) > maxMediaSize {
+		return nil, "", fmt.Errorf("media at %s is larger than %d bytes", url, maxMediaSize)
 	}
 
-	if err := json.NewDecoder(response.Body).Decode(&speciesData); err != nil {
-		return model.Evolution_chain{}, fmt.Errorf("decoding species data: %w", err)
+	// raw.githubusercontent.com serves everything as text/plain
+	contentType, ok := mediaTypes[path.Ext(request.URL.Path)]
+	if !ok {
+		contentType = http.DetectContentType(data)
 	}
 
-	// Step 2: Fetch the evolution chain using the URL
-	chainRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, speciesData.EvolutionChain.URL, nil)
+	return data, contentType, nil
+}
+
+// Helper for fetching a pokeapi resource and decoding the JSON body into target
+func (c *pokeAPIClient) getJSON(ctx context.Context, url string, target any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return model.Evolution_chain{}, fmt.Errorf("creating chain request: %w", err)
+		return fmt.Errorf("creating request: %w", err)
 	}
 
-	chainResponse, err := c.client.Do(chainRequest)
+	response, err := c.client.Do(request)
 	if err != nil {
-		return model.Evolution_chain{}, fmt.Errorf("fetching evolution chain: %w", err)
+		return err
 	}
-	defer chainResponse.Body.Close()
+	defer response.Body.Close()
 
-	if chainResponse.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(chainResponse.Body)
-		return model.Evolution_chain{}, fmt.Errorf("evolution chain API returned status %d: %s", chainResponse.StatusCode, string(body))
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return StatusError{StatusCode: response.StatusCode, Body: string(body)}
 	}
 
-	var chain model.Evolution_chain
-	if err := json.NewDecoder(chainResponse.Body).Decode(&chain); err != nil {
-		return model.Evolution_chain{}, fmt.Errorf("decoding evolution chain: %w", err)
+	if err := json.NewDecoder(response.Body).Decode(target); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
 	}
 
-	return chain, nil
+	return nil
+}
+
+// Limits the concurrent requests when fetching every resource of a list
+const maxConcurrentRequests = 5
+
+// Fetches every resource of a pokeapi list concurrently, results are in the order of the list
+func getAll[T any](ctx context.Context, c *pokeAPIClient, listURL string) ([]T, error) {
+	var list struct {
+		Results []struct {
+			URL string `json:"url"`
+		} `json:"results"`
+	}
+	if err := c.getJSON(ctx, listURL, &list); err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, len(list.Results))
+	for i, entry := range list.Results {
+		urls[i] = entry.URL
+	}
+
+	resources, errs := parallel.Map(urls, maxConcurrentRequests, func(url string) (T, error) {
+		var resource T
+		err := c.getJSON(ctx, url, &resource)
+		return resource, err
+	})
+	if err := parallel.FirstError(errs); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
 }
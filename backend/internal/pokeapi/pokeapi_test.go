@@ -3,6 +3,7 @@ package pokeapi
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"testing"
@@ -85,3 +86,156 @@ func TestGetPokemonFail(t *testing.T) {
 		t.Errorf("Expected no pokemon, got %s", pokemon.Name)
 	}
 }
+
+func TestGetItemNotFound(t *testing.T) {
+	client := &pokeAPIClient{client: &http.Client{
+		Transport: &mockRoundTripper{
+			fn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(bytes.NewBufferString("Not Found")),
+					Header:     make(http.Header),
+				}, nil
+			},
+		},
+	}}
+	_, err := client.GetItem(context.Background(), "not-an-item")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetPokemonEncountersSuccess(t *testing.T) {
+	mockResponse := `[{
+		"location_area": {"name": "viridian-forest-area", "url": "https://pokeapi.co/api/v2/location-area/321/"},
+		"version_details": [{
+			"version": {"name": "red", "url": "https://pokeapi.co/api/v2/version/1/"},
+			"max_chance": 5,
+			"encounter_details": [{
+				"min_level": 3,
+				"max_level": 5,
+				"chance": 5,
+				"condition_values": [],
+				"method": {"name": "walk", "url": "https://pokeapi.co/api/v2/encounter-method/1/"}
+			}]
+		}]
+	}]`
+
+	client := &pokeAPIClient{client: &http.Client{
+		Transport: &mockRoundTripper{
+			fn: func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://pokeapi.co/api/v2/pokemon/25/encounters"
+
+				if req.URL.String() != expectedURL {
+					t.Fatalf("unexpected URL %s", req.URL)
+				}
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(mockResponse)),
+					Header:     make(http.Header),
+				}, nil
+			},
+		},
+	}}
+	encounters, err := client.GetPokemonEncounters(context.Background(), 25)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(encounters) != 1 {
+		t.Fatalf("expected 1 location area, got %d", len(encounters))
+	}
+	if encounters[0].LocationArea.Name != "viridian-forest-area" {
+		t.Errorf("expected location area 'viridian-forest-area', got '%s'", encounters[0].LocationArea.Name)
+	}
+	if encounters[0].VersionDetails[0].EncounterDetails[0].Method.Name != "walk" {
+		t.Errorf("expected method 'walk', got '%s'", encounters[0].VersionDetails[0].EncounterDetails[0].Method.Name)
+	}
+}
+
+func TestGetLocationAreaSuccess(t *testing.T) {
+	mockResponse := `{
+		"id": 295,
+		"name": "kanto-route-1-area",
+		"location": {"name": "kanto-route-1", "url": "https://pokeapi.co/api/v2/location/88/"},
+		"pokemon_encounters": [{
+			"pokemon": {"name": "pidgey", "url": "https://pokeapi.co/api/v2/pokemon/16/"},
+			"version_details": []
+		}]
+	}`
+
+	client := &pokeAPIClient{client: &http.Client{
+		Transport: &mockRoundTripper{
+			fn: func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://pokeapi.co/api/v2/location-area/kanto-route-1-area"
+
+				if req.URL.String() != expectedURL {
+					t.Fatalf("unexpected URL %s", req.URL)
+				}
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(mockResponse)),
+					Header:     make(http.Header),
+				}, nil
+			},
+		},
+	}}
+	area, err := client.GetLocationArea(context.Background(), "kanto-route-1-area")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if area.Location.Name != "kanto-route-1" {
+		t.Errorf("expected location 'kanto-route-1', got '%s'", area.Location.Name)
+	}
+	if area.PokemonEncounters[0].Pokemon.ID() != 16 {
+		t.Errorf("expected pokemon id 16, got %d", area.PokemonEncounters[0].Pokemon.ID())
+	}
+}
+
+func TestGetMoveSuccess(t *testing.T) {
+	mockResponse := `{
+		"id": 89,
+		"name": "earthquake",
+		"power": 100,
+		"accuracy": 100,
+		"pp": 10,
+		"priority": 0,
+		"type": {"name": "ground", "url": "https://pokeapi.co/api/v2/type/5/"},
+		"damage_class": {"name": "physical", "url": "https://pokeapi.co/api/v2/move-damage-class/2/"},
+		"target": {"name": "all-other-pokemon", "url": "https://pokeapi.co/api/v2/move-target/9/"},
+		"meta": {"crit_rate": 0, "min_hits": null, "max_hits": null}
+	}`
+
+	client := &pokeAPIClient{client: &http.Client{
+		Transport: &mockRoundTripper{
+			fn: func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://pokeapi.co/api/v2/move/earthquake"
+
+				if req.URL.String() != expectedURL {
+					t.Fatalf("unexpected URL %s", req.URL)
+				}
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(mockResponse)),
+					Header:     make(http.Header),
+				}, nil
+			},
+		},
+	}}
+	move, err := client.GetMove(context.Background(), "earthquake")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if move.Power == nil || *move.Power != 100 {
+		t.Errorf("expected power 100, got %v", move.Power)
+	}
+	if move.Meta == nil || move.Meta.MinHits != nil {
+		t.Errorf("expected meta without min hits, got %+v", move.Meta)
+	}
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"poke-atlas/web-service/internal/batch"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/parallel"
+	"poke-atlas/web-service/internal/pokeapi"
+	"slices"
+	"strconv"
+)
+
+// Summaries of the pokemon of a request validated with batch.ValidateRequest. Stored pokemon are read in one query,
+// only the missing ones are fetched from pokeapi. Unknown pokemon are listed as not found instead of failing the request
+func (r *repository) GetPokemonBatch(ctx context.Context, req model.Pokemon_batch_request) (model.Pokemon_batch, error) {
+	pokemon, err := r.database.GetPokemonsByRefs(ctx, req.IDs, req.Names)
+	if err != nil {
+		return model.Pokemon_batch{}, err
+	}
+
+	missingIDs, missingNames := batch.Missing(req, pokemon)
+	refs := missingNames
+	for _, id := range missingIDs {
+		refs = append(refs, strconv.Itoa(id))
+	}
+	if len(refs) == 0 {
+		return batch.Result(req, pokemon), nil
+	}
+
+	log.Printf("fetching %d pokemon of the batch from api...", len(refs))
+
+	fetched, errs := parallel.Map(refs, maxConcurrentFetches, func(ref string) (model.Pokemon, error) {
+		return r.pokeAPIClient.GetPokemon(ctx, ref)
+	})
+
+	var added []int
+	for i := range refs {
+		if errors.Is(errs[i], pokeapi.ErrNotFound) {
+			continue
+		}
+		if errs[i] != nil {
+			return model.Pokemon_batch{}, errs[i]
+		}
+		// An id and a name of the request may be the same pokemon
+		if slices.Contains(added, fetched[i].ID) {
+			continue
+		}
+		if err := r.database.AddPokemon(ctx, fetched[i]); err != nil {
+			return model.Pokemon_batch{}, err
+		}
+		added = append(added, fetched[i].ID)
+	}
+
+	if len(added) > 0 {
+		if pokemon, err = r.database.GetPokemonsByRefs(ctx, req.IDs, req.Names); err != nil {
+			return model.Pokemon_batch{}, err
+		}
+	}
+
+	return batch.Result(req, pokemon), nil
+}
+
+// Moves each pokemon can learn keyed by pokemon id, missing pokemon are fetched first
+func (r *repository) GetPokemonMoveNames(ctx context.Context, ids []int) (map[int][]string, error) {
+	if err := r.ensurePokemons(ctx, ids); err != nil {
+		return nil, err
+	}
+	return r.database.GetPokemonsMoveNames(ctx, ids)
+}
+
+// Abilities of each pokemon keyed by pokemon id, missing pokemon are fetched first
+func (r *repository) GetPokemonAbilityNames(ctx context.Context, ids []int) (map[int][]string, error) {
+	if err := r.ensurePokemons(ctx, ids); err != nil {
+		return nil, err
+	}
+	return r.database.GetPokemonsAbilityNames(ctx, ids)
+}
+
+// Species ids keyed by pokemon id, missing pokemon are fetched first
+func (r *repository) GetPokemonSpeciesIDs(ctx context.Context, ids []int) (map[int]int, error) {
+	if err := r.ensurePokemons(ctx, ids); err != nil {
+		return nil, err
+	}
+	return r.database.GetPokemonsSpeciesIDs(ctx, ids)
+}
+
+// Moves keyed by name. Stored moves are read in one query, the missing ones are fetched concurrently.
+// Moves pokeapi doesn't know are left out
+func (r *repository) GetMoves(ctx context.Context, names []string) (map[string]model.Move_details, error) {
+	moves, err := r.database.GetMovesByName(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, name := range names {
+		if _, ok := moves[name]; !ok && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return moves, nil
+	}
+
+	log.Printf("fetching %d moves from api...", len(missing))
+
+	fetched, errs := parallel.Map(missing, maxConcurrentFetches, func(name string) (model.Move, error) {
+		return r.pokeAPIClient.GetMove(ctx, name)
+	})
+
+	for i := range missing {
+		if errors.Is(errs[i], pokeapi.ErrNotFound) {
+			continue
+		}
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if err := r.database.AddMove(ctx, fetched[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.database.GetMovesByName(ctx, names)
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"poke-atlas/web-service/internal/compare"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/parallel"
+)
+
+// Compares the detailed data of the pokemon in the given order, ids are validated with compare.ParseIDs.
+// Unknown ids are pokeapi.ErrNotFound
+func (r *repository) ComparePokemon(ctx context.Context, ids []int) (model.Comparison, error) {
+	// Storing the missing pokemon up front keeps the concurrent lookups below from all writing at once
+	if err := r.ensurePokemons(ctx, ids); err != nil {
+		return model.Comparison{}, err
+	}
+
+	pokemon, errs := parallel.Map(ids, maxConcurrentFetches, func(id int) (model.Pokemon_details, error) {
+		return r.GetPokemonDetailed(ctx, id)
+	})
+	if err := parallel.FirstError(errs); err != nil {
+		return model.Comparison{}, err
+	}
+
+	var learnsets [][]string
+	for _, id := range ids {
+		learnset, err := r.database.GetPokemonMoveNames(ctx, id)
+		if err != nil {
+			return model.Comparison{}, err
+		}
+		learnsets = append(learnsets, learnset)
+	}
+
+	chart, err := r.GetTypeChart(ctx)
+	if err != nil {
+		return model.Comparison{}, err
+	}
+
+	return compare.Compare(pokemon, compare.SharedMoves(learnsets), chart), nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"poke-atlas/web-service/internal/cries"
+	"poke-atlas/web-service/internal/model"
+	"strconv"
+)
+
+// Serves the cry from the media cache, fetching it on the first request.
+// Returns apierror.ValidationError for an unknown variant and sql.ErrNoRows if the pokemon doesn't have the variant
+func (r *repository) GetCry(ctx context.Context, id int, variant string) (model.Cached_media, error) {
+	if err := cries.ValidateVariant(variant); err != nil {
+		return model.Cached_media{}, err
+	}
+
+	if err := r.ensurePokemonCries(ctx, id); err != nil {
+		return model.Cached_media{}, err
+	}
+
+	url, err := r.database.GetPokemonCryURL(ctx, id, variant)
+	if err != nil {
+		return model.Cached_media{}, err
+	}
+
+	return r.cacheMedia(ctx, url)
+}
+
+// Paths of the cries of the pokemon on this server
+func (r *repository) getPokemonCries(ctx context.Context, id int) (model.Pokemon_cries, error) {
+	if err := r.ensurePokemonCries(ctx, id); err != nil {
+		return model.Pokemon_cries{}, err
+	}
+
+	variants, err := r.database.GetPokemonCryVariants(ctx, id)
+	if err != nil {
+		return model.Pokemon_cries{}, err
+	}
+
+	var pokemonCries model.Pokemon_cries
+	for _, variant := range variants {
+		switch variant {
+		case cries.Latest:
+			pokemonCries.Latest = cries.ProxyPath(id, variant)
+		case cries.Legacy:
+			pokemonCries.Legacy = cries.ProxyPath(id, variant)
+		}
+	}
+
+	return pokemonCries, nil
+}
+
+// Fetches the pokemon if it's missing from the database, and the cry urls of pokemon stored before their cries were kept
+func (r *repository) ensurePokemonCries(ctx context.Context, id int) error {
+	if err := r.ensurePokemon(ctx, id); err != nil {
+		return err
+	}
+
+	fetched, err := r.database.HasPokemonCries(ctx, id)
+	if err != nil || fetched {
+		return err
+	}
+
+	log.Printf("cries of pokemon %d not found in the database, fetching from api...", id)
+
+	pokemon, err := r.pokeAPIClient.GetPokemon(ctx, strconv.Itoa(id))
+	if err != nil {
+		return err
+	}
+
+	return r.database.AddPokemonCries(ctx, id, pokemon.Cries)
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"poke-atlas/web-service/internal/damage"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/stats"
+)
+
+func (r *repository) GetMove(ctx context.Context, name string) (model.Move_details, error) {
+	move, err := r.database.GetMove(ctx, name)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("move %s not found in the database!", name)
+
+		fetchedMove, err := r.pokeAPIClient.GetMove(ctx, name)
+		if err != nil {
+			return model.Move_details{}, err
+		}
+
+		err = r.database.AddMove(ctx, fetchedMove)
+		if err != nil {
+			return model.Move_details{}, err
+		}
+
+		return r.database.GetMove(ctx, name)
+	}
+
+	if err != nil {
+		return model.Move_details{}, err
+	}
+
+	return move, nil
+}
+
+func (r *repository) GetTypeChart(ctx context.Context) (model.Type_chart, error) {
+	chart, err := r.database.GetTypeChart(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Println("types not found in the database, fetching from api...")
+
+		fetchedTypes, err := r.pokeAPIClient.GetTypes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.database.AddTypes(ctx, fetchedTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		return r.database.GetTypeChart(ctx)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return chart, nil
+}
+
+// Calculates the damage of every move, the request must be validated with damage.ValidateRequest
+func (r *repository) CalculateDamage(ctx context.Context, req model.Damage_request) (model.Damage_result, error) {
+	chart, err := r.GetTypeChart(ctx)
+	if err != nil {
+		return model.Damage_result{}, err
+	}
+
+	attacker, attackerSummary, err := r.damagePokemon(ctx, req.Attacker)
+	if err != nil {
+		return model.Damage_result{}, err
+	}
+	defender, defenderSummary, err := r.damagePokemon(ctx, req.Defender)
+	if err != nil {
+		return model.Damage_result{}, err
+	}
+
+	result := model.Damage_result{
+		Attacker: attackerSummary,
+		Defender: defenderSummary,
+		Moves:    []model.Move_damage{},
+	}
+
+	for _, name := range req.Moves {
+		move, err := r.GetMove(ctx, name)
+		if err != nil {
+			return model.Damage_result{}, err
+		}
+
+		result.Moves = append(result.Moves, damage.Calculate(attacker, defender, move, req.Field, chart))
+	}
+
+	return result, nil
+}
+
+// Resolves the stored base stats, types and nature of one side into its final stats
+func (r *repository) damagePokemon(ctx context.Context, p model.Damage_pokemon) (damage.Pokemon, model.Damage_combatant, error) {
+	if err := r.ensurePokemon(ctx, p.ID); err != nil {
+		return damage.Pokemon{}, model.Damage_combatant{}, err
+	}
+
+	name, base, err := r.database.GetBaseStats(ctx, p.ID)
+	if err != nil {
+		return damage.Pokemon{}, model.Damage_combatant{}, err
+	}
+
+	types, err := r.database.GetPokemonTypes(ctx, p.ID)
+	if err != nil {
+		return damage.Pokemon{}, model.Damage_combatant{}, err
+	}
+
+	var nature model.Nature_details
+	if p.Nature != "" {
+		nature, err = r.getNature(ctx, p.Nature)
+		if err != nil {
+			return damage.Pokemon{}, model.Damage_combatant{}, err
+		}
+	}
+
+	finalStats := stats.Calculate(base, nature, damage.StatRequest(p))
+
+	pokemon := damage.Pokemon{
+		Level:     p.Level,
+		Types:     types,
+		Stats:     finalStats,
+		Boosts:    p.Boosts,
+		Ability:   p.Ability,
+		Item:      p.Item,
+		Status:    p.Status,
+		CurrentHP: p.CurrentHP,
+	}
+	summary := model.Damage_combatant{
+		PokemonID:   p.ID,
+		PokemonName: name,
+		Types:       types,
+		Stats:       finalStats,
+	}
+
+	return pokemon, summary, nil
+}
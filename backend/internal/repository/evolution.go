@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"poke-atlas/web-service/internal/model"
+)
+
+func (r *repository) GetEvolutionTree(ctx context.Context, chainID int) (model.Evolution_tree, error) {
+	tree, err := r.database.GetEvolutionTree(ctx, chainID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("evolution chain %d not found in the database!", chainID)
+
+		evoChain, err := r.pokeAPIClient.GetEvolutionChainByID(ctx, chainID)
+		if err != nil {
+			return model.Evolution_tree{}, err
+		}
+
+		err = r.addEvolutionChain(ctx, evoChain)
+		if err != nil {
+			return model.Evolution_tree{}, err
+		}
+
+		return r.database.GetEvolutionTree(ctx, chainID)
+	}
+
+	if err != nil {
+		return model.Evolution_tree{}, err
+	}
+
+	return tree, nil
+}
+
+func (r *repository) GetPokemonEvolutionTree(ctx context.Context, id int) (model.Evolution_tree, error) {
+	chainID, err := r.database.GetEvolutionChainID(ctx, id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("evolution chain of pokemon %d not found in the database!", id)
+
+		if err := r.ensurePokemon(ctx, id); err != nil {
+			return model.Evolution_tree{}, err
+		}
+
+		// Chain id is only known through the pokemon species
+		speciesID, err := r.database.GetPokemonSpeciesID(ctx, id)
+		if err != nil {
+			return model.Evolution_tree{}, err
+		}
+
+		evoChain, err := r.pokeAPIClient.GetEvolutionChain(ctx, speciesID)
+		if err != nil {
+			return model.Evolution_tree{}, err
+		}
+
+		err = r.addEvolutionChain(ctx, evoChain)
+		if err != nil {
+			return model.Evolution_tree{}, err
+		}
+
+		return r.GetEvolutionTree(ctx, evoChain.Id)
+	}
+
+	if err != nil {
+		return model.Evolution_tree{}, err
+	}
+
+	return r.GetEvolutionTree(ctx, chainID)
+}
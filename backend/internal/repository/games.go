@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+)
+
+// Games are the version groups with their versions and the pokedexes they use
+func (r *repository) GetGames(ctx context.Context) ([]model.Game, error) {
+	if err := r.ensureVersionGroups(ctx); err != nil {
+		return nil, err
+	}
+	if err := r.ensurePokedexes(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.database.GetGames(ctx)
+}
+
+// Accepts the id or the name of the generation, returns sql.ErrNoRows if it doesn't exist
+func (r *repository) GetGeneration(ctx context.Context, generation string) (model.Generation_details, error) {
+	// The version groups of the generation are stored with the games
+	if err := r.ensureVersionGroups(ctx); err != nil {
+		return model.Generation_details{}, err
+	}
+
+	return r.database.GetGeneration(ctx, generation)
+}
+
+func (r *repository) ensureGenerations(ctx context.Context) error {
+	fetched, err := r.database.HasGenerations(ctx)
+	if err != nil {
+		return err
+	}
+	if fetched {
+		return nil
+	}
+
+	log.Println("generations not found in the database, fetching from api...")
+
+	generations, err := r.pokeAPIClient.GetGenerations(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.database.AddGenerations(ctx, generations)
+}
+
+// Resolves a generation id or name to its name, an empty generation stays empty.
+// An unknown generation is an apierror.ValidationError
+func (r *repository) getGenerationName(ctx context.Context, generation string) (string, error) {
+	if err := r.ensureGenerations(ctx); err != nil {
+		return "", err
+	}
+	if generation == "" {
+		return "", nil
+	}
+
+	name, err := r.database.GetGenerationName(ctx, generation)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", apierror.Validationf("unknown generation %s", generation)
+	}
+	return name, err
+}
+
+func (r *repository) ensureVersionGroups(ctx context.Context) error {
+	fetched, err := r.database.HasVersionGroups(ctx)
+	if err != nil {
+		return err
+	}
+	if fetched {
+		return nil
+	}
+
+	// Version groups reference their generation
+	if err := r.ensureGenerations(ctx); err != nil {
+		return err
+	}
+
+	log.Println("version groups not found in the database, fetching from api...")
+
+	versionGroups, err := r.pokeAPIClient.GetVersionGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.database.AddVersionGroups(ctx, versionGroups)
+}
+
+// An unknown version is an apierror.ValidationError, an empty version is accepted
+func (r *repository) checkVersion(ctx context.Context, version string) error {
+	if version == "" {
+		return nil
+	}
+	if err := r.ensureVersionGroups(ctx); err != nil {
+		return err
+	}
+
+	exists, err := r.database.HasVersion(ctx, version)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apierror.Validationf("unknown version %s", version)
+	}
+	return nil
+}
+
+// An unknown version group is an apierror.ValidationError, an empty version group is accepted
+func (r *repository) checkVersionGroup(ctx context.Context, versionGroup string) error {
+	if versionGroup == "" {
+		return nil
+	}
+	if err := r.ensureVersionGroups(ctx); err != nil {
+		return err
+	}
+
+	exists, err := r.database.HasVersionGroup(ctx, versionGroup)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apierror.Validationf("unknown version group %s", versionGroup)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"poke-atlas/web-service/internal/model"
+)
+
+func (r *repository) GetItem(ctx context.Context, name string) (model.Item_details, error) {
+	item, err := r.database.GetItem(ctx, name)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("item %s not found in the database!", name)
+
+		fetchedItem, err := r.pokeAPIClient.GetItem(ctx, name)
+		if err != nil {
+			return model.Item_details{}, err
+		}
+
+		// Held items reference the pokemon, so missing pokemon have to be stored first
+		for _, holder := range fetchedItem.HeldByPokemon {
+			if err := r.ensurePokemon(ctx, holder.Pokemon.ID()); err != nil {
+				return model.Item_details{}, err
+			}
+		}
+
+		err = r.database.AddItem(ctx, fetchedItem)
+		if err != nil {
+			return model.Item_details{}, err
+		}
+
+		return r.database.GetItem(ctx, name)
+	}
+
+	if err != nil {
+		return model.Item_details{}, err
+	}
+
+	return item, nil
+}
@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/parallel"
+	"poke-atlas/web-service/internal/pokeapi"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// Species per page when syncing the names of every species
+const speciesNamesPageSize = 100
+
+// Slugs of a response to localize. Pokemon are given by id as their names come from their species
+type NameRefs struct {
+	PokemonIDs []int
+	Types      []string
+	Moves      []string
+	Abilities  []string
+	Stats      []string
+}
+
+// Display names of the slugs in the language, names missing from the database are fetched first.
+// The language must be a pokeapi language code
+func (r *repository) GetLocalizedNames(ctx context.Context, language string, refs NameRefs) (model.Localized_names, error) {
+	if err := r.ensureSpeciesNames(ctx, refs.PokemonIDs); err != nil {
+		return model.Localized_names{}, err
+	}
+	if err := r.ensureTypeNames(ctx, refs.Types); err != nil {
+		return model.Localized_names{}, err
+	}
+	if err := r.ensureStatNames(ctx, refs.Stats); err != nil {
+		return model.Localized_names{}, err
+	}
+	if err := r.ensureMoveNames(ctx, refs.Moves); err != nil {
+		return model.Localized_names{}, err
+	}
+	if err := r.ensureAbilityNames(ctx, refs.Abilities); err != nil {
+		return model.Localized_names{}, err
+	}
+
+	names := model.Localized_names{Language: language}
+	var err error
+
+	if names.Pokemon, err = r.database.GetLocalizedPokemonNames(ctx, refs.PokemonIDs, language); err != nil {
+		return model.Localized_names{}, err
+	}
+	for target, resource := range map[*map[string]string]struct {
+		name  string
+		slugs []string
+	}{
+		&names.Types:     {"type", refs.Types},
+		&names.Moves:     {"move", refs.Moves},
+		&names.Abilities: {"ability", refs.Abilities},
+		&names.Stats:     {"stat", refs.Stats},
+	} {
+		if *target, err = r.database.GetLocalizedNames(ctx, resource.name, resource.slugs, language); err != nil {
+			return model.Localized_names{}, err
+		}
+	}
+
+	return names, nil
+}
+
+// Finds the default pokemon of the species with the localized name, e.g. "Glurak" is charizard.
+// A name that isn't stored waits for the names of every species to be synced once, later misses are not found right away
+func (r *repository) findPokemonByLocalizedName(ctx context.Context, name string) (model.Pokemon_summary, error) {
+	species, err := r.database.FindLocalizedName(ctx, "pokemon-species", name)
+	if errors.Is(err, sql.ErrNoRows) {
+		if err := r.SyncAllSpeciesNames(ctx); err != nil {
+			return model.Pokemon_summary{}, err
+		}
+		species, err = r.database.FindLocalizedName(ctx, "pokemon-species", name)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Pokemon_summary{}, pokeapi.ErrNotFound
+	}
+	if err != nil {
+		return model.Pokemon_summary{}, err
+	}
+
+	// Species ids match the ids of their default pokemon
+	id, err := strconv.Atoi(species)
+	if err != nil {
+		return model.Pokemon_summary{}, err
+	}
+	if err := r.ensurePokemon(ctx, id); err != nil {
+		return model.Pokemon_summary{}, err
+	}
+
+	return r.database.GetPokemonByID(ctx, id)
+}
+
+// Fetches the names of the species ids offset+1 to offset+limit,
+// so pokemon can be looked up by their name in any language without reaching pokeapi
+func (r *repository) SyncSpeciesNames(ctx context.Context, offset int, limit int) (model.Species_name_sync, error) {
+	ids := make([]int, limit)
+	for i := range ids {
+		ids[i] = offset + i + 1
+	}
+
+	fetched, err := r.fetchSpeciesNames(ctx, ids)
+	if err != nil {
+		return model.Species_name_sync{}, err
+	}
+
+	return model.Species_name_sync{Species: limit, Cached: limit - fetched, Fetched: fetched}, nil
+}
+
+// Sync of the names of every species shared by everyone waiting for it
+type speciesNamesSync struct {
+	mu     sync.Mutex
+	synced bool
+	// Closed when the running sync ends, nil while none runs
+	done chan struct{}
+	err  error
+}
+
+// Stores the names of every species unless they already are. Concurrent calls share one sync,
+// which keeps running in the background when ctx is done, e.g. when the server starts it on startup
+func (r *repository) SyncAllSpeciesNames(ctx context.Context) error {
+	r.speciesNames.mu.Lock()
+	if r.speciesNames.synced {
+		r.speciesNames.mu.Unlock()
+		return nil
+	}
+	if r.speciesNames.done == nil {
+		r.speciesNames.done = make(chan struct{})
+		go r.syncAllSpeciesNames(context.WithoutCancel(ctx), r.speciesNames.done)
+	}
+	done := r.speciesNames.done
+	r.speciesNames.mu.Unlock()
+
+	select {
+	case <-done:
+		r.speciesNames.mu.Lock()
+		defer r.speciesNames.mu.Unlock()
+		return r.speciesNames.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *repository) syncAllSpeciesNames(ctx context.Context, done chan struct{}) {
+	err := r.fetchAllSpeciesNames(ctx)
+	if err != nil {
+		log.Println("Failed to sync species names:", err)
+	}
+
+	r.speciesNames.mu.Lock()
+	r.speciesNames.synced = err == nil
+	r.speciesNames.err = err
+	r.speciesNames.done = nil
+	r.speciesNames.mu.Unlock()
+	close(done)
+}
+
+// Pages through the species until the national dex ends, species ids have no gaps
+func (r *repository) fetchAllSpeciesNames(ctx context.Context) error {
+	synced, err := r.database.HasSpeciesNamesSynced(ctx)
+	if err != nil || synced {
+		return err
+	}
+
+	for offset := 0; ; offset += speciesNamesPageSize {
+		_, err := r.SyncSpeciesNames(ctx, offset, speciesNamesPageSize)
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.database.MarkSpeciesNamesSynced(ctx)
+}
+
+// Fetches the names of the species of the pokemon missing from the database
+func (r *repository) ensureSpeciesNames(ctx context.Context, pokemonIDs []int) error {
+	var speciesIDs []int
+	for _, id := range pokemonIDs {
+		speciesID, err := r.database.GetPokemonSpeciesID(ctx, id)
+		if err != nil {
+			return err
+		}
+		// Varieties of the same species share their names
+		if !slices.Contains(speciesIDs, speciesID) {
+			speciesIDs = append(speciesIDs, speciesID)
+		}
+	}
+
+	_, err := r.fetchSpeciesNames(ctx, speciesIDs)
+	return err
+}
+
+// Fetches the names of the species missing from the database and returns how many were fetched.
+// Requests run concurrently, the results are stored in one go as sqlite allows a single writer.
+// Names that were fetched are stored even if other requests fail
+func (r *repository) fetchSpeciesNames(ctx context.Context, speciesIDs []int) (int, error) {
+	var missing []int
+	for _, id := range speciesIDs {
+		fetched, err := r.database.HasLocalizedNames(ctx, "pokemon-species", strconv.Itoa(id))
+		if err != nil {
+			return 0, err
+		}
+		if !fetched {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return 0, nil
+	}
+
+	log.Printf("fetching names of %d species from api...", len(missing))
+
+	species, errs := parallel.Map(missing, maxConcurrentFetches, func(id int) (model.PokemonSpecies, error) {
+		return r.pokeAPIClient.GetPokemonSpecies(ctx, id)
+	})
+
+	var fetched []model.PokemonSpecies
+	for i := range species {
+		if errs[i] == nil {
+			fetched = append(fetched, species[i])
+		}
+	}
+	if err := r.database.AddSpeciesNames(ctx, fetched); err != nil {
+		return 0, err
+	}
+
+	return len(fetched), parallel.FirstError(errs)
+}
+
+// Fetches every type if the names of any of them are missing
+func (r *repository) ensureTypeNames(ctx context.Context, types []string) error {
+	missing, err := r.missingNames(ctx, "type", types)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	log.Println("type names not found in the database, fetching from api...")
+
+	fetchedTypes, err := r.pokeAPIClient.GetTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.database.AddTypes(ctx, fetchedTypes)
+}
+
+// Fetches every stat if the names of any of them are missing
+func (r *repository) ensureStatNames(ctx context.Context, stats []string) error {
+	missing, err := r.missingNames(ctx, "stat", stats)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	log.Println("stat names not found in the database, fetching from api...")
+
+	fetchedStats, err := r.pokeAPIClient.GetStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.database.AddStats(ctx, fetchedStats)
+}
+
+// Fetches the moves whose names are missing, concurrently like GetMoves
+func (r *repository) ensureMoveNames(ctx context.Context, moves []string) error {
+	missing, err := r.missingNames(ctx, "move", moves)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	_, err = r.GetMoves(ctx, missing)
+	return err
+}
+
+// Fetches the abilities whose names are missing concurrently, they are stored one at a time as sqlite allows a single writer
+func (r *repository) ensureAbilityNames(ctx context.Context, abilities []string) error {
+	missing, err := r.missingNames(ctx, "ability", abilities)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+
+	log.Printf("fetching names of %d abilities from api...", len(missing))
+
+	fetched, errs := parallel.Map(missing, maxConcurrentFetches, func(name string) (model.Ability, error) {
+		return r.pokeAPIClient.GetAbility(ctx, name)
+	})
+	if err := parallel.FirstError(errs); err != nil {
+		return err
+	}
+
+	for _, ability := range fetched {
+		if err := r.database.AddAbility(ctx, ability); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Names of the resources whose localized names haven't been fetched yet
+func (r *repository) missingNames(ctx context.Context, resource string, names []string) ([]string, error) {
+	var missing []string
+	for _, name := range names {
+		// Team members may leave out their ability
+		if name == "" {
+			continue
+		}
+		fetched, err := r.database.HasLocalizedNames(ctx, resource, name)
+		if err != nil {
+			return nil, err
+		}
+		// Teams share moves and abilities
+		if !fetched && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"poke-atlas/web-service/internal/model"
+)
+
+func (r *repository) SearchLocations(ctx context.Context, search string, limit int) ([]model.Location_summary, error) {
+	locations, err := r.database.SearchLocations(ctx, search, limit)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Println("location list not found in the database, fetching from api...")
+
+		fetchedLocations, err := r.pokeAPIClient.GetLocations(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.database.AddLocations(ctx, fetchedLocations)
+		if err != nil {
+			return nil, err
+		}
+
+		return r.database.SearchLocations(ctx, search, limit)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+// Returns the location with every pokemon obtainable in each of its areas, an unknown version is an apierror.ValidationError
+func (r *repository) GetLocation(ctx context.Context, name string, version string) (model.Location_details, error) {
+	if err := r.checkVersion(ctx, version); err != nil {
+		return model.Location_details{}, err
+	}
+
+	location, err := r.database.GetLocation(ctx, name)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("location %s not found in the database!", name)
+
+		var fetchedLocation model.Location
+		fetchedLocation, err = r.pokeAPIClient.GetLocation(ctx, name)
+		if err != nil {
+			return model.Location_details{}, err
+		}
+
+		err = r.database.AddLocation(ctx, fetchedLocation)
+		if err != nil {
+			return model.Location_details{}, err
+		}
+
+		location, err = r.database.GetLocation(ctx, name)
+	}
+
+	if err != nil {
+		return model.Location_details{}, err
+	}
+
+	for i, area := range location.Areas {
+		location.Areas[i], err = r.GetLocationArea(ctx, area.Name, version)
+		if err != nil {
+			return model.Location_details{}, err
+		}
+	}
+
+	return location, nil
+}
+
+// An unknown version is an apierror.ValidationError
+func (r *repository) GetLocationArea(ctx context.Context, name string, version string) (model.Location_area, error) {
+	if err := r.checkVersion(ctx, version); err != nil {
+		return model.Location_area{}, err
+	}
+
+	area, err := r.database.GetLocationArea(ctx, name, version)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("location area %s not found in the database!", name)
+
+		fetchedArea, err := r.pokeAPIClient.GetLocationArea(ctx, name)
+		if err != nil {
+			return model.Location_area{}, err
+		}
+
+		// Encounters reference the pokemon, so missing pokemon have to be stored first
+		for _, encounter := range fetchedArea.PokemonEncounters {
+			if err := r.ensurePokemon(ctx, encounter.Pokemon.ID()); err != nil {
+				return model.Location_area{}, err
+			}
+		}
+
+		err = r.database.AddLocationArea(ctx, fetchedArea)
+		if err != nil {
+			return model.Location_area{}, err
+		}
+
+		return r.database.GetLocationArea(ctx, name, version)
+	}
+
+	if err != nil {
+		return model.Location_area{}, err
+	}
+
+	return area, nil
+}
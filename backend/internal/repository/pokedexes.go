@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+)
+
+func (r *repository) ensurePokedexes(ctx context.Context) error {
+	fetched, err := r.database.HasPokedexes(ctx)
+	if err != nil {
+		return err
+	}
+	if fetched {
+		return nil
+	}
+
+	log.Println("pokedexes not found in the database, fetching from api...")
+
+	pokedexes, err := r.pokeAPIClient.GetPokedexes(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.database.AddPokedexes(ctx, pokedexes)
+}
+
+// An empty version group returns every pokedex, an unknown one is an apierror.ValidationError
+func (r *repository) GetPokedexes(ctx context.Context, versionGroup string) ([]model.Pokedex_summary, error) {
+	if err := r.checkVersionGroup(ctx, versionGroup); err != nil {
+		return nil, err
+	}
+	if err := r.ensurePokedexes(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.database.GetPokedexes(ctx, versionGroup)
+}
+
+// Returns sql.ErrNoRows if the pokedex doesn't exist. The pokemon of the page are fetched if they are missing
+func (r *repository) GetPokedexEntries(ctx context.Context, name string, offset int, limit int) (model.Pokedex_entries, error) {
+	if err := r.ensurePokedexes(ctx); err != nil {
+		return model.Pokedex_entries{}, err
+	}
+
+	page, err := r.database.GetPokedexEntries(ctx, name, offset, limit)
+	if err != nil {
+		return model.Pokedex_entries{}, err
+	}
+
+	ids := make([]int, len(page.Entries))
+	for i, entry := range page.Entries {
+		ids[i] = entry.PokemonID
+	}
+	if err := r.ensurePokemons(ctx, ids); err != nil {
+		return model.Pokedex_entries{}, err
+	}
+
+	return r.database.GetPokedexEntries(ctx, name, offset, limit)
+}
+
+// An unknown pokedex is an apierror.ValidationError, an empty name is accepted
+func (r *repository) checkPokedex(ctx context.Context, name string) error {
+	if name == "" {
+		return nil
+	}
+	if err := r.ensurePokedexes(ctx); err != nil {
+		return err
+	}
+
+	exists, err := r.database.HasPokedex(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apierror.Validationf("unknown pokedex %s", name)
+	}
+	return nil
+}
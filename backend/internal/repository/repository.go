@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"log"
+	"poke-atlas/web-service/internal/mediacache"
 	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/parallel"
 	"poke-atlas/web-service/internal/pokeapi"
+	"poke-atlas/web-service/internal/spriteimage"
 	"poke-atlas/web-service/internal/store"
 	"strconv"
 )
@@ -14,18 +17,72 @@ import (
 type Repository interface {
 	GetPokemon(ctx context.Context, name string) (model.Pokemon_summary, error)
 	GetPokemons(ctx context.Context, offset int, limit int) ([]model.Pokemon_summary, error)
+	GetPokemonBatch(ctx context.Context, req model.Pokemon_batch_request) (model.Pokemon_batch, error)
+	GetPokemonMoveNames(ctx context.Context, ids []int) (map[int][]string, error)
+	GetPokemonAbilityNames(ctx context.Context, ids []int) (map[int][]string, error)
+	GetPokemonSpeciesIDs(ctx context.Context, ids []int) (map[int]int, error)
 	GetPokemonDetailed(ctx context.Context, id int) (model.Pokemon_details, error)
+	ComparePokemon(ctx context.Context, ids []int) (model.Comparison, error)
+	GetPokemonEncounters(ctx context.Context, id int, version string) (model.Pokemon_encounters, error)
+	GetEvolutionTree(ctx context.Context, chainID int) (model.Evolution_tree, error)
+	GetPokemonEvolutionTree(ctx context.Context, id int) (model.Evolution_tree, error)
+	GetSpeciesVarieties(ctx context.Context, speciesID int) (model.Species_varieties, error)
+	SearchLocations(ctx context.Context, search string, limit int) ([]model.Location_summary, error)
+	GetLocation(ctx context.Context, name string, version string) (model.Location_details, error)
+	GetLocationArea(ctx context.Context, name string, version string) (model.Location_area, error)
+	GetItem(ctx context.Context, name string) (model.Item_details, error)
+	GetNatures(ctx context.Context) ([]model.Nature_details, error)
+	GetCharacteristics(ctx context.Context) ([]model.Characteristic_details, error)
+	CalculateStats(ctx context.Context, id int, req model.Stat_calculation_request) (model.Calculated_stats, error)
+	EstimateIVs(ctx context.Context, id int, req model.Iv_range_request) (model.Iv_ranges, error)
+	GetMove(ctx context.Context, name string) (model.Move_details, error)
+	GetMoves(ctx context.Context, names []string) (map[string]model.Move_details, error)
+	GetTypeChart(ctx context.Context) (model.Type_chart, error)
+	CalculateDamage(ctx context.Context, req model.Damage_request) (model.Damage_result, error)
+	GetTeams(ctx context.Context) ([]model.Team, error)
+	GetTeam(ctx context.Context, id int) (model.Team, error)
+	CreateTeam(ctx context.Context, team model.Team) (model.Team, error)
+	UpdateTeam(ctx context.Context, id int, team model.Team) (model.Team, error)
+	DeleteTeam(ctx context.Context, id int) error
+	GetTeamAnalysis(ctx context.Context, id int) (model.Team_analysis, error)
+	ImportTeam(ctx context.Context, req model.Team_import_request) (model.Team, error)
+	ExportTeam(ctx context.Context, id int) (string, error)
+	GetSaves(ctx context.Context) ([]model.Save, error)
+	GetSave(ctx context.Context, id int) (model.Save, error)
+	CreateSave(ctx context.Context, save model.Save) (model.Save, error)
+	DeleteSave(ctx context.Context, id int) error
+	GetSavePokemon(ctx context.Context, id int) ([]model.Save_entry, error)
+	SetSavePokemon(ctx context.Context, id int, pokemonID int, status model.Save_pokemon_status) (model.Save_entry, error)
+	GetSaveCompletion(ctx context.Context, id int, generation string, pokedex string) (model.Save_completion, error)
+	GetObtainablePokemon(ctx context.Context, id int, generation string) ([]model.Obtainable_pokemon, error)
+	GetPokedexes(ctx context.Context, versionGroup string) ([]model.Pokedex_summary, error)
+	GetPokedexEntries(ctx context.Context, name string, offset int, limit int) (model.Pokedex_entries, error)
+	GetGames(ctx context.Context) ([]model.Game, error)
+	GetGeneration(ctx context.Context, generation string) (model.Generation_details, error)
+	GetPokemonFlavorText(ctx context.Context, id int, version string, language string) (model.Pokemon_flavor_text, error)
+	GetLocalizedNames(ctx context.Context, language string, refs NameRefs) (model.Localized_names, error)
+	SyncSpeciesNames(ctx context.Context, offset int, limit int) (model.Species_name_sync, error)
+	SyncAllSpeciesNames(ctx context.Context) error
+	GetSprite(ctx context.Context, id int, form string, variant string, options spriteimage.Options) (model.Cached_media, error)
+	GetCry(ctx context.Context, id int, variant string) (model.Cached_media, error)
+	SyncSprites(ctx context.Context, offset int, limit int, variants []string) (model.Sprite_sync, error)
 }
 
+// Limits the concurrent pokeapi requests when fetching many resources at once
+const maxConcurrentFetches = 5
+
 type repository struct {
 	pokeAPIClient pokeapi.PokeAPIClient
 	database      store.Database
+	mediaCache    *mediacache.Cache
+	speciesNames  speciesNamesSync
 }
 
-func NewRepository(pokeAPIClient pokeapi.PokeAPIClient, db store.Database) Repository {
+func NewRepository(pokeAPIClient pokeapi.PokeAPIClient, db store.Database, mediaCache *mediacache.Cache) Repository {
 	newRepository := &repository{
 		pokeAPIClient: pokeAPIClient,
 		database:      db,
+		mediaCache:    mediaCache,
 	}
 
 	return newRepository
@@ -42,8 +99,16 @@ func (r *repository) GetPokemon(ctx context.Context, name string) (model.Pokemon
 
 	log.Print(pokemon)
 
+	// Names that can't be pokemon slugs, e.g. "Glurak", are looked up by their localized species name
+	if !pokeapi.SlugPattern.MatchString(name) {
+		return r.findPokemonByLocalizedName(ctx, name)
+	}
+
 	log.Println("Fetching from api...")
 	response, err := r.pokeAPIClient.GetPokemon(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return r.findPokemonByLocalizedName(ctx, name)
+	}
 	if err != nil {
 		log.Println("Failed to fetch pokemon from api", err.Error())
 		return model.Pokemon_summary{}, err
@@ -57,15 +122,8 @@ func (r *repository) GetPokemon(ctx context.Context, name string) (model.Pokemon
 		return model.Pokemon_summary{}, err
 	}
 
-	pokemon = model.Pokemon_summary{
-		ID:        response.ID,
-		Name:      response.Name,
-		Weight:    response.Weight,
-		Height:    response.Height,
-		SpriteUrl: response.Sprites.FrontDefault,
-	}
-
-	return pokemon, nil
+	// Read back so the summary has the types and the sprite path of the stored pokemon
+	return r.database.GetPokemon(ctx, response.Name)
 }
 
 func (r *repository) GetPokemons(ctx context.Context, offset int, limit int) ([]model.Pokemon_summary, error) {
@@ -121,98 +179,244 @@ func (r *repository) GetPokemons(ctx context.Context, offset int, limit int) ([]
 }
 
 func (r *repository) GetPokemonDetailed(ctx context.Context, id int) (model.Pokemon_details, error) {
+	// Stores the pokemon if it's missing, and the held items and game indices of pokemon stored before they were kept
+	if err := r.ensurePokemonDetails(ctx, id); err != nil {
+		return model.Pokemon_details{}, err
+	}
+
 	// Database
 	pokemon, err := r.database.GetPokemonDetailed(ctx, id)
+	if err != nil {
+		return model.Pokemon_details{}, err
+	}
 
-	//log.Printf("GetPokemonDetailed err: %v, type: %T", err, err)
-	//log.Printf("Is sql.ErrNoRows? %v", errors.Is(err, sql.ErrNoRows))
+	// If cries can't be fetched, return pokemon without them rather than failing the entire request
+	pokemonCries, err := r.getPokemonCries(ctx, id)
+	if err != nil {
+		log.Printf("Failed to fetch cries for pokemon %d: %v", id, err)
+	}
+	pokemon.Cries = pokemonCries
 
-	if errors.Is(err, sql.ErrNoRows) {
-		log.Print("pokemon not found in the database!")
+	// Evolution chains and forms belong to the species, which differs from the pokemon for forms like raichu-alola
+	speciesID, err := r.database.GetPokemonSpeciesID(ctx, id)
+	if err != nil {
+		speciesID = id
+	}
+
+	// Check if we need to fetch evolution chain
+	// We need it if the chain of the species was never stored, or stored without its evolution details
+	hasEvolutionDetails, err := r.database.HasEvolutionDetails(ctx, speciesID)
+	if err != nil {
+		return model.Pokemon_details{}, err
+	}
+
+	if !hasEvolutionDetails {
+		log.Println("fetching evolution chain from pokeapi...")
+		evoChain, err := r.pokeAPIClient.GetEvolutionChain(ctx, speciesID)
 
-		// We can convert to string because pokeAPI supports querying both name and id
-		fetchedPokemon, err := r.pokeAPIClient.GetPokemon(ctx, strconv.Itoa(id))
 		if err != nil {
-			return model.Pokemon_details{}, err
+			// If evolution chain fetch fails, return pokemon without it
+			// rather than failing the entire request
+			log.Printf("Failed to fetch evolution chain for pokemon %d: %v", id, err)
+			return pokemon, nil
 		}
 
-		err = r.database.AddPokemon(ctx, fetchedPokemon)
+		// Add evolution chain data to db
+		err = r.addEvolutionChain(ctx, evoChain)
 		if err != nil {
-			return model.Pokemon_details{}, err
+			log.Printf("Returning pokemon %d without its evolution chain", id)
 		}
 
-		// Fetch the detailed view from database after adding
+		// Fetch again to get the evolution data
 		pokemon, err = r.database.GetPokemonDetailed(ctx, id)
+
+		if err != nil {
+			return model.Pokemon_details{}, err
+		}
+		pokemon.Cries = pokemonCries
 	}
 
-	// Check if we need to fetch evolution chain
-	// We need it if we don't have ANY evolution data for this pokemon
-	needsEvolutionChain := len(pokemon.EvolutionChain) == 0
+	// If forms can't be fetched, return pokemon without them rather than failing the entire request
+	species, err := r.GetSpeciesVarieties(ctx, speciesID)
+	if err != nil {
+		log.Printf("Failed to fetch forms for pokemon %d: %v", id, err)
+		return pokemon, nil
+	}
 
-	if needsEvolutionChain {
-		log.Println("fetching evolution chain from pokeapi...")
-		evoChain, err := r.pokeAPIClient.GetEvolutionChain(ctx, id)
+	pokemon.Forms = []model.Pokemon_form{}
+	for _, variety := range species.Varieties {
+		pokemon.Forms = append(pokemon.Forms, variety.Forms...)
+	}
 
+	return pokemon, nil
+}
+
+// An unknown version is an apierror.ValidationError
+func (r *repository) GetPokemonEncounters(ctx context.Context, id int, version string) (model.Pokemon_encounters, error) {
+	if err := r.checkVersion(ctx, version); err != nil {
+		return model.Pokemon_encounters{}, err
+	}
+
+	encounters, err := r.database.GetPokemonEncounters(ctx, id, version)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("encounters of pokemon %d not found in the database!", id)
+
+		// Encounters reference the pokemon, so it has to be stored first
+		if err := r.ensurePokemon(ctx, id); err != nil {
+			return model.Pokemon_encounters{}, err
+		}
+
+		fetchedEncounters, err := r.pokeAPIClient.GetPokemonEncounters(ctx, id)
 		if err != nil {
-			// If evolution chain fetch fails, return pokemon without it
-			// rather than failing the entire request
-			log.Printf("Failed to fetch evolution chain for pokemon %d: %v", id, err)
-			return pokemon, nil
+			return model.Pokemon_encounters{}, err
 		}
 
-		// Add evolution chain data to db
-		err = r.database.AddEvolutionChain(ctx, evoChain)
+		err = r.database.AddPokemonEncounters(ctx, id, fetchedEncounters)
+		if err != nil {
+			return model.Pokemon_encounters{}, err
+		}
 
+		return r.database.GetPokemonEncounters(ctx, id, version)
+	}
+
+	if err != nil {
+		return model.Pokemon_encounters{}, err
+	}
+
+	return encounters, nil
+}
+
+// Fetches the pokemon from pokeapi if it is not in the database yet
+func (r *repository) ensurePokemon(ctx context.Context, id int) error {
+	exists, err := r.database.HasPokemon(ctx, id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	fetchedPokemon, err := r.pokeAPIClient.GetPokemon(ctx, strconv.Itoa(id))
+	if err != nil {
+		return err
+	}
+
+	return r.database.AddPokemon(ctx, fetchedPokemon)
+}
+
+// Fetches the pokemon if it's missing from the database, and the held items and game indices of pokemon stored before they were kept
+func (r *repository) ensurePokemonDetails(ctx context.Context, id int) error {
+	if err := r.ensurePokemon(ctx, id); err != nil {
+		return err
+	}
+
+	hasHeldItems, err := r.database.HasPokemonHeldItems(ctx, id)
+	if err != nil {
+		return err
+	}
+	hasGameIndices, err := r.database.HasPokemonGameIndices(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasHeldItems && hasGameIndices {
+		return nil
+	}
+
+	log.Printf("details of pokemon %d not found in the database, fetching from api...", id)
+
+	pokemon, err := r.pokeAPIClient.GetPokemon(ctx, strconv.Itoa(id))
+	if err != nil {
+		return err
+	}
+
+	if !hasHeldItems {
+		if err := r.database.AddPokemonHeldItems(ctx, id, pokemon.HeldItems); err != nil {
+			return err
+		}
+	}
+	if !hasGameIndices {
+		if err := r.database.AddPokemonGameIndices(ctx, id, pokemon.GameIndices); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fetches the pokemon missing from the database concurrently, they are stored one at a time as sqlite allows a single writer
+func (r *repository) ensurePokemons(ctx context.Context, ids []int) error {
+	var missing []int
+	for _, id := range ids {
+		exists, err := r.database.HasPokemon(ctx, id)
 		if err != nil {
-			// Fail here most likely indicates that not all pokemon data exists in the database --> FOREIGN KEY contraint failed
+			return err
+		}
+		if !exists {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
 
-			log.Printf("Failed to add evolution chain to db: %v", err)
+	pokemons, errs := parallel.Map(missing, maxConcurrentFetches, func(id int) (model.Pokemon, error) {
+		return r.pokeAPIClient.GetPokemon(ctx, strconv.Itoa(id))
+	})
 
-			// In case of error try fetching possible missing pokemons
-			log.Printf("Attempting to fetch missing pokemons...")
-
-			missing := extractNamessFromEvolutionChain(evoChain)
-
-			for _, name := range missing {
-				// Fetch missing pokemons
-				fetchedPokemon, err := r.pokeAPIClient.GetPokemon(ctx, name)
-				if err != nil {
-					log.Printf("Failed to fetch missing pokemons: %v", err)
-					break
-				}
-				// Add missing pokemons to db
-				err = r.database.AddPokemon(ctx, fetchedPokemon)
-				log.Println("adding pokemon: ", name)
-				if err != nil {
-					log.Printf("Failed to add pokemon to database: %v", err)
-				}
-			}
+	for i := range missing {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		if err := r.database.AddPokemon(ctx, pokemons[i]); err != nil {
+			return err
+		}
+	}
 
-			// Retry adding evolution chain to db
-			log.Print("Retrying adding evolution chain to db...")
-			err = r.database.AddEvolutionChain(ctx, evoChain)
-			if err != nil {
-				log.Printf("Failed to add evolution chain to db: %v", err)
+	return nil
+}
+
+// Adds the evolution chain to db, fetching the pokemon of the chain that are missing from the database
+func (r *repository) addEvolutionChain(ctx context.Context, evoChain model.Evolution_chain) error {
+	err := r.database.AddEvolutionChain(ctx, evoChain)
+
+	if err != nil {
+		// Fail here most likely indicates that not all pokemon data exists in the database --> FOREIGN KEY contraint failed
+
+		log.Printf("Failed to add evolution chain to db: %v", err)
+
+		// In case of error try fetching possible missing pokemons
+		log.Printf("Attempting to fetch missing pokemons...")
+
+		// Species IDs match the IDs of their default pokemon, species names don't always match pokemon names (deoxys)
+		missing := extractSpeciesIDsFromEvolutionChain(evoChain)
+
+		for _, id := range missing {
+			// Fetch and add missing pokemons to db
+			log.Println("adding pokemon: ", id)
+			if err := r.ensurePokemon(ctx, id); err != nil {
+				log.Printf("Failed to add missing pokemon: %v", err)
+				break
 			}
 		}
 
-		// Fetch again to get the evolution data
-		pokemon, err = r.database.GetPokemonDetailed(ctx, id)
-
+		// Retry adding evolution chain to db
+		log.Print("Retrying adding evolution chain to db...")
+		err = r.database.AddEvolutionChain(ctx, evoChain)
 		if err != nil {
-			return model.Pokemon_details{}, err
+			log.Printf("Failed to add evolution chain to db: %v", err)
+			return err
 		}
 	}
 
-	return pokemon, nil
+	return nil
 }
 
-func extractNamessFromEvolutionChain(chain model.Evolution_chain) []string {
-	ids := make(map[string]bool)
+func extractSpeciesIDsFromEvolutionChain(chain model.Evolution_chain) []int {
+	ids := make(map[int]bool)
 
 	var traverse func(link model.ChainLink)
 	traverse = func(link model.ChainLink) {
-		ids[link.Species.Name] = true
+		ids[link.Species.ID()] = true
 		for _, evo := range link.EvolvesTo {
 			traverse(evo)
 		}
@@ -220,7 +424,7 @@ func extractNamessFromEvolutionChain(chain model.Evolution_chain) []string {
 
 	traverse(chain.Chain)
 
-	result := make([]string, 0, len(ids))
+	result := make([]int, 0, len(ids))
 	for id := range ids {
 		result = append(result, id)
 	}
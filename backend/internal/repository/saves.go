@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/parallel"
+	"poke-atlas/web-service/internal/saves"
+)
+
+func (r *repository) GetSaves(ctx context.Context) ([]model.Save, error) {
+	return r.database.GetSaves(ctx)
+}
+
+// Returns sql.ErrNoRows if the save doesn't exist
+func (r *repository) GetSave(ctx context.Context, id int) (model.Save, error) {
+	return r.database.GetSave(ctx, id)
+}
+
+// The save must be validated with saves.ValidateSave, an unknown version is an apierror.ValidationError
+func (r *repository) CreateSave(ctx context.Context, save model.Save) (model.Save, error) {
+	if err := r.checkVersion(ctx, save.Version); err != nil {
+		return model.Save{}, err
+	}
+
+	id, err := r.database.CreateSave(ctx, save)
+	if err != nil {
+		return model.Save{}, err
+	}
+
+	return r.database.GetSave(ctx, id)
+}
+
+// Returns sql.ErrNoRows if the save doesn't exist
+func (r *repository) DeleteSave(ctx context.Context, id int) error {
+	return r.database.DeleteSave(ctx, id)
+}
+
+// Returns sql.ErrNoRows if the save doesn't exist
+func (r *repository) GetSavePokemon(ctx context.Context, id int) ([]model.Save_entry, error) {
+	if _, err := r.database.GetSave(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return r.database.GetSaveEntries(ctx, id)
+}
+
+// Marks the species of the pokemon in the save, returns sql.ErrNoRows if the save doesn't exist
+func (r *repository) SetSavePokemon(ctx context.Context, id int, pokemonID int, status model.Save_pokemon_status) (model.Save_entry, error) {
+	if _, err := r.database.GetSave(ctx, id); err != nil {
+		return model.Save_entry{}, err
+	}
+
+	if err := r.ensurePokemon(ctx, pokemonID); err != nil {
+		return model.Save_entry{}, err
+	}
+	speciesID, err := r.database.GetPokemonSpeciesID(ctx, pokemonID)
+	if err != nil {
+		return model.Save_entry{}, err
+	}
+	// Entries show the sprite of the default pokemon of the species
+	if err := r.ensurePokemon(ctx, speciesID); err != nil {
+		return model.Save_entry{}, err
+	}
+
+	if err := r.database.SetSavePokemon(ctx, id, speciesID, saves.NormalizeStatus(status)); err != nil {
+		return model.Save_entry{}, err
+	}
+
+	return r.database.GetSaveEntry(ctx, id, speciesID)
+}
+
+// Completion of every generation, or only of the given one. A pokedex only counts the species of its entries.
+// Returns sql.ErrNoRows if the save doesn't exist and apierror.ValidationError if the generation or pokedex doesn't
+func (r *repository) GetSaveCompletion(ctx context.Context, id int, generation string, pokedex string) (model.Save_completion, error) {
+	if _, err := r.database.GetSave(ctx, id); err != nil {
+		return model.Save_completion{}, err
+	}
+
+	generation, err := r.getGenerationName(ctx, generation)
+	if err != nil {
+		return model.Save_completion{}, err
+	}
+
+	if err := r.checkPokedex(ctx, pokedex); err != nil {
+		return model.Save_completion{}, err
+	}
+
+	generations, err := r.database.GetSaveCompletion(ctx, id, generation, pokedex)
+	if err != nil {
+		return model.Save_completion{}, err
+	}
+
+	return model.Save_completion{
+		SaveID:      id,
+		Pokedex:     pokedex,
+		Total:       saves.Summarize(generations),
+		Generations: generations,
+	}, nil
+}
+
+// Uncaught pokemon with wild encounters in the version of the save.
+// With a generation the encounters of all its species are fetched first, otherwise only the stored encounters are used.
+// Returns sql.ErrNoRows if the save doesn't exist and apierror.ValidationError if the generation doesn't
+func (r *repository) GetObtainablePokemon(ctx context.Context, id int, generation string) ([]model.Obtainable_pokemon, error) {
+	save, err := r.database.GetSave(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	generation, err = r.getGenerationName(ctx, generation)
+	if err != nil {
+		return nil, err
+	}
+
+	if generation != "" {
+		speciesIDs, err := r.database.GetGenerationSpeciesIDs(ctx, generation)
+		if err != nil {
+			return nil, err
+		}
+		// Species ids match the ids of their default pokemon
+		if err := r.ensureEncounters(ctx, speciesIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.database.GetObtainablePokemon(ctx, id, save.Version, generation)
+}
+
+// Fetches the pokemon and encounters that are missing from the database.
+// Requests run concurrently, the results are stored one at a time as sqlite allows a single writer
+func (r *repository) ensureEncounters(ctx context.Context, pokemonIDs []int) error {
+	var missing []int
+	for _, id := range pokemonIDs {
+		_, err := r.database.GetPokemonEncounters(ctx, id, "")
+		if errors.Is(err, sql.ErrNoRows) {
+			missing = append(missing, id)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := r.ensurePokemons(ctx, missing); err != nil {
+		return err
+	}
+
+	log.Printf("fetching encounters of %d pokemon from api...", len(missing))
+
+	encounters, errs := parallel.Map(missing, maxConcurrentFetches, func(id int) ([]model.LocationAreaEncounter, error) {
+		return r.pokeAPIClient.GetPokemonEncounters(ctx, id)
+	})
+
+	for i, id := range missing {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		if err := r.database.AddPokemonEncounters(ctx, id, encounters[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
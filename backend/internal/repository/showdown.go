@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/pokeapi"
+	"poke-atlas/web-service/internal/showdown"
+	"poke-atlas/web-service/internal/stats"
+	"poke-atlas/web-service/internal/teams"
+	"strings"
+)
+
+// Creates a team from a Showdown paste. Names are matched against the stored pokemon, items, natures, learnsets and abilities
+// allowing for typos, problems are returned as a showdown.ParseError listing every invalid line
+func (r *repository) ImportTeam(ctx context.Context, req model.Team_import_request) (model.Team, error) {
+	paste, err := showdown.Parse(req.Text)
+	if err != nil {
+		return model.Team{}, err
+	}
+
+	team := model.Team{Name: req.Name, Members: []model.Team_member{}}
+	if team.Name == "" {
+		team.Name = paste.Name
+	}
+
+	storedPokemon, err := r.database.GetPokemonNames(ctx)
+	if err != nil {
+		return model.Team{}, err
+	}
+	storedItems, err := r.database.GetItemNames(ctx)
+	if err != nil {
+		return model.Team{}, err
+	}
+	natures, err := r.GetNatures(ctx)
+	if err != nil {
+		return model.Team{}, err
+	}
+	var natureNames []string
+	for _, nature := range natures {
+		natureNames = append(natureNames, nature.Name)
+	}
+
+	var parseErr showdown.ParseError
+	addError := func(line int, message string) {
+		parseErr.Errors = append(parseErr.Errors, model.Line_error{Line: line, Message: message})
+	}
+
+	for i, set := range paste.Sets {
+		if i == teams.MaxMembers {
+			addError(set.Line, fmt.Sprintf("a team has at most %d members", teams.MaxMembers))
+			break
+		}
+
+		member, ok, err := r.resolveSet(ctx, set, names{pokemon: storedPokemon, items: storedItems, natures: natureNames}, addError)
+		if err != nil {
+			return model.Team{}, err
+		}
+		if ok {
+			team.Members = append(team.Members, member)
+		}
+	}
+
+	if len(parseErr.Errors) > 0 {
+		return model.Team{}, parseErr
+	}
+
+	if err := teams.ValidateTeam(&team); err != nil {
+		return model.Team{}, err
+	}
+
+	return r.CreateTeam(ctx, team)
+}
+
+// Names the sets of a paste are matched against
+type names struct {
+	pokemon []string
+	items   []string
+	natures []string
+}
+
+// Resolves the names of one set, returns false if any line of it is invalid
+func (r *repository) resolveSet(ctx context.Context, set showdown.Set, candidates names, addError func(int, string)) (model.Team_member, bool, error) {
+	pokemon, ok, err := r.resolvePokemon(ctx, set, candidates.pokemon, addError)
+	if err != nil || !ok {
+		return model.Team_member{}, false, err
+	}
+
+	// Showdown leaves out the level line for level 100
+	member := model.Team_member{
+		PokemonID: pokemon.ID,
+		Level:     set.Level,
+		IVs:       set.IVs,
+		EVs:       set.EVs,
+		Moves:     []string{},
+	}
+	if member.Level == 0 {
+		member.Level = 100
+	}
+	valid := true
+
+	if set.Item != "" {
+		item, ok, err := r.resolveItem(ctx, set, candidates.items, addError)
+		if err != nil {
+			return model.Team_member{}, false, err
+		}
+		valid = valid && ok
+		member.Item = item
+	}
+
+	if set.Ability != "" {
+		abilities, err := r.database.GetPokemonAbilityNames(ctx, pokemon.ID)
+		if err != nil {
+			return model.Team_member{}, false, err
+		}
+		ability, ok := resolveName(set.Ability, abilities, set.AbilityLine, pokemon.Name+" can't have the ability "+set.Ability, addError)
+		valid = valid && ok
+		member.Ability = ability
+	}
+
+	if set.Nature != "" {
+		nature, ok := resolveName(set.Nature, candidates.natures, set.NatureLine, "unknown nature "+set.Nature, addError)
+		valid = valid && ok
+		member.Nature = nature
+	}
+
+	learnset, err := r.database.GetPokemonMoveNames(ctx, pokemon.ID)
+	if err != nil {
+		return model.Team_member{}, false, err
+	}
+	for i, move := range set.Moves {
+		if i == teams.MaxMoves {
+			addError(move.Line, fmt.Sprintf("a pokemon knows at most %d moves", teams.MaxMoves))
+			valid = false
+			break
+		}
+
+		name, ok := resolveName(move.Name, learnset, move.Line, pokemon.Name+" can't learn "+move.Name, addError)
+		if !ok {
+			valid = false
+			continue
+		}
+		member.Moves = append(member.Moves, name)
+	}
+
+	statReq := teams.StatRequest(member)
+	if err := stats.ValidateRequest(&statReq); err != nil {
+		addError(set.Line, err.Error())
+		valid = false
+	}
+
+	return member, valid, nil
+}
+
+// Looks the pokemon up by its exact name, then as a species like "landorus" for its default form "landorus-incarnate",
+// fetching either when it isn't stored. Other names are matched against the stored pokemon allowing for typos
+func (r *repository) resolvePokemon(ctx context.Context, set showdown.Set, storedPokemon []string, addError func(int, string)) (model.Pokemon_summary, bool, error) {
+	id := showdown.ToID(set.Species)
+
+	pokemon, err := r.GetPokemon(ctx, id)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		pokemon, err = r.getDefaultPokemon(ctx, id)
+	}
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		name, ok := resolveName(set.Species, storedPokemon, set.Line, "unknown pokemon "+set.Species, addError)
+		if !ok {
+			return model.Pokemon_summary{}, false, nil
+		}
+		pokemon, err = r.GetPokemon(ctx, name)
+	}
+	if err != nil {
+		return model.Pokemon_summary{}, false, err
+	}
+
+	return pokemon, true, nil
+}
+
+// Returns the default variety of the species with the pokeapi name, pokeapi.ErrNotFound if there is no such species
+func (r *repository) getDefaultPokemon(ctx context.Context, speciesName string) (model.Pokemon_summary, error) {
+	if !pokeapi.SlugPattern.MatchString(speciesName) {
+		return model.Pokemon_summary{}, pokeapi.ErrNotFound
+	}
+
+	species, err := r.pokeAPIClient.GetPokemonSpeciesByName(ctx, speciesName)
+	if err != nil {
+		return model.Pokemon_summary{}, err
+	}
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			return r.GetPokemon(ctx, variety.Pokemon.Name)
+		}
+	}
+
+	return model.Pokemon_summary{}, pokeapi.ErrNotFound
+}
+
+// Looks the item up by its exact name, fetching it when it isn't stored. Other names are matched against the stored items allowing for typos
+func (r *repository) resolveItem(ctx context.Context, set showdown.Set, storedItems []string, addError func(int, string)) (string, bool, error) {
+	id := showdown.ToID(set.Item)
+	if pokeapi.SlugPattern.MatchString(id) {
+		_, err := r.GetItem(ctx, id)
+		if err == nil {
+			return id, true, nil
+		}
+		if !errors.Is(err, pokeapi.ErrNotFound) {
+			return "", false, err
+		}
+	}
+
+	name, ok := resolveName(set.Item, storedItems, set.Line, "unknown item "+set.Item, addError)
+	return name, ok, nil
+}
+
+// Matches the display name against the pokeapi names of the candidates allowing for typos. Adds the message as error of the line
+// if no candidate is close enough and lists the candidates if several are equally close
+func resolveName(displayName string, candidates []string, line int, message string, addError func(int, string)) (string, bool) {
+	matches := showdown.Matches(showdown.ToID(displayName), candidates)
+	switch len(matches) {
+	case 1:
+		return matches[0], true
+	case 0:
+		addError(line, message)
+	default:
+		addError(line, displayName+" is ambiguous, it could be "+strings.Join(matches, " or "))
+	}
+
+	return "", false
+}
+
+// Returns sql.ErrNoRows if the team doesn't exist
+func (r *repository) ExportTeam(ctx context.Context, id int) (string, error) {
+	team, err := r.database.GetTeam(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return showdown.Format(team), nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"poke-atlas/web-service/internal/flavortext"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/parallel"
+	"strconv"
+)
+
+func (r *repository) GetSpeciesVarieties(ctx context.Context, speciesID int) (model.Species_varieties, error) {
+	species, err := r.database.GetSpeciesVarieties(ctx, speciesID)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("species %d not found in the database!", speciesID)
+
+		fetchedSpecies, err := r.pokeAPIClient.GetPokemonSpecies(ctx, speciesID)
+		if err != nil {
+			return model.Species_varieties{}, err
+		}
+
+		varietyIDs := make([]int, len(fetchedSpecies.Varieties))
+		for i, variety := range fetchedSpecies.Varieties {
+			varietyIDs[i] = variety.Pokemon.ID()
+		}
+
+		formNames, err := r.getPokemonFormNames(ctx, varietyIDs)
+		if err != nil {
+			return model.Species_varieties{}, err
+		}
+
+		log.Printf("fetching %d forms of species %d from api...", len(formNames), speciesID)
+
+		forms, errs := parallel.Map(formNames, maxConcurrentFetches, func(name string) (model.PokemonForm, error) {
+			return r.pokeAPIClient.GetPokemonForm(ctx, name)
+		})
+		if err := parallel.FirstError(errs); err != nil {
+			return model.Species_varieties{}, err
+		}
+
+		for i, f := range fetchedSpecies.FlavorTextEntries {
+			fetchedSpecies.FlavorTextEntries[i].FlavorText = flavortext.Normalize(f.FlavorText)
+		}
+
+		err = r.database.AddSpecies(ctx, fetchedSpecies, forms)
+		if err != nil {
+			return model.Species_varieties{}, err
+		}
+
+		return r.database.GetSpeciesVarieties(ctx, speciesID)
+	}
+
+	if err != nil {
+		return model.Species_varieties{}, err
+	}
+
+	return species, nil
+}
+
+// Names of the forms of the pokemon, as forms are only listed by their pokemon.
+// Missing pokemon are fetched, and so are pokemon stored before their form names were kept
+func (r *repository) getPokemonFormNames(ctx context.Context, ids []int) ([]string, error) {
+	if err := r.ensurePokemons(ctx, ids); err != nil {
+		return nil, err
+	}
+
+	var stale []int
+	for _, id := range ids {
+		fetched, err := r.database.HasPokemonFormNames(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !fetched {
+			stale = append(stale, id)
+		}
+	}
+
+	if len(stale) > 0 {
+		log.Printf("forms of %d pokemon not found in the database, fetching from api...", len(stale))
+
+		pokemons, errs := parallel.Map(stale, maxConcurrentFetches, func(id int) (model.Pokemon, error) {
+			return r.pokeAPIClient.GetPokemon(ctx, strconv.Itoa(id))
+		})
+		for i, id := range stale {
+			if errs[i] != nil {
+				return nil, errs[i]
+			}
+			if err := r.database.AddPokemonFormNames(ctx, id, pokemons[i].Forms); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var names []string
+	for _, id := range ids {
+		formNames, err := r.database.GetPokemonFormNames(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, formNames...)
+	}
+
+	return names, nil
+}
+
+// Pokedex entries of the species of the pokemon, an unknown version is an apierror.ValidationError.
+// Empty version and language return the entries of every version and language
+func (r *repository) GetPokemonFlavorText(ctx context.Context, id int, version string, language string) (model.Pokemon_flavor_text, error) {
+	if err := r.checkVersion(ctx, version); err != nil {
+		return model.Pokemon_flavor_text{}, err
+	}
+
+	if err := r.ensurePokemon(ctx, id); err != nil {
+		return model.Pokemon_flavor_text{}, err
+	}
+	speciesID, err := r.database.GetPokemonSpeciesID(ctx, id)
+	if err != nil {
+		return model.Pokemon_flavor_text{}, err
+	}
+
+	// Fetches the species with its flavor text if it is missing
+	species, err := r.GetSpeciesVarieties(ctx, speciesID)
+	if err != nil {
+		return model.Pokemon_flavor_text{}, err
+	}
+	if err := r.ensureSpeciesFlavorText(ctx, speciesID); err != nil {
+		return model.Pokemon_flavor_text{}, err
+	}
+
+	entries, err := r.database.GetSpeciesFlavorText(ctx, speciesID, version, language)
+	if err != nil {
+		return model.Pokemon_flavor_text{}, err
+	}
+
+	return model.Pokemon_flavor_text{
+		PokemonID:   id,
+		SpeciesName: species.Name,
+		Entries:     entries,
+	}, nil
+}
+
+// Fetches the flavor text of species stored before their flavor text was kept, the species must already exist in the database
+func (r *repository) ensureSpeciesFlavorText(ctx context.Context, speciesID int) error {
+	fetched, err := r.database.HasSpeciesFlavorText(ctx, speciesID)
+	if err != nil || fetched {
+		return err
+	}
+
+	log.Printf("flavor text of species %d not found in the database, fetching from api...", speciesID)
+
+	species, err := r.pokeAPIClient.GetPokemonSpecies(ctx, speciesID)
+	if err != nil {
+		return err
+	}
+
+	return r.database.AddSpeciesFlavorText(ctx, speciesID, species.FlavorTextEntries)
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"os"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/parallel"
+	"poke-atlas/web-service/internal/spriteimage"
+	"poke-atlas/web-service/internal/sprites"
+	"strconv"
+)
+
+// Serves the sprite from the media cache, fetching it on the first request. Resized and converted sprites are cached as well.
+// With a form the sprite of that form of the pokemon is served instead, forms are stored with the varieties of their species.
+// Returns apierror.ValidationError for an unknown variant and sql.ErrNoRows if the pokemon or form doesn't have the variant
+func (r *repository) GetSprite(ctx context.Context, id int, form string, variant string, options spriteimage.Options) (model.Cached_media, error) {
+	url, err := r.getSpriteURL(ctx, id, form, variant)
+	if err != nil {
+		return model.Cached_media{}, err
+	}
+
+	sprite, err := r.cacheMedia(ctx, url)
+	if err != nil || options.IsOriginal() {
+		return sprite, err
+	}
+
+	return r.deriveSprite(ctx, sprite, options)
+}
+
+func (r *repository) getSpriteURL(ctx context.Context, id int, form string, variant string) (string, error) {
+	if form == "" {
+		if err := sprites.ValidateVariant(variant); err != nil {
+			return "", err
+		}
+		if err := r.ensurePokemonSprites(ctx, []int{id}); err != nil {
+			return "", err
+		}
+		return r.database.GetPokemonSpriteURL(ctx, id, variant)
+	}
+
+	if err := sprites.ValidateFormVariant(variant); err != nil {
+		return "", err
+	}
+	return r.database.GetPokemonFormSpriteURL(ctx, id, form, variant)
+}
+
+// Returns the sprite scaled and encoded with the options, deriving it from the original on the first request
+func (r *repository) deriveSprite(ctx context.Context, sprite model.Cached_media, options spriteimage.Options) (model.Cached_media, error) {
+	derived, err := r.database.GetDerivedMedia(ctx, sprite.Hash, options.Size, options.Format)
+	if err == nil && r.mediaCache.Has(derived.Hash) {
+		derived.URL = sprite.URL
+		derived.Path = r.mediaCache.Path(derived.Hash)
+		return derived, nil
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return model.Cached_media{}, err
+	}
+
+	data, err := os.ReadFile(sprite.Path)
+	if err != nil {
+		return model.Cached_media{}, err
+	}
+
+	data, contentType, err := spriteimage.Transform(data, options)
+	if err != nil {
+		return model.Cached_media{}, err
+	}
+
+	hash, err := r.mediaCache.Put(data)
+	if err != nil {
+		return model.Cached_media{}, err
+	}
+
+	derived = model.Cached_media{
+		URL:         sprite.URL,
+		Hash:        hash,
+		ContentType: contentType,
+		Size:        len(data),
+		Path:        r.mediaCache.Path(hash),
+	}
+	if err := r.database.AddDerivedMedia(ctx, sprite.Hash, options.Size, options.Format, derived); err != nil {
+		return model.Cached_media{}, err
+	}
+
+	return derived, nil
+}
+
+// Fetches the variants of the pokemon ids offset+1 to offset+limit into the media cache,
+// so the sprites can be served without reaching pokeapi or github later
+func (r *repository) SyncSprites(ctx context.Context, offset int, limit int, variants []string) (model.Sprite_sync, error) {
+	ids := make([]int, limit)
+	for i := range ids {
+		ids[i] = offset + i + 1
+	}
+
+	if err := r.ensurePokemonSprites(ctx, ids); err != nil {
+		return model.Sprite_sync{}, err
+	}
+
+	result := model.Sprite_sync{Pokemon: len(ids), Variants: variants}
+	var missing []string
+	for _, id := range ids {
+		for _, variant := range variants {
+			url, err := r.database.GetPokemonSpriteURL(ctx, id, variant)
+			if errors.Is(err, sql.ErrNoRows) {
+				result.Unavailable++
+				continue
+			}
+			if err != nil {
+				return model.Sprite_sync{}, err
+			}
+
+			_, cached, err := r.cachedMedia(ctx, url)
+			if err != nil {
+				return model.Sprite_sync{}, err
+			}
+			if cached {
+				result.Cached++
+				continue
+			}
+			missing = append(missing, url)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	log.Printf("fetching %d sprites...", len(missing))
+
+	media, errs := parallel.Map(missing, maxConcurrentFetches, func(url string) (model.Cached_media, error) {
+		return r.fetchMedia(ctx, url)
+	})
+
+	for i := range missing {
+		if errs[i] != nil {
+			return model.Sprite_sync{}, errs[i]
+		}
+		if err := r.database.AddCachedMedia(ctx, media[i]); err != nil {
+			return model.Sprite_sync{}, err
+		}
+		result.Fetched++
+	}
+
+	return result, nil
+}
+
+// Fetches the pokemon missing from the database, and the sprite urls of pokemon stored before their variants were kept
+func (r *repository) ensurePokemonSprites(ctx context.Context, ids []int) error {
+	if err := r.ensurePokemons(ctx, ids); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		fetched, err := r.database.HasPokemonSprites(ctx, id)
+		if err != nil {
+			return err
+		}
+		if fetched {
+			continue
+		}
+
+		log.Printf("sprites of pokemon %d not found in the database, fetching from api...", id)
+
+		pokemon, err := r.pokeAPIClient.GetPokemon(ctx, strconv.Itoa(id))
+		if err != nil {
+			return err
+		}
+		if err := r.database.AddPokemonSprites(ctx, id, pokemon.Sprites); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the cached file of the url, fetching it first if it isn't cached
+func (r *repository) cacheMedia(ctx context.Context, url string) (model.Cached_media, error) {
+	media, cached, err := r.cachedMedia(ctx, url)
+	if err != nil || cached {
+		return media, err
+	}
+
+	media, err = r.fetchMedia(ctx, url)
+	if err != nil {
+		return model.Cached_media{}, err
+	}
+
+	if err := r.database.AddCachedMedia(ctx, media); err != nil {
+		return model.Cached_media{}, err
+	}
+
+	return media, nil
+}
+
+// Whether the url is cached, files removed from the cache directory count as not cached
+func (r *repository) cachedMedia(ctx context.Context, url string) (model.Cached_media, bool, error) {
+	media, err := r.database.GetCachedMedia(ctx, url)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Cached_media{}, false, nil
+	}
+	if err != nil {
+		return model.Cached_media{}, false, err
+	}
+	if !r.mediaCache.Has(media.Hash) {
+		return model.Cached_media{}, false, nil
+	}
+
+	media.Path = r.mediaCache.Path(media.Hash)
+	return media, true, nil
+}
+
+// Fetches the url into the media cache, the result still has to be added to the database
+func (r *repository) fetchMedia(ctx context.Context, url string) (model.Cached_media, error) {
+	data, contentType, err := r.pokeAPIClient.GetMedia(ctx, url)
+	if err != nil {
+		return model.Cached_media{}, err
+	}
+
+	hash, err := r.mediaCache.Put(data)
+	if err != nil {
+		return model.Cached_media{}, err
+	}
+
+	return model.Cached_media{
+		URL:         url,
+		Hash:        hash,
+		ContentType: contentType,
+		Size:        len(data),
+		Path:        r.mediaCache.Path(hash),
+	}, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/stats"
+)
+
+func (r *repository) GetNatures(ctx context.Context) ([]model.Nature_details, error) {
+	natures, err := r.database.GetNatures(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Println("natures not found in the database, fetching from api...")
+
+		fetchedNatures, err := r.pokeAPIClient.GetNatures(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.database.AddNatures(ctx, fetchedNatures)
+		if err != nil {
+			return nil, err
+		}
+
+		return r.database.GetNatures(ctx)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return natures, nil
+}
+
+// Returns apierror.ValidationError if the nature doesn't exist
+func (r *repository) getNature(ctx context.Context, name string) (model.Nature_details, error) {
+	natures, err := r.GetNatures(ctx)
+	if err != nil {
+		return model.Nature_details{}, err
+	}
+
+	for _, nature := range natures {
+		if nature.Name == name {
+			return nature, nil
+		}
+	}
+
+	return model.Nature_details{}, apierror.Validationf("unknown nature %s", name)
+}
+
+func (r *repository) GetCharacteristics(ctx context.Context) ([]model.Characteristic_details, error) {
+	characteristics, err := r.database.GetCharacteristics(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Println("characteristics not found in the database, fetching from api...")
+
+		fetchedCharacteristics, err := r.pokeAPIClient.GetCharacteristics(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.database.AddCharacteristics(ctx, fetchedCharacteristics)
+		if err != nil {
+			return nil, err
+		}
+
+		return r.database.GetCharacteristics(ctx)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return characteristics, nil
+}
+
+// Calculates final stats from the stored base stats, the request must be validated with stats.ValidateRequest
+func (r *repository) CalculateStats(ctx context.Context, id int, req model.Stat_calculation_request) (model.Calculated_stats, error) {
+	if err := r.ensurePokemon(ctx, id); err != nil {
+		return model.Calculated_stats{}, err
+	}
+
+	name, base, err := r.database.GetBaseStats(ctx, id)
+	if err != nil {
+		return model.Calculated_stats{}, err
+	}
+
+	// No nature is the same as a neutral nature
+	var nature model.Nature_details
+	if req.Nature != "" {
+		nature, err = r.getNature(ctx, req.Nature)
+		if err != nil {
+			return model.Calculated_stats{}, err
+		}
+	}
+
+	return model.Calculated_stats{
+		PokemonID:   id,
+		PokemonName: name,
+		Level:       req.Level,
+		Nature:      req.Nature,
+		Formula:     req.Formula,
+		BaseStats:   base,
+		Stats:       stats.Calculate(base, nature, req),
+	}, nil
+}
+
+// Estimates IV ranges from observed stats, the request must be validated with stats.ValidateIVRangeRequest
+func (r *repository) EstimateIVs(ctx context.Context, id int, req model.Iv_range_request) (model.Iv_ranges, error) {
+	if err := r.ensurePokemon(ctx, id); err != nil {
+		return model.Iv_ranges{}, err
+	}
+
+	name, base, err := r.database.GetBaseStats(ctx, id)
+	if err != nil {
+		return model.Iv_ranges{}, err
+	}
+
+	var nature model.Nature_details
+	if req.Nature != "" {
+		nature, err = r.getNature(ctx, req.Nature)
+		if err != nil {
+			return model.Iv_ranges{}, err
+		}
+	}
+
+	lowest, highest, err := stats.EstimateIVs(base, nature, req.Snapshots)
+	if err != nil {
+		return model.Iv_ranges{}, err
+	}
+
+	return model.Iv_ranges{
+		PokemonID:   id,
+		PokemonName: name,
+		Nature:      req.Nature,
+		BaseStats:   base,
+		Min:         lowest,
+		Max:         highest,
+	}, nil
+}
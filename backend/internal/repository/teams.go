@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/stats"
+	"poke-atlas/web-service/internal/teams"
+)
+
+func (r *repository) GetTeams(ctx context.Context) ([]model.Team, error) {
+	return r.database.GetTeams(ctx)
+}
+
+// Returns sql.ErrNoRows if the team doesn't exist
+func (r *repository) GetTeam(ctx context.Context, id int) (model.Team, error) {
+	return r.database.GetTeam(ctx, id)
+}
+
+// The team must be validated with teams.ValidateTeam
+func (r *repository) CreateTeam(ctx context.Context, team model.Team) (model.Team, error) {
+	if err := r.prepareTeamMembers(ctx, team.Members); err != nil {
+		return model.Team{}, err
+	}
+
+	id, err := r.database.CreateTeam(ctx, team)
+	if err != nil {
+		return model.Team{}, err
+	}
+
+	return r.database.GetTeam(ctx, id)
+}
+
+// The team must be validated with teams.ValidateTeam, returns sql.ErrNoRows if the team doesn't exist
+func (r *repository) UpdateTeam(ctx context.Context, id int, team model.Team) (model.Team, error) {
+	if err := r.prepareTeamMembers(ctx, team.Members); err != nil {
+		return model.Team{}, err
+	}
+
+	if err := r.database.UpdateTeam(ctx, id, team); err != nil {
+		return model.Team{}, err
+	}
+
+	return r.database.GetTeam(ctx, id)
+}
+
+// Returns sql.ErrNoRows if the team doesn't exist
+func (r *repository) DeleteTeam(ctx context.Context, id int) error {
+	return r.database.DeleteTeam(ctx, id)
+}
+
+// Stores missing member pokemon and checks their natures, unknown natures are an apierror.ValidationError
+func (r *repository) prepareTeamMembers(ctx context.Context, members []model.Team_member) error {
+	for _, member := range members {
+		if err := r.ensurePokemon(ctx, member.PokemonID); err != nil {
+			return err
+		}
+		if member.Nature != "" {
+			if _, err := r.getNature(ctx, member.Nature); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Returns sql.ErrNoRows if the team doesn't exist
+func (r *repository) GetTeamAnalysis(ctx context.Context, id int) (model.Team_analysis, error) {
+	team, err := r.database.GetTeam(ctx, id)
+	if err != nil {
+		return model.Team_analysis{}, err
+	}
+
+	chart, err := r.GetTypeChart(ctx)
+	if err != nil {
+		return model.Team_analysis{}, err
+	}
+
+	var members []teams.Member
+	for _, m := range team.Members {
+		_, base, err := r.database.GetBaseStats(ctx, m.PokemonID)
+		if err != nil {
+			return model.Team_analysis{}, err
+		}
+
+		types, err := r.database.GetPokemonTypes(ctx, m.PokemonID)
+		if err != nil {
+			return model.Team_analysis{}, err
+		}
+
+		var nature model.Nature_details
+		if m.Nature != "" {
+			nature, err = r.getNature(ctx, m.Nature)
+			if err != nil {
+				return model.Team_analysis{}, err
+			}
+		}
+
+		var moves []model.Move_details
+		for _, name := range m.Moves {
+			move, err := r.GetMove(ctx, name)
+			if err != nil {
+				return model.Team_analysis{}, err
+			}
+			moves = append(moves, move)
+		}
+
+		members = append(members, teams.Member{
+			PokemonID: m.PokemonID,
+			Name:      m.PokemonName,
+			Types:     types,
+			Ability:   m.Ability,
+			Speed:     stats.Calculate(base, nature, teams.StatRequest(m)).Speed,
+			Moves:     moves,
+		})
+	}
+
+	analysis := teams.Analyze(members, chart)
+	analysis.TeamID = team.ID
+	analysis.Name = team.Name
+
+	return analysis, nil
+}
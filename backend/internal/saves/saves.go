@@ -0,0 +1,59 @@
+package saves
+
+import (
+	"math"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"strings"
+)
+
+// Validates a new save, the version is checked against pokeapi by the repository
+func ValidateSave(save *model.Save) error {
+	save.TrainerName = strings.TrimSpace(save.TrainerName)
+	save.Version = strings.ToLower(strings.TrimSpace(save.Version))
+
+	if save.TrainerName == "" {
+		return apierror.Validationf("trainer name is required")
+	}
+	if save.Version == "" {
+		return apierror.Validationf("version is required")
+	}
+	return nil
+}
+
+// Caught implies seen and shiny implies caught, so marking a shiny also marks it caught and seen
+func NormalizeStatus(status model.Save_pokemon_status) model.Save_pokemon_status {
+	if status.Shiny {
+		status.Caught = true
+	}
+	if status.Caught {
+		status.Seen = true
+	}
+	return status
+}
+
+// Adds up the completion of the generations and fills in their caught percentages
+func Summarize(generations []model.Completion) model.Completion {
+	total := model.Completion{Generation: "all"}
+
+	for i := range generations {
+		g := &generations[i]
+		g.CaughtPercent = percent(g.Caught, g.Total)
+
+		total.Total += g.Total
+		total.Seen += g.Seen
+		total.Caught += g.Caught
+		total.Shiny += g.Shiny
+	}
+
+	total.CaughtPercent = percent(total.Caught, total.Total)
+	return total
+}
+
+// Rounded to one decimal
+func percent(part int, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(whole)*1000) / 10
+}
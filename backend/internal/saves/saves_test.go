@@ -0,0 +1,58 @@
+package saves
+
+import (
+	"poke-atlas/web-service/internal/model"
+	"testing"
+)
+
+func TestValidateSave(t *testing.T) {
+	save := model.Save{TrainerName: " Red ", Version: " Red "}
+	if err := ValidateSave(&save); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if save.TrainerName != "Red" || save.Version != "red" {
+		t.Errorf("expected trimmed trainer name and lowercase version, got %+v", save)
+	}
+
+	if err := ValidateSave(&model.Save{Version: "red"}); err == nil {
+		t.Error("expected error for missing trainer name")
+	}
+	if err := ValidateSave(&model.Save{TrainerName: "Red"}); err == nil {
+		t.Error("expected error for missing version")
+	}
+}
+
+func TestNormalizeStatus(t *testing.T) {
+	tests := []struct {
+		status   model.Save_pokemon_status
+		expected model.Save_pokemon_status
+	}{
+		{model.Save_pokemon_status{Shiny: true}, model.Save_pokemon_status{Seen: true, Caught: true, Shiny: true}},
+		{model.Save_pokemon_status{Caught: true}, model.Save_pokemon_status{Seen: true, Caught: true}},
+		{model.Save_pokemon_status{Seen: true}, model.Save_pokemon_status{Seen: true}},
+		{model.Save_pokemon_status{}, model.Save_pokemon_status{}},
+	}
+
+	for _, test := range tests {
+		if result := NormalizeStatus(test.status); result != test.expected {
+			t.Errorf("%+v: expected %+v, got %+v", test.status, test.expected, result)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	generations := []model.Completion{
+		{Generation: "generation-i", Total: 151, Seen: 100, Caught: 75, Shiny: 1},
+		{Generation: "generation-ii", Total: 100, Seen: 10, Caught: 1},
+		{Generation: "generation-ix", Total: 0},
+	}
+
+	total := Summarize(generations)
+
+	if total != (model.Completion{Generation: "all", Total: 251, Seen: 110, Caught: 76, Shiny: 1, CaughtPercent: 30.3}) {
+		t.Errorf("unexpected total %+v", total)
+	}
+	if generations[0].CaughtPercent != 49.7 || generations[1].CaughtPercent != 1 || generations[2].CaughtPercent != 0 {
+		t.Errorf("unexpected percentages %+v", generations)
+	}
+}
@@ -0,0 +1,96 @@
+package showdown
+
+import (
+	"poke-atlas/web-service/internal/model"
+	"strings"
+)
+
+var nameReplacer = strings.NewReplacer(
+	"♀", "-f",
+	"♂", "-m",
+	"é", "e",
+	"É", "e",
+	"’", "",
+	"'", "",
+	".", "",
+	":", "",
+	"%", "",
+)
+
+// Turns a display name like "Mr. Mime", "King's Shield" or "Nidoran♀" into its pokeapi name
+func ToID(name string) string {
+	// "Hidden Power [Fire]" is the move hidden-power
+	if start := strings.Index(name, "["); start != -1 {
+		name = name[:start]
+	}
+
+	name = strings.ToLower(nameReplacer.Replace(name))
+
+	var b strings.Builder
+	for _, word := range strings.Fields(name) {
+		if b.Len() > 0 {
+			b.WriteString("-")
+		}
+		for _, r := range word {
+			if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Pokemon keep the dash of their forms like Showdown does, "landorus-therian" becomes "Landorus-Therian"
+func PokemonDisplayName(name string) string {
+	return strings.ReplaceAll(model.DisplayName(name), " ", "-")
+}
+
+// Finds the candidates matching the pokeapi name id, allowing for roughly one typo per four characters.
+// An exact match is the only match, otherwise every candidate at the smallest distance matches
+func Matches(id string, candidates []string) []string {
+	var matches []string
+	bestDistance := max(1, len(id)/4)
+
+	for _, candidate := range candidates {
+		if candidate == id {
+			return []string{candidate}
+		}
+
+		distance := editDistance(id, candidate)
+		if distance < bestDistance {
+			matches, bestDistance = nil, distance
+		}
+		if distance == bestDistance {
+			matches = append(matches, candidate)
+		}
+	}
+
+	return matches
+}
+
+// Edit distance where swapping two adjacent letters counts as one edit (optimal string alignment)
+func editDistance(a string, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,291 @@
+package showdown
+
+import (
+	"fmt"
+	"poke-atlas/web-service/internal/model"
+	"strconv"
+	"strings"
+)
+
+// Pokemon Showdown's paste format for sets (https://pokepast.es/syntax.html):
+//
+//	Nickname (Species) (M) @ Item
+//	Ability: Levitate
+//	Level: 50
+//	EVs: 252 Atk / 4 SpD / 252 Spe
+//	Adamant Nature
+//	IVs: 0 SpA
+//	- Move
+
+// A set as written in the paste, names are not resolved yet
+type Set struct {
+	Line        int
+	Species     string
+	Item        string
+	Ability     string
+	AbilityLine int
+	Nature      string
+	NatureLine  int
+	Level       int
+	EVs         model.Stat_spread
+	// Only given when the paste has an IVs line, missing IVs are 31
+	IVs   *model.Stat_spread
+	Moves []Move
+}
+
+type Move struct {
+	Line int
+	Name string
+}
+
+type Paste struct {
+	// Team name from a "=== [format] Name ===" header
+	Name string
+	Sets []Set
+}
+
+// Problems with individual lines of the paste
+type ParseError struct {
+	Errors []model.Line_error
+}
+
+func (e ParseError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, lineError := range e.Errors {
+		messages[i] = fmt.Sprintf("line %d: %s", lineError.Line, lineError.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ParseError) add(line int, format string, args ...any) {
+	e.Errors = append(e.Errors, model.Line_error{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+var statAbbreviations = map[string]string{
+	"hp":  model.StatHP,
+	"atk": model.StatAttack,
+	"def": model.StatDefense,
+	"spa": model.StatSpecialAttack,
+	"spd": model.StatSpecialDefense,
+	"spe": model.StatSpeed,
+}
+
+// Lines Showdown writes that the team model has no place for
+var ignoredKeys = []string{"shiny", "tera type", "happiness", "gigantamax", "dynamax level", "pokeball", "hidden power"}
+
+// Parses the syntax of a paste, returns a ParseError listing every invalid line
+func Parse(text string) (Paste, error) {
+	var paste Paste
+	var parseErr ParseError
+	var current *Set
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, raw := range lines {
+		lineNumber := i + 1
+		line := strings.TrimSpace(raw)
+
+		if line == "" {
+			current = nil
+			continue
+		}
+
+		if strings.HasPrefix(line, "===") {
+			paste.Name = parseHeader(line)
+			current = nil
+			continue
+		}
+
+		// The first line of a set names the pokemon
+		if current == nil {
+			set, err := parseFirstLine(line)
+			if err != nil {
+				parseErr.add(lineNumber, "%v", err)
+			}
+			set.Line = lineNumber
+			paste.Sets = append(paste.Sets, set)
+			current = &paste.Sets[len(paste.Sets)-1]
+			continue
+		}
+
+		if err := parseLine(current, line, lineNumber); err != nil {
+			parseErr.add(lineNumber, "%v", err)
+		}
+	}
+
+	if len(paste.Sets) == 0 && len(parseErr.Errors) == 0 {
+		parseErr.add(1, "the paste contains no pokemon")
+	}
+
+	if len(parseErr.Errors) > 0 {
+		return paste, parseErr
+	}
+	return paste, nil
+}
+
+// "=== [gen9ou] Rain ===" names the team "Rain"
+func parseHeader(line string) string {
+	name := strings.TrimSpace(strings.Trim(line, "="))
+	if strings.HasPrefix(name, "[") {
+		if end := strings.Index(name, "]"); end != -1 {
+			name = strings.TrimSpace(name[end+1:])
+		}
+	}
+	return name
+}
+
+func parseFirstLine(line string) (Set, error) {
+	var set Set
+
+	name, item, hasItem := strings.Cut(line, "@")
+	if hasItem {
+		set.Item = strings.TrimSpace(item)
+	}
+
+	name = strings.TrimSpace(name)
+	name = strings.TrimSuffix(strings.TrimSuffix(name, " (M)"), " (F)")
+
+	// A nicknamed pokemon has its species in parentheses
+	if strings.HasSuffix(name, ")") {
+		if start := strings.LastIndex(name, " ("); start != -1 {
+			name = name[start+2 : len(name)-1]
+		}
+	}
+
+	set.Species = strings.TrimSpace(name)
+	if set.Species == "" {
+		return set, fmt.Errorf("missing pokemon")
+	}
+	return set, nil
+}
+
+func parseLine(set *Set, line string, lineNumber int) error {
+	if strings.HasPrefix(line, "-") || strings.HasPrefix(line, "~") {
+		move := strings.TrimSpace(line[1:])
+		// Slashes separate alternatives, the first one is used
+		move, _, _ = strings.Cut(move, "/")
+		move = strings.TrimSpace(move)
+		if move == "" {
+			return fmt.Errorf("missing move name")
+		}
+		set.Moves = append(set.Moves, Move{Line: lineNumber, Name: move})
+		return nil
+	}
+
+	if nature, ok := strings.CutSuffix(line, " Nature"); ok {
+		set.Nature = strings.TrimSpace(nature)
+		set.NatureLine = lineNumber
+		return nil
+	}
+
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return fmt.Errorf("unrecognized line %q", line)
+	}
+	value = strings.TrimSpace(value)
+
+	switch strings.ToLower(strings.TrimSpace(key)) {
+	case "ability":
+		set.Ability = value
+		set.AbilityLine = lineNumber
+	case "level":
+		level, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("level must be a number")
+		}
+		set.Level = level
+	case "evs":
+		evs, err := parseSpread(value, model.Stat_spread{})
+		if err != nil {
+			return err
+		}
+		set.EVs = evs
+	case "ivs":
+		ivs, err := parseSpread(value, model.NewStatSpread(31))
+		if err != nil {
+			return err
+		}
+		set.IVs = &ivs
+	default:
+		for _, ignored := range ignoredKeys {
+			if strings.EqualFold(strings.TrimSpace(key), ignored) {
+				return nil
+			}
+		}
+		return fmt.Errorf("unrecognized line %q", line)
+	}
+
+	return nil
+}
+
+// Parses "252 Atk / 4 SpD / 252 Spe", stats that aren't listed keep their value in spread
+func parseSpread(value string, spread model.Stat_spread) (model.Stat_spread, error) {
+	for _, part := range strings.Split(value, "/") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return spread, fmt.Errorf("invalid stat %q", strings.TrimSpace(part))
+		}
+
+		amount, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return spread, fmt.Errorf("invalid stat %q", strings.TrimSpace(part))
+		}
+		stat, ok := statAbbreviations[strings.ToLower(fields[1])]
+		if !ok {
+			return spread, fmt.Errorf("unknown stat %s", fields[1])
+		}
+
+		spread.Set(stat, amount)
+	}
+	return spread, nil
+}
+
+// Writes a team in paste format, names are turned back from pokeapi names into display names
+func Format(team model.Team) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== %s ===\n\n", team.Name)
+
+	for _, member := range team.Members {
+		b.WriteString(PokemonDisplayName(member.PokemonName))
+		if member.Item != "" {
+			b.WriteString(" @ " + model.DisplayName(member.Item))
+		}
+		b.WriteString("\n")
+
+		if member.Ability != "" {
+			fmt.Fprintf(&b, "Ability: %s\n", model.DisplayName(member.Ability))
+		}
+		if member.Level != 0 && member.Level != 100 {
+			fmt.Fprintf(&b, "Level: %d\n", member.Level)
+		}
+		if evs := formatSpread(member.EVs, 0); evs != "" {
+			fmt.Fprintf(&b, "EVs: %s\n", evs)
+		}
+		if member.Nature != "" {
+			fmt.Fprintf(&b, "%s Nature\n", model.DisplayName(member.Nature))
+		}
+		if member.IVs != nil {
+			if ivs := formatSpread(*member.IVs, 31); ivs != "" {
+				fmt.Fprintf(&b, "IVs: %s\n", ivs)
+			}
+		}
+		for _, move := range member.Moves {
+			fmt.Fprintf(&b, "- %s\n", model.DisplayName(move))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// Lists the stats that differ from the default value, e.g. "252 Atk / 4 SpD"
+func formatSpread(spread model.Stat_spread, defaultValue int) string {
+	var parts []string
+	for _, abbreviation := range []string{"HP", "Atk", "Def", "SpA", "SpD", "Spe"} {
+		value := spread.Get(statAbbreviations[strings.ToLower(abbreviation)])
+		if value != defaultValue {
+			parts = append(parts, fmt.Sprintf("%d %s", value, abbreviation))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
@@ -0,0 +1,160 @@
+package showdown
+
+import (
+	"errors"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+	"testing"
+)
+
+const paste = `=== [gen9vgc2024regh] Sand ===
+
+Chompy (Garchomp) (F) @ Choice Scarf
+Ability: Rough Skin
+Level: 50
+Tera Type: Steel
+EVs: 4 HP / 252 Atk / 252 Spe
+Jolly Nature
+- Earthquake
+- Dragon Claw / Outrage
+- Stone Edge
+- Hidden Power [Fire]
+
+Tyranitar @ Leftovers
+Ability: Sand Stream
+IVs: 0 Spe
+- Rock Slide
+`
+
+func TestParse(t *testing.T) {
+	result, err := Parse(paste)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if result.Name != "Sand" || len(result.Sets) != 2 {
+		t.Fatalf("expected team Sand with 2 sets, got %q with %d", result.Name, len(result.Sets))
+	}
+
+	garchomp := result.Sets[0]
+	if garchomp.Species != "Garchomp" || garchomp.Item != "Choice Scarf" || garchomp.Ability != "Rough Skin" || garchomp.Nature != "Jolly" {
+		t.Errorf("unexpected set %+v", garchomp)
+	}
+	if garchomp.Line != 3 || garchomp.Level != 50 || garchomp.IVs != nil {
+		t.Errorf("unexpected line, level or IVs in %+v", garchomp)
+	}
+	if garchomp.EVs != (model.Stat_spread{HP: 4, Attack: 252, Speed: 252}) {
+		t.Errorf("unexpected EVs %+v", garchomp.EVs)
+	}
+
+	var moves []string
+	for _, move := range garchomp.Moves {
+		moves = append(moves, move.Name)
+	}
+	if !slices.Equal(moves, []string{"Earthquake", "Dragon Claw", "Stone Edge", "Hidden Power [Fire]"}) {
+		t.Errorf("unexpected moves %v", moves)
+	}
+
+	tyranitar := result.Sets[1]
+	if tyranitar.Species != "Tyranitar" || tyranitar.IVs == nil || tyranitar.IVs.Speed != 0 || tyranitar.IVs.HP != 31 {
+		t.Errorf("unexpected set %+v", tyranitar)
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	_, err := Parse("Garchomp\nAbility Rough Skin\nEVs: 252 Atk / 4 Foo\n- Earthquake\n\n @ Leftovers")
+
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+
+	var lines []int
+	for _, lineError := range parseErr.Errors {
+		lines = append(lines, lineError.Line)
+	}
+	if !slices.Equal(lines, []int{2, 3, 6}) {
+		t.Errorf("expected errors on lines 2, 3 and 6, got %v", parseErr.Errors)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	ivs := model.NewStatSpread(31)
+	ivs.Speed = 0
+	team := model.Team{Name: "Sand", Members: []model.Team_member{{
+		PokemonName: "tyranitar",
+		Item:        "leftovers",
+		Ability:     "sand-stream",
+		Level:       50,
+		Nature:      "brave",
+		IVs:         &ivs,
+		EVs:         model.Stat_spread{HP: 252, Attack: 252, SpecialDefense: 4},
+		Moves:       []string{"rock-slide", "u-turn"},
+	}}}
+
+	expected := `=== Sand ===
+
+Tyranitar @ Leftovers
+Ability: Sand Stream
+Level: 50
+EVs: 252 HP / 252 Atk / 4 SpD
+Brave Nature
+IVs: 0 Spe
+- Rock Slide
+- U Turn
+
+`
+	if result := Format(team); result != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, result)
+	}
+
+	// The export has to import again
+	if _, err := Parse(Format(team)); err != nil {
+		t.Errorf("expected export to parse, got %v", err)
+	}
+}
+
+func TestToID(t *testing.T) {
+	tests := map[string]string{
+		"Mr. Mime":            "mr-mime",
+		"Farfetch’d":          "farfetchd",
+		"King's Shield":       "kings-shield",
+		"Nidoran♀":            "nidoran-f",
+		"Flabébé":             "flabebe",
+		"Type: Null":          "type-null",
+		"Landorus-Therian":    "landorus-therian",
+		"Hidden Power [Fire]": "hidden-power",
+		"U-turn":              "u-turn",
+	}
+
+	for name, expected := range tests {
+		if result := ToID(name); result != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, result)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	candidates := []string{"garchomp", "gabite", "landorus-incarnate", "earthquake", "calm", "bold", "mild"}
+
+	tests := []struct {
+		id       string
+		expected []string
+	}{
+		{"garchomp", []string{"garchomp"}},
+		{"garchmop", []string{"garchomp"}},
+		{"earthqauke", []string{"earthquake"}},
+		{"gacrhomp", []string{"garchomp"}},
+		{"landorus", nil},
+		{"pikachu", nil},
+		// "mold" is one typo away from both
+		{"mold", []string{"bold", "mild"}},
+		{"bold", []string{"bold"}},
+	}
+
+	for _, test := range tests {
+		if result := Matches(test.id, candidates); !slices.Equal(result, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.id, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package spriteimage
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"poke-atlas/web-service/internal/apierror"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+)
+
+const (
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+// Sizes sprites can be scaled to, every size is another derived file per sprite in the media cache
+var Sizes = []int{32, 48, 64, 96, 128, 256, 512}
+
+// How a sprite is served, the zero value is the original image
+type Options struct {
+	// Width and height of the square the image is scaled to fit, 0 keeps the original size
+	Size int
+	// Empty keeps the original format
+	Format string
+}
+
+func (o Options) IsOriginal() bool {
+	return o.Size == 0 && o.Format == ""
+}
+
+// Parses the size and format query parameters, both may be empty
+func ParseOptions(size string, format string) (Options, error) {
+	var options Options
+
+	if size != "" {
+		parsed, err := strconv.Atoi(size)
+		if err != nil {
+			return Options{}, apierror.Validationf("size must be a valid integer")
+		}
+		if !slices.Contains(Sizes, parsed) {
+			return Options{}, apierror.Validationf("size must be one of %s", joinSizes())
+		}
+		options.Size = parsed
+	}
+
+	switch format {
+	case "", FormatPNG, FormatWebP:
+		options.Format = format
+	default:
+		return Options{}, apierror.Validationf("format must be %s or %s", FormatPNG, FormatWebP)
+	}
+
+	return options, nil
+}
+
+// Scales and encodes the image, returns the new image with its content type.
+// Without a format the image is encoded as png
+func Transform(data []byte, options Options) ([]byte, string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding sprite: %w", err)
+	}
+
+	if options.Size != 0 {
+		width, height := fit(img.Bounds().Dx(), img.Bounds().Dy(), options.Size)
+		img = ScaleNearest(img, width, height)
+	}
+
+	var buf bytes.Buffer
+	if options.Format == FormatWebP {
+		if err := nativewebp.Encode(&buf, img, nil); err != nil {
+			return nil, "", fmt.Errorf("encoding webp: %w", err)
+		}
+		return buf.Bytes(), "image/webp", nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", fmt.Errorf("encoding png: %w", err)
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+// Scales with nearest-neighbor, every pixel of the result is a pixel of the source so pixel art stays sharp
+func ScaleNearest(src image.Image, width int, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		srcY := bounds.Min.Y + y*bounds.Dy()/height
+		for x := 0; x < width; x++ {
+			srcX := bounds.Min.X + x*bounds.Dx()/width
+			dst.Set(x, y, src.At(srcX, srcY))
+		}
+	}
+
+	return dst
+}
+
+// Size of the image scaled to fit a square, keeping its aspect ratio
+func fit(width int, height int, size int) (int, int) {
+	if width >= height {
+		return size, max(1, height*size/width)
+	}
+	return max(1, width*size/height), size
+}
+
+func joinSizes() string {
+	sizes := make([]string, len(Sizes))
+	for i, size := range Sizes {
+		sizes[i] = strconv.Itoa(size)
+	}
+	return strings.Join(sizes, ", ")
+}
@@ -0,0 +1,99 @@
+package spriteimage
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"poke-atlas/web-service/internal/apierror"
+	"testing"
+)
+
+// 2x2 checkerboard of black and transparent pixels
+func checkerboard() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.NRGBA{A: 255})
+	img.Set(1, 1, color.NRGBA{A: 255})
+	return img
+}
+
+func TestParseOptions(t *testing.T) {
+	options, err := ParseOptions("", "")
+	if err != nil || !options.IsOriginal() {
+		t.Errorf("expected original options, got %+v, %v", options, err)
+	}
+
+	options, err = ParseOptions("48", "webp")
+	if err != nil || options != (Options{Size: 48, Format: FormatWebP}) {
+		t.Errorf("expected size 48 webp, got %+v, %v", options, err)
+	}
+
+	for _, params := range [][2]string{{"abc", ""}, {"4", ""}, {"50", ""}, {"4096", ""}, {"", "gif"}} {
+		if _, err := ParseOptions(params[0], params[1]); !errors.As(err, &apierror.ValidationError{}) {
+			t.Errorf("%v: expected validation error, got %v", params, err)
+		}
+	}
+}
+
+func TestScaleNearest(t *testing.T) {
+	scaled := ScaleNearest(checkerboard(), 4, 4)
+
+	// Every source pixel becomes a 2x2 block without blending
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			expected := uint8(0)
+			if x/2 == y/2 {
+				expected = 255
+			}
+			if a := scaled.NRGBAAt(x, y).A; a != expected {
+				t.Errorf("pixel %d,%d: expected alpha %d, got %d", x, y, expected, a)
+			}
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct{ width, height, size, expectedWidth, expectedHeight int }{
+		{96, 96, 48, 48, 48},
+		{96, 96, 192, 192, 192},
+		{40, 30, 80, 80, 60},
+		{30, 40, 80, 60, 80},
+		{1000, 1, 10, 10, 1},
+	}
+
+	for _, test := range tests {
+		width, height := fit(test.width, test.height, test.size)
+		if width != test.expectedWidth || height != test.expectedHeight {
+			t.Errorf("%dx%d in %d: expected %dx%d, got %dx%d", test.width, test.height, test.size, test.expectedWidth, test.expectedHeight, width, height)
+		}
+	}
+}
+
+func TestTransform(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, checkerboard()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, contentType, err := Transform(buf.Bytes(), Options{Size: 8})
+	if err != nil || contentType != "image/png" {
+		t.Fatalf("expected png, got %q, %v", contentType, err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil || img.Bounds().Dx() != 8 || img.Bounds().Dy() != 8 {
+		t.Errorf("expected 8x8 png, got %v, %v", img.Bounds(), err)
+	}
+
+	data, contentType, err = Transform(buf.Bytes(), Options{Format: FormatWebP})
+	if err != nil || contentType != "image/webp" {
+		t.Fatalf("expected webp, got %q, %v", contentType, err)
+	}
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		t.Errorf("expected a webp file, got %q", data)
+	}
+
+	if _, _, err := Transform([]byte("not an image"), Options{Size: 8}); err == nil {
+		t.Error("expected error for invalid image")
+	}
+}
@@ -0,0 +1,98 @@
+package sprites
+
+import (
+	"fmt"
+	"net/url"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+	"strings"
+)
+
+const (
+	FrontDefault         = "front-default"
+	FrontShiny           = "front-shiny"
+	FrontFemale          = "front-female"
+	FrontShinyFemale     = "front-shiny-female"
+	BackDefault          = "back-default"
+	BackShiny            = "back-shiny"
+	BackFemale           = "back-female"
+	BackShinyFemale      = "back-shiny-female"
+	OfficialArtwork      = "official-artwork"
+	OfficialArtworkShiny = "official-artwork-shiny"
+)
+
+// Variants served by the sprite proxy
+var Variants = []string{
+	FrontDefault, FrontShiny, FrontFemale, FrontShinyFemale,
+	BackDefault, BackShiny, BackFemale, BackShinyFemale,
+	OfficialArtwork, OfficialArtworkShiny,
+}
+
+// Variants of a form, pokeapi only has front sprites of forms
+var FormVariants = []string{FrontDefault, FrontShiny}
+
+// URLs of the variants the pokemon has, pokeapi leaves out e.g. female sprites of species without gender differences
+func URLs(sprites model.PokemonSprites) map[string]string {
+	urls := map[string]string{}
+	for variant, url := range map[string]string{
+		FrontDefault:         sprites.FrontDefault,
+		FrontShiny:           sprites.FrontShiny,
+		FrontFemale:          sprites.FrontFemale,
+		FrontShinyFemale:     sprites.FrontShinyFemale,
+		BackDefault:          sprites.BackDefault,
+		BackShiny:            sprites.BackShiny,
+		BackFemale:           sprites.BackFemale,
+		BackShinyFemale:      sprites.BackShinyFemale,
+		OfficialArtwork:      sprites.Other.OfficialArtwork.FrontDefault,
+		OfficialArtworkShiny: sprites.Other.OfficialArtwork.FrontShiny,
+	} {
+		if url != "" {
+			urls[variant] = url
+		}
+	}
+	return urls
+}
+
+func ValidateVariant(variant string) error {
+	if !slices.Contains(Variants, variant) {
+		return apierror.Validationf("unknown sprite variant %q, expected one of %s", variant, strings.Join(Variants, ", "))
+	}
+	return nil
+}
+
+func ValidateFormVariant(variant string) error {
+	if !slices.Contains(FormVariants, variant) {
+		return apierror.Validationf("unknown form sprite variant %q, expected one of %s", variant, strings.Join(FormVariants, ", "))
+	}
+	return nil
+}
+
+// Path of the sprite on this server, serving it from the media cache
+func ProxyPath(pokemonID int, variant string) string {
+	return fmt.Sprintf("/api/v1/sprites/%d/%s", pokemonID, variant)
+}
+
+// Path of the sprite of a form of the pokemon on this server, e.g. unown-b of unown
+func FormProxyPath(pokemonID int, form string, variant string) string {
+	return ProxyPath(pokemonID, variant) + "?form=" + url.QueryEscape(form)
+}
+
+// Parses a comma separated list of variants, an empty list is the front default sprite
+func ParseVariants(list string) ([]string, error) {
+	if list == "" {
+		return []string{FrontDefault}, nil
+	}
+
+	var variants []string
+	for _, variant := range strings.Split(list, ",") {
+		variant = strings.TrimSpace(variant)
+		if err := ValidateVariant(variant); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(variants, variant) {
+			variants = append(variants, variant)
+		}
+	}
+	return variants, nil
+}
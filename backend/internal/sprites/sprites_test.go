@@ -0,0 +1,80 @@
+package sprites
+
+import (
+	"errors"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+	"testing"
+)
+
+func TestURLs(t *testing.T) {
+	sprites := model.PokemonSprites{
+		FrontDefault: "front.png",
+		BackShiny:    "back-shiny.png",
+		Other: model.PokemonOtherSprites{
+			OfficialArtwork: model.PokemonArtwork{FrontDefault: "artwork.png"},
+		},
+	}
+
+	urls := URLs(sprites)
+	expected := map[string]string{
+		FrontDefault:    "front.png",
+		BackShiny:       "back-shiny.png",
+		OfficialArtwork: "artwork.png",
+	}
+	if len(urls) != len(expected) {
+		t.Fatalf("expected %d urls, got %v", len(expected), urls)
+	}
+	for variant, url := range expected {
+		if urls[variant] != url {
+			t.Errorf("%s: expected %q, got %q", variant, url, urls[variant])
+		}
+	}
+}
+
+func TestValidateVariant(t *testing.T) {
+	for _, variant := range Variants {
+		if err := ValidateVariant(variant); err != nil {
+			t.Errorf("expected %s to be valid, got %v", variant, err)
+		}
+	}
+
+	if err := ValidateVariant("front_default"); !errors.As(err, &apierror.ValidationError{}) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestValidateFormVariant(t *testing.T) {
+	if err := ValidateFormVariant(FrontShiny); err != nil {
+		t.Errorf("expected front shiny to be valid, got %v", err)
+	}
+	if err := ValidateFormVariant(BackDefault); !errors.As(err, &apierror.ValidationError{}) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestProxyPath(t *testing.T) {
+	if path := ProxyPath(25, OfficialArtwork); path != "/api/v1/sprites/25/official-artwork" {
+		t.Errorf("unexpected path %q", path)
+	}
+	if path := FormProxyPath(201, "unown-b", FrontShiny); path != "/api/v1/sprites/201/front-shiny?form=unown-b" {
+		t.Errorf("unexpected form path %q", path)
+	}
+}
+
+func TestParseVariants(t *testing.T) {
+	variants, err := ParseVariants("")
+	if err != nil || !slices.Equal(variants, []string{FrontDefault}) {
+		t.Errorf("expected front default, got %v, %v", variants, err)
+	}
+
+	variants, err = ParseVariants("official-artwork, front-shiny,official-artwork")
+	if err != nil || !slices.Equal(variants, []string{OfficialArtwork, FrontShiny}) {
+		t.Errorf("expected deduplicated variants, got %v, %v", variants, err)
+	}
+
+	if _, err := ParseVariants("front-default,nope"); err == nil {
+		t.Error("expected error for unknown variant")
+	}
+}
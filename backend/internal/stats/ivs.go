@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+)
+
+// Validates the snapshots of an IV estimation, only the standard formula is supported
+func ValidateIVRangeRequest(req model.Iv_range_request) error {
+	if len(req.Snapshots) == 0 {
+		return apierror.Validationf("at least one snapshot is required")
+	}
+
+	for i, snapshot := range req.Snapshots {
+		if err := validateLevel(snapshot.Level); err != nil {
+			return apierror.Validationf("snapshot %d: %v", i+1, err)
+		}
+		if err := validateSpread("ev", snapshot.EVs, MaxEV); err != nil {
+			return apierror.Validationf("snapshot %d: %v", i+1, err)
+		}
+		if snapshot.EVs.Total() > MaxTotalEVs {
+			return apierror.Validationf("snapshot %d: ev total %d exceeds %d", i+1, snapshot.EVs.Total(), MaxTotalEVs)
+		}
+		for _, stat := range model.StatNames {
+			if snapshot.Stats.Get(stat) < 1 {
+				return apierror.Validationf("snapshot %d: %s must be at least 1", i+1, stat)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the lowest and highest IV of every stat producing the observed stats in all snapshots.
+// Returns an apierror.ValidationError when no IV matches a stat, which means the input is wrong
+func EstimateIVs(base model.Stat_spread, nature model.Nature_details, snapshots []model.Stat_snapshot) (model.Stat_spread, model.Stat_spread, error) {
+	var lowest, highest model.Stat_spread
+
+	for _, stat := range model.StatNames {
+		low, high := -1, -1
+
+		for iv := 0; iv <= MaxIV; iv++ {
+			if matchesSnapshots(stat, base, iv, nature, snapshots) {
+				if low == -1 {
+					low = iv
+				}
+				high = iv
+			}
+		}
+
+		if low == -1 {
+			return model.Stat_spread{}, model.Stat_spread{}, apierror.Validationf("no iv matches the observed %s", stat)
+		}
+
+		lowest.Set(stat, low)
+		highest.Set(stat, high)
+	}
+
+	return lowest, highest, nil
+}
+
+func matchesSnapshots(stat string, base model.Stat_spread, iv int, nature model.Nature_details, snapshots []model.Stat_snapshot) bool {
+	for _, snapshot := range snapshots {
+		value := Stat(stat, base.Get(stat), iv, snapshot.EVs.Get(stat), snapshot.Level, nature)
+
+		// Shedinja always has 1 HP whatever its IV
+		if stat == model.StatHP && base.HP == 1 {
+			value = 1
+		}
+
+		if value != snapshot.Stats.Get(stat) {
+			return false
+		}
+	}
+	return true
+}
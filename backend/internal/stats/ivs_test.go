@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"errors"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"testing"
+)
+
+var garchompIVs = model.Stat_spread{HP: 24, Attack: 12, Defense: 30, SpecialAttack: 16, SpecialDefense: 23, Speed: 5}
+var garchompEVs = model.Stat_spread{HP: 74, Attack: 190, Defense: 91, SpecialAttack: 48, SpecialDefense: 84, Speed: 23}
+
+// Stats of the Bulbapedia garchomp at level 78, and worked out by hand at level 100
+var garchompLevel78 = model.Stat_snapshot{
+	Level: 78,
+	EVs:   garchompEVs,
+	Stats: model.Stat_spread{HP: 289, Attack: 278, Defense: 193, SpecialAttack: 135, SpecialDefense: 171, Speed: 171},
+}
+var garchompLevel100 = model.Stat_snapshot{
+	Level: 100,
+	EVs:   garchompEVs,
+	Stats: model.Stat_spread{HP: 368, Attack: 356, Defense: 247, SpecialAttack: 173, SpecialDefense: 219, Speed: 219},
+}
+
+func TestEstimateIVs(t *testing.T) {
+	low, high, err := EstimateIVs(garchompBase, adamant, []model.Stat_snapshot{garchompLevel78})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expectedLow := model.Stat_spread{HP: 24, Attack: 11, Defense: 30, SpecialAttack: 14, SpecialDefense: 22, Speed: 4}
+	if low != expectedLow || high != garchompIVs {
+		t.Errorf("expected %+v to %+v, got %+v to %+v", expectedLow, garchompIVs, low, high)
+	}
+}
+
+func TestEstimateIVsNarrowsWithSnapshots(t *testing.T) {
+	snapshots := []model.Stat_snapshot{garchompLevel78, garchompLevel100}
+
+	low, high, err := EstimateIVs(garchompBase, adamant, snapshots)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, stat := range model.StatNames {
+		if low.Get(stat) != garchompIVs.Get(stat) || high.Get(stat) != garchompIVs.Get(stat) {
+			t.Errorf("expected %s iv %d, got %d to %d", stat, garchompIVs.Get(stat), low.Get(stat), high.Get(stat))
+		}
+	}
+}
+
+func TestEstimateIVsImpossibleStat(t *testing.T) {
+	snapshot := garchompLevel78
+	snapshot.Stats.Speed = 400
+
+	_, _, err := EstimateIVs(garchompBase, adamant, []model.Stat_snapshot{snapshot})
+	if !errors.As(err, &apierror.ValidationError{}) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestValidateIVRangeRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  model.Iv_range_request
+	}{
+		{"no snapshots", model.Iv_range_request{}},
+		{"level too low", model.Iv_range_request{Snapshots: []model.Stat_snapshot{{Level: 0, Stats: model.NewStatSpread(10)}}}},
+		{"ev too high", model.Iv_range_request{Snapshots: []model.Stat_snapshot{{Level: 50, EVs: model.Stat_spread{Speed: 253}, Stats: model.NewStatSpread(10)}}}},
+		{"missing stat", model.Iv_range_request{Snapshots: []model.Stat_snapshot{{Level: 50, Stats: model.Stat_spread{HP: 100}}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateIVRangeRequest(test.req)
+			if !errors.As(err, &apierror.ValidationError{}) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
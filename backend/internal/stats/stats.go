@@ -0,0 +1,172 @@
+package stats
+
+import (
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+)
+
+// Supported stat formulas
+const (
+	// Generation 3 onwards
+	FormulaStandard = "standard"
+	// Let's Go Pikachu/Eevee, awakening values replace EVs and friendship boosts stats
+	FormulaLetsGo = "lets-go"
+	// Pokemon Champions, stat points replace EVs and IVs are always 31 at level 50
+	FormulaChampions = "champions"
+)
+
+const (
+	MaxIV              = 31
+	MaxEV              = 252
+	MaxTotalEVs        = 510
+	MaxAV              = 200
+	MaxFriendship      = 255
+	MaxStatPoints      = 32
+	MaxTotalStatPoints = 66
+	ChampionsLevel     = 50
+)
+
+// Validates the request and fills in defaults: standard formula, IVs of 31 and level 50 for champions
+func ValidateRequest(req *model.Stat_calculation_request) error {
+	if req.Formula == "" {
+		req.Formula = FormulaStandard
+	}
+	if req.IVs == nil {
+		ivs := model.NewStatSpread(MaxIV)
+		req.IVs = &ivs
+	}
+
+	switch req.Formula {
+	case FormulaStandard:
+		if err := validateLevel(req.Level); err != nil {
+			return err
+		}
+		if err := validateSpread("iv", *req.IVs, MaxIV); err != nil {
+			return err
+		}
+		if err := validateSpread("ev", req.EVs, MaxEV); err != nil {
+			return err
+		}
+		if req.EVs.Total() > MaxTotalEVs {
+			return apierror.Validationf("ev total %d exceeds %d", req.EVs.Total(), MaxTotalEVs)
+		}
+	case FormulaLetsGo:
+		if err := validateLevel(req.Level); err != nil {
+			return err
+		}
+		if err := validateSpread("iv", *req.IVs, MaxIV); err != nil {
+			return err
+		}
+		if err := validateSpread("av", req.AVs, MaxAV); err != nil {
+			return err
+		}
+		if req.Friendship < 0 || req.Friendship > MaxFriendship {
+			return apierror.Validationf("friendship must be between 0 and %d", MaxFriendship)
+		}
+	case FormulaChampions:
+		if req.Level == 0 {
+			req.Level = ChampionsLevel
+		}
+		if req.Level != ChampionsLevel {
+			return apierror.Validationf("champions formula only supports level %d", ChampionsLevel)
+		}
+		if err := validateSpread("stat point", req.StatPoints, MaxStatPoints); err != nil {
+			return err
+		}
+		if req.StatPoints.Total() > MaxTotalStatPoints {
+			return apierror.Validationf("stat point total %d exceeds %d", req.StatPoints.Total(), MaxTotalStatPoints)
+		}
+	default:
+		return apierror.Validationf("unknown formula %s, expected one of %s, %s, %s", req.Formula, FormulaStandard, FormulaLetsGo, FormulaChampions)
+	}
+
+	return nil
+}
+
+func validateLevel(level int) error {
+	if level < 1 || level > 100 {
+		return apierror.Validationf("level must be between 1 and 100")
+	}
+	return nil
+}
+
+func validateSpread(name string, spread model.Stat_spread, max int) error {
+	for _, stat := range model.StatNames {
+		value := spread.Get(stat)
+		if value < 0 || value > max {
+			return apierror.Validationf("%s of %s must be between 0 and %d", name, stat, max)
+		}
+	}
+	return nil
+}
+
+// Calculates final stats of a validated request
+func Calculate(base model.Stat_spread, nature model.Nature_details, req model.Stat_calculation_request) model.Stat_spread {
+	var result model.Stat_spread
+
+	for _, stat := range model.StatNames {
+		var value int
+
+		switch req.Formula {
+		case FormulaLetsGo:
+			value = letsGoStat(stat, base.Get(stat), req.IVs.Get(stat), req.AVs.Get(stat), req.Level, req.Friendship, nature)
+		case FormulaChampions:
+			value = championsStat(stat, base.Get(stat), req.StatPoints.Get(stat), nature)
+		default:
+			value = Stat(stat, base.Get(stat), req.IVs.Get(stat), req.EVs.Get(stat), req.Level, nature)
+		}
+
+		// Shedinja always has 1 HP, it is the only pokemon with a base HP of 1
+		if stat == model.StatHP && base.HP == 1 {
+			value = 1
+		}
+
+		result.Set(stat, value)
+	}
+
+	return result
+}
+
+// Generation 3 onwards stat formula
+func Stat(stat string, base int, iv int, ev int, level int, nature model.Nature_details) int {
+	core := (2*base + iv + ev/4) * level / 100
+
+	if stat == model.StatHP {
+		return core + level + 10
+	}
+	return applyNature(core+5, stat, nature)
+}
+
+func letsGoStat(stat string, base int, iv int, av int, level int, friendship int, nature model.Nature_details) int {
+	core := (2*base + iv) * level / 100
+
+	if stat == model.StatHP {
+		return core + level + 10 + av
+	}
+
+	// Friendship boosts other stats by up to 10%
+	friendshipBonus := friendship * 10 / MaxFriendship
+	return applyNature(core+5, stat, nature)*(100+friendshipBonus)/100 + av
+}
+
+// Every stat point adds one point to the stat before nature at level 50
+func championsStat(stat string, base int, statPoints int, nature model.Nature_details) int {
+	if stat == model.StatHP {
+		return base + 75 + statPoints
+	}
+	return applyNature(base+20+statPoints, stat, nature)
+}
+
+// Nature boosts one stat by 10% and lowers another by 10%, rounding down
+func applyNature(value int, stat string, nature model.Nature_details) int {
+	if nature.IncreasedStat == nature.DecreasedStat {
+		return value
+	}
+	switch stat {
+	case nature.IncreasedStat:
+		return value * 110 / 100
+	case nature.DecreasedStat:
+		return value * 90 / 100
+	}
+	return value
+}
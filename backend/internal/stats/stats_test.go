@@ -0,0 +1,93 @@
+package stats
+
+import (
+	"errors"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"testing"
+)
+
+var adamant = model.Nature_details{Name: "adamant", IncreasedStat: model.StatAttack, DecreasedStat: model.StatSpecialAttack}
+
+// Garchomp example from Bulbapedia's stat article
+var garchompBase = model.Stat_spread{HP: 108, Attack: 130, Defense: 95, SpecialAttack: 80, SpecialDefense: 85, Speed: 102}
+
+func TestCalculateStandard(t *testing.T) {
+	ivs := model.Stat_spread{HP: 24, Attack: 12, Defense: 30, SpecialAttack: 16, SpecialDefense: 23, Speed: 5}
+	req := model.Stat_calculation_request{
+		Level:  78,
+		IVs:    &ivs,
+		EVs:    model.Stat_spread{HP: 74, Attack: 190, Defense: 91, SpecialAttack: 48, SpecialDefense: 84, Speed: 23},
+		Nature: "adamant",
+	}
+	if err := ValidateRequest(&req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := Calculate(garchompBase, adamant, req)
+
+	expected := model.Stat_spread{HP: 289, Attack: 278, Defense: 193, SpecialAttack: 135, SpecialDefense: 171, Speed: 171}
+	if result != expected {
+		t.Errorf("expected %+v, got %+v", expected, result)
+	}
+}
+
+func TestCalculateShedinja(t *testing.T) {
+	req := model.Stat_calculation_request{Level: 100}
+	if err := ValidateRequest(&req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := Calculate(model.Stat_spread{HP: 1, Attack: 90, Defense: 45, SpecialAttack: 30, SpecialDefense: 30, Speed: 40}, model.Nature_details{}, req)
+
+	if result.HP != 1 {
+		t.Errorf("expected 1 HP, got %d", result.HP)
+	}
+}
+
+func TestCalculateChampions(t *testing.T) {
+	req := model.Stat_calculation_request{
+		Formula:    FormulaChampions,
+		StatPoints: model.Stat_spread{HP: 2, Attack: 32, Speed: 32},
+	}
+	if err := ValidateRequest(&req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	result := Calculate(garchompBase, adamant, req)
+
+	if result.HP != 185 {
+		t.Errorf("expected 185 HP, got %d", result.HP)
+	}
+	if result.Attack != 200 {
+		t.Errorf("expected 200 attack, got %d", result.Attack)
+	}
+	if result.SpecialAttack != 90 {
+		t.Errorf("expected 90 special attack, got %d", result.SpecialAttack)
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  model.Stat_calculation_request
+	}{
+		{"level too high", model.Stat_calculation_request{Level: 101}},
+		{"iv too high", model.Stat_calculation_request{Level: 50, IVs: &model.Stat_spread{Speed: 32}}},
+		{"ev too high", model.Stat_calculation_request{Level: 50, EVs: model.Stat_spread{Attack: 253}}},
+		{"ev total too high", model.Stat_calculation_request{Level: 50, EVs: model.Stat_spread{HP: 252, Attack: 252, Speed: 8}}},
+		{"av too high", model.Stat_calculation_request{Formula: FormulaLetsGo, Level: 50, AVs: model.Stat_spread{HP: 201}}},
+		{"champions level", model.Stat_calculation_request{Formula: FormulaChampions, Level: 100}},
+		{"stat point total too high", model.Stat_calculation_request{Formula: FormulaChampions, StatPoints: model.Stat_spread{HP: 32, Attack: 32, Speed: 3}}},
+		{"unknown formula", model.Stat_calculation_request{Formula: "gen-2", Level: 50}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateRequest(&test.req)
+			if !errors.As(err, &apierror.ValidationError{}) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
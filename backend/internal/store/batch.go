@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+)
+
+// Lookups of many pokemon or moves in one query each, the ids and names are passed as a json array to json_each
+
+// Moves each pokemon can learn in any version group, keyed by pokemon id
+func (s *sqliteDatabase) GetPokemonsMoveNames(ctx context.Context, pokemonIDs []int) (map[int][]string, error) {
+	return s.queryNamesByPokemon(ctx, `
+	SELECT DISTINCT pokemon_id, move_name FROM pokemon_moves
+	WHERE pokemon_id IN (SELECT value FROM json_each(?))
+	ORDER BY pokemon_id, move_name
+	`, pokemonIDs)
+}
+
+func (s *sqliteDatabase) GetPokemonsAbilityNames(ctx context.Context, pokemonIDs []int) (map[int][]string, error) {
+	return s.queryNamesByPokemon(ctx, `
+	SELECT pokemon_id, ability_name FROM pokemon_ability
+	WHERE pokemon_id IN (SELECT value FROM json_each(?))
+	ORDER BY pokemon_id, ability_name
+	`, pokemonIDs)
+}
+
+// Species ids keyed by pokemon id, stored pokemon only
+func (s *sqliteDatabase) GetPokemonsSpeciesIDs(ctx context.Context, pokemonIDs []int) (map[int]int, error) {
+	idsJSON, err := json.Marshal(pokemonIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT pokemons.id, `+pokemonSpeciesColumn+` FROM pokemons WHERE pokemons.id IN (SELECT value FROM json_each(?))
+	`, string(idsJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	speciesIDs := map[int]int{}
+	for rows.Next() {
+		var pokemonID, speciesID int
+		if err := rows.Scan(&pokemonID, &speciesID); err != nil {
+			return nil, err
+		}
+		speciesIDs[pokemonID] = speciesID
+	}
+
+	return speciesIDs, rows.Err()
+}
+
+// Stored moves keyed by name, moves that were never fetched on their own are left out
+func (s *sqliteDatabase) GetMovesByName(ctx context.Context, names []string) (map[string]model.Move_details, error) {
+	namesJSON, err := json.Marshal(names)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT id, move_name, type, damage_class, target, COALESCE(power, 0), COALESCE(accuracy, 0), COALESCE(pp, 0), priority,
+	crit_rate, COALESCE(min_hits, 0), COALESCE(max_hits, 0), short_effect
+	FROM move_details
+	WHERE move_name IN (SELECT value FROM json_each(?))
+	`, string(namesJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	moves := map[string]model.Move_details{}
+	for rows.Next() {
+		var m model.Move_details
+		err := rows.Scan(
+			&m.ID, &m.Name, &m.Type, &m.DamageClass, &m.Target, &m.Power, &m.Accuracy, &m.PP, &m.Priority,
+			&m.CritRate, &m.MinHits, &m.MaxHits, &m.ShortEffect,
+		)
+		if err != nil {
+			return nil, err
+		}
+		moves[m.Name] = m
+	}
+
+	return moves, rows.Err()
+}
+
+// Runs a query selecting pokemon ids and names for the ids given as its only parameter
+func (s *sqliteDatabase) queryNamesByPokemon(ctx context.Context, query string, pokemonIDs []int) (map[int][]string, error) {
+	idsJSON, err := json.Marshal(pokemonIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, string(idsJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := map[int][]string{}
+	for rows.Next() {
+		var pokemonID int
+		var name string
+		if err := rows.Scan(&pokemonID, &name); err != nil {
+			return nil, err
+		}
+		names[pokemonID] = append(names[pokemonID], name)
+	}
+
+	return names, rows.Err()
+}
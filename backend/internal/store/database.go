@@ -9,8 +9,105 @@ type Database interface {
 	InitDB() error
 	Close() error
 	GetPokemon(ctx context.Context, name string) (model.Pokemon_summary, error)
+	GetPokemonByID(ctx context.Context, id int) (model.Pokemon_summary, error)
+	GetPokemonsByRefs(ctx context.Context, ids []int, names []string) ([]model.Pokemon_summary, error)
+	GetPokemonsMoveNames(ctx context.Context, pokemonIDs []int) (map[int][]string, error)
+	GetPokemonsAbilityNames(ctx context.Context, pokemonIDs []int) (map[int][]string, error)
+	GetPokemonsSpeciesIDs(ctx context.Context, pokemonIDs []int) (map[int]int, error)
+	GetMovesByName(ctx context.Context, names []string) (map[string]model.Move_details, error)
 	GetPokemons(ctx context.Context, offset int, limit int) ([]model.Pokemon_summary, error)
 	AddPokemon(ctx context.Context, pokemon model.Pokemon) error
+	HasPokemon(ctx context.Context, id int) (bool, error)
 	GetPokemonDetailed(ctx context.Context, id int) (model.Pokemon_details, error)
 	AddEvolutionChain(ctx context.Context, chain model.Evolution_chain) error
+	HasEvolutionDetails(ctx context.Context, speciesID int) (bool, error)
+	GetEvolutionChainID(ctx context.Context, pokemonID int) (int, error)
+	GetEvolutionTree(ctx context.Context, chainID int) (model.Evolution_tree, error)
+	GetPokemonSpeciesID(ctx context.Context, pokemonID int) (int, error)
+	AddPokemonFormNames(ctx context.Context, pokemonID int, forms []model.NamedResource) error
+	HasPokemonFormNames(ctx context.Context, pokemonID int) (bool, error)
+	GetPokemonFormNames(ctx context.Context, pokemonID int) ([]string, error)
+	AddSpecies(ctx context.Context, species model.PokemonSpecies, forms []model.PokemonForm) error
+	GetSpeciesVarieties(ctx context.Context, speciesID int) (model.Species_varieties, error)
+	GetSpeciesFlavorText(ctx context.Context, speciesID int, version string, language string) ([]model.Flavor_text_entry, error)
+	AddSpeciesFlavorText(ctx context.Context, speciesID int, flavorTexts []model.FlavorText) error
+	HasSpeciesFlavorText(ctx context.Context, speciesID int) (bool, error)
+	AddItem(ctx context.Context, item model.Item) error
+	GetItem(ctx context.Context, name string) (model.Item_details, error)
+	AddPokemonHeldItems(ctx context.Context, pokemonID int, heldItems []model.HeldItem) error
+	HasPokemonHeldItems(ctx context.Context, pokemonID int) (bool, error)
+	AddNatures(ctx context.Context, natures []model.Nature) error
+	GetNatures(ctx context.Context) ([]model.Nature_details, error)
+	AddCharacteristics(ctx context.Context, characteristics []model.Characteristic) error
+	GetCharacteristics(ctx context.Context) ([]model.Characteristic_details, error)
+	GetBaseStats(ctx context.Context, pokemonID int) (string, model.Stat_spread, error)
+	GetPokemonTypes(ctx context.Context, pokemonID int) ([]string, error)
+	AddMove(ctx context.Context, move model.Move) error
+	GetMove(ctx context.Context, name string) (model.Move_details, error)
+	AddTypes(ctx context.Context, types []model.Type) error
+	GetTypeChart(ctx context.Context) (model.Type_chart, error)
+	CreateTeam(ctx context.Context, team model.Team) (int, error)
+	UpdateTeam(ctx context.Context, id int, team model.Team) error
+	DeleteTeam(ctx context.Context, id int) error
+	GetTeam(ctx context.Context, id int) (model.Team, error)
+	GetTeams(ctx context.Context) ([]model.Team, error)
+	GetPokemonNames(ctx context.Context) ([]string, error)
+	GetItemNames(ctx context.Context) ([]string, error)
+	GetPokemonMoveNames(ctx context.Context, pokemonID int) ([]string, error)
+	GetPokemonAbilityNames(ctx context.Context, pokemonID int) ([]string, error)
+	AddPokemonEncounters(ctx context.Context, pokemonID int, encounters []model.LocationAreaEncounter) error
+	GetPokemonEncounters(ctx context.Context, pokemonID int, version string) (model.Pokemon_encounters, error)
+	AddLocations(ctx context.Context, locations []model.NamedResource) error
+	SearchLocations(ctx context.Context, search string, limit int) ([]model.Location_summary, error)
+	AddLocation(ctx context.Context, location model.Location) error
+	GetLocation(ctx context.Context, name string) (model.Location_details, error)
+	AddLocationArea(ctx context.Context, area model.LocationArea) error
+	GetLocationArea(ctx context.Context, name string, version string) (model.Location_area, error)
+	AddGenerations(ctx context.Context, generations []model.Generation) error
+	HasGenerations(ctx context.Context) (bool, error)
+	GetGenerationName(ctx context.Context, generation string) (string, error)
+	GetGenerationSpeciesIDs(ctx context.Context, generation string) ([]int, error)
+	GetGeneration(ctx context.Context, generation string) (model.Generation_details, error)
+	AddVersionGroups(ctx context.Context, versionGroups []model.VersionGroup) error
+	HasVersionGroups(ctx context.Context) (bool, error)
+	GetGames(ctx context.Context) ([]model.Game, error)
+	HasVersion(ctx context.Context, name string) (bool, error)
+	HasVersionGroup(ctx context.Context, name string) (bool, error)
+	AddPokemonGameIndices(ctx context.Context, pokemonID int, gameIndices []model.VersionGameIndex) error
+	HasPokemonGameIndices(ctx context.Context, pokemonID int) (bool, error)
+	CreateSave(ctx context.Context, save model.Save) (int, error)
+	GetSave(ctx context.Context, id int) (model.Save, error)
+	GetSaves(ctx context.Context) ([]model.Save, error)
+	DeleteSave(ctx context.Context, id int) error
+	SetSavePokemon(ctx context.Context, saveID int, speciesID int, status model.Save_pokemon_status) error
+	GetSaveEntries(ctx context.Context, saveID int) ([]model.Save_entry, error)
+	GetSaveEntry(ctx context.Context, saveID int, speciesID int) (model.Save_entry, error)
+	GetSaveCompletion(ctx context.Context, saveID int, generation string, pokedex string) ([]model.Completion, error)
+	GetObtainablePokemon(ctx context.Context, saveID int, version string, generation string) ([]model.Obtainable_pokemon, error)
+	AddPokedexes(ctx context.Context, pokedexes []model.Pokedex) error
+	HasPokedexes(ctx context.Context) (bool, error)
+	HasPokedex(ctx context.Context, name string) (bool, error)
+	GetPokedexes(ctx context.Context, versionGroup string) ([]model.Pokedex_summary, error)
+	GetPokedexEntries(ctx context.Context, name string, offset int, limit int) (model.Pokedex_entries, error)
+	HasLocalizedNames(ctx context.Context, resource string, name string) (bool, error)
+	AddSpeciesNames(ctx context.Context, species []model.PokemonSpecies) error
+	MarkSpeciesNamesSynced(ctx context.Context) error
+	HasSpeciesNamesSynced(ctx context.Context) (bool, error)
+	AddStats(ctx context.Context, stats []model.Stat) error
+	AddAbility(ctx context.Context, ability model.Ability) error
+	GetLocalizedNames(ctx context.Context, resource string, names []string, language string) (map[string]string, error)
+	GetLocalizedPokemonNames(ctx context.Context, pokemonIDs []int, language string) (map[string]string, error)
+	FindLocalizedName(ctx context.Context, resource string, localizedName string) (string, error)
+	AddPokemonSprites(ctx context.Context, pokemonID int, sprites model.PokemonSprites) error
+	HasPokemonSprites(ctx context.Context, pokemonID int) (bool, error)
+	GetPokemonSpriteURL(ctx context.Context, pokemonID int, variant string) (string, error)
+	GetPokemonFormSpriteURL(ctx context.Context, pokemonID int, form string, variant string) (string, error)
+	AddPokemonCries(ctx context.Context, pokemonID int, cries model.PokemonCries) error
+	HasPokemonCries(ctx context.Context, pokemonID int) (bool, error)
+	GetPokemonCryURL(ctx context.Context, pokemonID int, variant string) (string, error)
+	GetPokemonCryVariants(ctx context.Context, pokemonID int) ([]string, error)
+	GetCachedMedia(ctx context.Context, url string) (model.Cached_media, error)
+	AddCachedMedia(ctx context.Context, media model.Cached_media) error
+	GetDerivedMedia(ctx context.Context, sourceHash string, size int, format string) (model.Cached_media, error)
+	AddDerivedMedia(ctx context.Context, sourceHash string, size int, format string, media model.Cached_media) error
 }
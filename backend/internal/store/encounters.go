@@ -0,0 +1,164 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+	"sort"
+	"strconv"
+)
+
+func (s *sqliteDatabase) AddPokemonEncounters(ctx context.Context, pokemonID int, encounters []model.LocationAreaEncounter) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, area := range encounters {
+		if err := insertEncounters(ctx, tx, pokemonID, area.LocationArea.Name, area.VersionDetails); err != nil {
+			return err
+		}
+	}
+
+	if err := markFetched(ctx, tx, "pokemon-encounters", strconv.Itoa(pokemonID)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the encounters of the pokemon have not been fetched yet.
+// An empty version returns encounters of every version
+func (s *sqliteDatabase) GetPokemonEncounters(ctx context.Context, pokemonID int, version string) (model.Pokemon_encounters, error) {
+	query := `
+	SELECT
+	pokemons.id,
+	pokemons.name,
+	(
+		SELECT json_group_array(
+			json_object(
+				'location_area', grouped.location_area,
+				'encounters', json(grouped.encounters)
+			)
+		)
+		FROM (
+			SELECT location_area, json_group_array(
+				json_object(
+					'version', version,
+					'method', method,
+					'min_level', min_level,
+					'max_level', max_level,
+					'chance', chance,
+					'conditions', json(conditions)
+				)
+			) as encounters
+			FROM (
+				SELECT * FROM pokemon_encounters
+				WHERE pokemon_id = ? AND (? = '' OR version = ?)
+				ORDER BY version, method, min_level
+			)
+			GROUP BY location_area
+			ORDER BY location_area
+		) as grouped
+	) as locations
+	FROM pokemons
+	JOIN fetched_resources ON fetched_resources.resource = 'pokemon-encounters' AND fetched_resources.name = CAST(pokemons.id AS TEXT)
+	WHERE pokemons.id = ?
+	`
+
+	encounters := model.Pokemon_encounters{Version: version}
+	var locationsJSON []byte
+
+	err := s.db.QueryRowContext(ctx, query, pokemonID, version, version, pokemonID).Scan(
+		&encounters.PokemonID,
+		&encounters.PokemonName,
+		&locationsJSON,
+	)
+	if err == sql.ErrNoRows {
+		return model.Pokemon_encounters{}, sql.ErrNoRows
+	}
+	if err != nil {
+		return model.Pokemon_encounters{}, err
+	}
+
+	if err := json.Unmarshal(locationsJSON, &encounters.Locations); err != nil {
+		return model.Pokemon_encounters{}, err
+	}
+
+	return encounters, nil
+}
+
+// Inserts the encounters of one pokemon in one location area.
+// PokeAPI lists every encounter slot separately, so identical slots are merged and their chances summed
+func insertEncounters(ctx context.Context, tx *sql.Tx, pokemonID int, locationArea string, details []model.VersionEncounterDetail) error {
+	stmtLocationArea, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO location_areas (name) VALUES (?)`)
+	defer stmtLocationArea.Close()
+	stmtVersion, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO versions (name) VALUES (?)`)
+	defer stmtVersion.Close()
+	stmtMethod, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO encounter_methods (name) VALUES (?)`)
+	defer stmtMethod.Close()
+	stmtEncounter, _ := tx.PrepareContext(ctx, `
+	INSERT INTO pokemon_encounters (pokemon_id, location_area, version, method, min_level, max_level, conditions, chance)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT DO UPDATE SET chance = excluded.chance
+	`)
+	defer stmtEncounter.Close()
+
+	type encounterKey struct {
+		version    string
+		method     string
+		minLevel   int
+		maxLevel   int
+		conditions string
+	}
+	chances := make(map[encounterKey]int)
+	var keys []encounterKey
+
+	for _, versionDetail := range details {
+		for _, e := range versionDetail.EncounterDetails {
+			key := encounterKey{
+				version:    versionDetail.Version.Name,
+				method:     e.Method.Name,
+				minLevel:   e.MinLevel,
+				maxLevel:   e.MaxLevel,
+				conditions: conditionsJSON(e.ConditionValues),
+			}
+			if _, ok := chances[key]; !ok {
+				keys = append(keys, key)
+			}
+			chances[key] += e.Chance
+		}
+	}
+
+	if _, err := stmtLocationArea.ExecContext(ctx, locationArea); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if _, err := stmtVersion.ExecContext(ctx, key.version); err != nil {
+			return err
+		}
+		if _, err := stmtMethod.ExecContext(ctx, key.method); err != nil {
+			return err
+		}
+		if _, err := stmtEncounter.ExecContext(ctx, pokemonID, locationArea, key.version, key.method, key.minLevel, key.maxLevel, key.conditions, chances[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Helper function for storing encounter conditions in a stable order
+func conditionsJSON(conditions []model.NamedResource) string {
+	names := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		names = append(names, c.Name)
+	}
+	sort.Strings(names)
+
+	result, _ := json.Marshal(names)
+	return string(result)
+}
@@ -0,0 +1,164 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+)
+
+// Subquery returning the evolution details of an evolution_chains edge as a JSON array.
+// edge is the alias of the evolution_chains row in the outer query
+func evolutionDetailsQuery(edge string) string {
+	return `
+		SELECT json_group_array(
+			json_object(
+				'trigger', ed.trigger_name,
+				'item', ed.item,
+				'held_item', ed.held_item,
+				'gender', ed.gender,
+				'known_move', ed.known_move,
+				'known_move_type', ed.known_move_type,
+				'location', ed.location,
+				'min_level', ed.min_level,
+				'min_happiness', ed.min_happiness,
+				'min_beauty', ed.min_beauty,
+				'min_affection', ed.min_affection,
+				'needs_multiplayer', json(IIF(ed.needs_multiplayer, 'true', 'false')),
+				'needs_overworld_rain', json(IIF(ed.needs_overworld_rain, 'true', 'false')),
+				'party_species', ed.party_species,
+				'party_type', ed.party_type,
+				'relative_physical_stats', ed.relative_physical_stats,
+				'time_of_day', ed.time_of_day,
+				'trade_species', ed.trade_species,
+				'turn_upside_down', json(IIF(ed.turn_upside_down, 'true', 'false')),
+				'used_move', ed.used_move,
+				'min_move_count', ed.min_move_count,
+				'min_steps', ed.min_steps,
+				'min_damage_taken', ed.min_damage_taken
+			)
+		)
+		FROM (
+			SELECT * FROM evolution_details
+			WHERE evolution_details.pokemon_id = ` + edge + `.pokemon_id AND evolution_details.evolves_to_id = ` + edge + `.evolves_to_id
+			ORDER BY detail_index
+		) as ed`
+}
+
+// Returns sql.ErrNoRows if the evolution chain of the pokemon has not been fetched yet.
+// Chains consist of species, so forms like raichu-alola are looked up through their species
+func (s *sqliteDatabase) GetEvolutionChainID(ctx context.Context, pokemonID int) (int, error) {
+	var chainID int
+	err := s.db.QueryRowContext(ctx, `SELECT chain_id FROM evolution_chain_members WHERE pokemon_id = `+speciesIDQuery, pokemonID, pokemonID).Scan(&chainID)
+	return chainID, err
+}
+
+// Returns the evolution chain as a tree starting from its root pokemon, sql.ErrNoRows if the chain has not been fetched yet
+func (s *sqliteDatabase) GetEvolutionTree(ctx context.Context, chainID int) (model.Evolution_tree, error) {
+	tree := model.Evolution_tree{ChainID: chainID}
+
+	var babyTriggerItem sql.NullString
+	err := s.db.QueryRowContext(ctx, `SELECT baby_trigger_item FROM evolution_chain_info WHERE chain_id = ?`, chainID).Scan(&babyTriggerItem)
+	if err == sql.ErrNoRows {
+		return model.Evolution_tree{}, sql.ErrNoRows
+	}
+	if err != nil {
+		return model.Evolution_tree{}, err
+	}
+	tree.BabyTriggerItem = babyTriggerItem.String
+
+	membersQuery := `
+	SELECT pokemons.id, pokemons.name, pokemons.sprite_url, ecm.is_baby,
+	(
+		SELECT json_group_array(type_name)
+		FROM (SELECT type_name FROM pokemon_types WHERE pokemon_types.pokemon_id = pokemons.id ORDER BY slot)
+	) as types
+	FROM evolution_chain_members ecm
+	JOIN pokemons ON pokemons.id = ecm.pokemon_id
+	WHERE ecm.chain_id = ?
+	ORDER BY pokemons.id
+	`
+
+	rows, err := s.db.QueryContext(ctx, membersQuery, chainID)
+	if err != nil {
+		return model.Evolution_tree{}, err
+	}
+	defer rows.Close()
+
+	nodes := make(map[int]*model.Evolution_node)
+	var order []int
+
+	for rows.Next() {
+		var node model.Evolution_node
+		var typesJSON []byte
+		if err := rows.Scan(&node.PokemonID, &node.PokemonName, &node.SpriteUrl, &node.IsBaby, &typesJSON); err != nil {
+			return model.Evolution_tree{}, err
+		}
+		json.Unmarshal(typesJSON, &node.Types)
+		node.Conditions = []model.Evolution_condition{}
+		node.EvolvesTo = []model.Evolution_node{}
+
+		nodes[node.PokemonID] = &node
+		order = append(order, node.PokemonID)
+	}
+	if err := rows.Err(); err != nil {
+		return model.Evolution_tree{}, err
+	}
+
+	edgesQuery := `
+	SELECT ec.pokemon_id, ec.evolves_to_id, (` + evolutionDetailsQuery("ec") + `) as details
+	FROM evolution_chains ec
+	JOIN evolution_chain_members ecm ON ecm.pokemon_id = ec.pokemon_id
+	WHERE ecm.chain_id = ?
+	ORDER BY ec.evolves_to_id
+	`
+
+	edgeRows, err := s.db.QueryContext(ctx, edgesQuery, chainID)
+	if err != nil {
+		return model.Evolution_tree{}, err
+	}
+	defer edgeRows.Close()
+
+	children := make(map[int][]int)
+	hasParent := make(map[int]bool)
+
+	for edgeRows.Next() {
+		var fromID, toID int
+		var detailsJSON []byte
+		if err := edgeRows.Scan(&fromID, &toID, &detailsJSON); err != nil {
+			return model.Evolution_tree{}, err
+		}
+
+		node, ok := nodes[toID]
+		if !ok {
+			continue
+		}
+		json.Unmarshal(detailsJSON, &node.Conditions)
+		node.Summary = model.EvolutionSummary(node.Conditions)
+
+		children[fromID] = append(children[fromID], toID)
+		hasParent[toID] = true
+	}
+	if err := edgeRows.Err(); err != nil {
+		return model.Evolution_tree{}, err
+	}
+
+	// Children are attached depth first so that every node is complete before it is copied into its parent
+	var build func(id int) model.Evolution_node
+	build = func(id int) model.Evolution_node {
+		node := *nodes[id]
+		for _, childID := range children[id] {
+			node.EvolvesTo = append(node.EvolvesTo, build(childID))
+		}
+		return node
+	}
+
+	for _, id := range order {
+		if !hasParent[id] {
+			tree.Root = build(id)
+			break
+		}
+	}
+
+	return tree, nil
+}
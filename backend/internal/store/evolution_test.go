@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+	"testing"
+)
+
+func chainLink(id int, name string, details []model.EvolutionDetail, evolvesTo ...model.ChainLink) model.ChainLink {
+	return model.ChainLink{
+		Species:          resource("pokemon-species", id, name),
+		EvolutionDetails: details,
+		EvolvesTo:        evolvesTo,
+	}
+}
+
+func useItem(item string) []model.EvolutionDetail {
+	return []model.EvolutionDetail{{Trigger: model.NamedResource{Name: "use-item"}, Item: &model.NamedResource{Name: item}}}
+}
+
+func levelUpWithHappiness(happiness int, timeOfDay string) []model.EvolutionDetail {
+	return []model.EvolutionDetail{{Trigger: model.NamedResource{Name: "level-up"}, MinHappiness: &happiness, TimeOfDay: timeOfDay}}
+}
+
+func names(nodes []model.Evolution_node) []string {
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.PokemonName)
+	}
+	return names
+}
+
+func TestGetEvolutionTreeBranches(t *testing.T) {
+	s := newTestDatabase(t)
+	ctx := context.Background()
+
+	addTestPokemon(t, s, 133, "eevee", "normal")
+	addTestPokemon(t, s, 134, "vaporeon", "water")
+	addTestPokemon(t, s, 135, "jolteon", "electric")
+	addTestPokemon(t, s, 136, "flareon", "fire")
+	addTestPokemon(t, s, 196, "espeon", "psychic")
+
+	chain := model.Evolution_chain{
+		Id: 67,
+		Chain: chainLink(133, "eevee", nil,
+			chainLink(134, "vaporeon", useItem("water-stone")),
+			chainLink(135, "jolteon", useItem("thunder-stone")),
+			chainLink(136, "flareon", useItem("fire-stone")),
+			chainLink(196, "espeon", levelUpWithHappiness(160, "day")),
+		),
+	}
+	if err := s.AddEvolutionChain(ctx, chain); err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := s.GetEvolutionTree(ctx, 67)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := tree.Root
+	if root.PokemonName != "eevee" || len(root.Conditions) != 0 {
+		t.Fatalf("root = %s with %d conditions, want eevee without conditions", root.PokemonName, len(root.Conditions))
+	}
+	want := []string{"vaporeon", "jolteon", "flareon", "espeon"}
+	if got := names(root.EvolvesTo); !slices.Equal(got, want) {
+		t.Fatalf("eevee evolves to %v, want %v", got, want)
+	}
+
+	vaporeon := root.EvolvesTo[0]
+	if len(vaporeon.Conditions) != 1 || vaporeon.Conditions[0].Trigger != "use-item" || vaporeon.Conditions[0].Item != "water-stone" {
+		t.Errorf("vaporeon conditions = %+v, want use-item water-stone", vaporeon.Conditions)
+	}
+	if len(vaporeon.Types) != 1 || vaporeon.Types[0] != "water" {
+		t.Errorf("vaporeon types = %v, want [water]", vaporeon.Types)
+	}
+
+	espeon := root.EvolvesTo[3]
+	if len(espeon.Conditions) != 1 {
+		t.Fatalf("espeon has %d conditions, want 1", len(espeon.Conditions))
+	}
+	condition := espeon.Conditions[0]
+	if condition.MinHappiness == nil || *condition.MinHappiness != 160 || condition.TimeOfDay != "day" || condition.Item != "" {
+		t.Errorf("espeon condition = %+v, want happiness 160 during the day", condition)
+	}
+	for _, branch := range root.EvolvesTo {
+		if len(branch.EvolvesTo) != 0 {
+			t.Errorf("%s evolves further into %v", branch.PokemonName, names(branch.EvolvesTo))
+		}
+	}
+}
+
+func TestGetEvolutionTreeMultiStage(t *testing.T) {
+	s := newTestDatabase(t)
+	ctx := context.Background()
+
+	// The root has the highest id, so it can't be found by ordering alone
+	addTestPokemon(t, s, 25, "pikachu", "electric")
+	addTestPokemon(t, s, 26, "raichu", "electric")
+	addTestPokemon(t, s, 172, "pichu", "electric")
+
+	pichu := chainLink(172, "pichu", nil,
+		chainLink(25, "pikachu", levelUpWithHappiness(220, ""),
+			chainLink(26, "raichu", useItem("thunder-stone")),
+		),
+	)
+	pichu.IsBaby = true
+	if err := s.AddEvolutionChain(ctx, model.Evolution_chain{Id: 10, Chain: pichu}); err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := s.GetEvolutionTree(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := tree.Root
+	if root.PokemonName != "pichu" || !root.IsBaby {
+		t.Fatalf("root = %s (baby %v), want baby pichu", root.PokemonName, root.IsBaby)
+	}
+	if len(root.EvolvesTo) != 1 || root.EvolvesTo[0].PokemonName != "pikachu" {
+		t.Fatalf("pichu evolves to %v, want [pikachu]", names(root.EvolvesTo))
+	}
+	pikachu := root.EvolvesTo[0]
+	if pikachu.IsBaby || pikachu.Conditions[0].MinHappiness == nil || *pikachu.Conditions[0].MinHappiness != 220 {
+		t.Errorf("pikachu = %+v, want happiness 220 and not a baby", pikachu)
+	}
+	if len(pikachu.EvolvesTo) != 1 || pikachu.EvolvesTo[0].PokemonName != "raichu" {
+		t.Fatalf("pikachu evolves to %v, want [raichu]", names(pikachu.EvolvesTo))
+	}
+	raichu := pikachu.EvolvesTo[0]
+	if raichu.Conditions[0].Item != "thunder-stone" || len(raichu.EvolvesTo) != 0 {
+		t.Errorf("raichu = %+v, want thunder-stone and no further evolutions", raichu)
+	}
+
+	chainID, err := s.GetEvolutionChainID(ctx, 26)
+	if err != nil || chainID != 10 {
+		t.Errorf("GetEvolutionChainID(raichu) = %d, %v, want 10", chainID, err)
+	}
+	for _, id := range []int{25, 26, 172} {
+		if fetched, err := s.HasEvolutionDetails(ctx, id); err != nil || !fetched {
+			t.Errorf("HasEvolutionDetails(%d) = %v, %v, want true", id, fetched, err)
+		}
+	}
+}
+
+func TestGetEvolutionTreeUnknownChain(t *testing.T) {
+	s := newTestDatabase(t)
+
+	if _, err := s.GetEvolutionTree(context.Background(), 1); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetEvolutionTree of an unknown chain = %v, want sql.ErrNoRows", err)
+	}
+	if fetched, err := s.HasEvolutionDetails(context.Background(), 1); err != nil || fetched {
+		t.Errorf("HasEvolutionDetails of an unknown chain = %v, %v, want false", fetched, err)
+	}
+}
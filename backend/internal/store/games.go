@@ -0,0 +1,310 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+	"strconv"
+)
+
+func (s *sqliteDatabase) AddGenerations(ctx context.Context, generations []model.Generation) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmtGeneration, _ := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO generations (id, name, main_region) VALUES (?, ?, ?)`)
+	defer stmtGeneration.Close()
+	stmtSpecies, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO generation_species (generation_id, species_id, species_name) VALUES (?, ?, ?)`)
+	defer stmtSpecies.Close()
+	stmtMove, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO moves (name) VALUES (?)`)
+	defer stmtMove.Close()
+	stmtGenerationMove, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO generation_moves (generation_id, move_name) VALUES (?, ?)`)
+	defer stmtGenerationMove.Close()
+	stmtAbility, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO abilities (name) VALUES (?)`)
+	defer stmtAbility.Close()
+	stmtGenerationAbility, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO generation_abilities (generation_id, ability_name) VALUES (?, ?)`)
+	defer stmtGenerationAbility.Close()
+	stmtType, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO types (name) VALUES (?)`)
+	defer stmtType.Close()
+	stmtGenerationType, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO generation_types (generation_id, type_name) VALUES (?, ?)`)
+	defer stmtGenerationType.Close()
+
+	for _, g := range generations {
+		if _, err := stmtGeneration.ExecContext(ctx, g.ID, g.Name, nullString(g.MainRegion.Name)); err != nil {
+			return err
+		}
+		for _, species := range g.PokemonSpecies {
+			if _, err := stmtSpecies.ExecContext(ctx, g.ID, species.ID(), species.Name); err != nil {
+				return err
+			}
+		}
+		for _, move := range g.Moves {
+			if _, err := stmtMove.ExecContext(ctx, move.Name); err != nil {
+				return err
+			}
+			if _, err := stmtGenerationMove.ExecContext(ctx, g.ID, move.Name); err != nil {
+				return err
+			}
+		}
+		for _, ability := range g.Abilities {
+			if _, err := stmtAbility.ExecContext(ctx, ability.Name); err != nil {
+				return err
+			}
+			if _, err := stmtGenerationAbility.ExecContext(ctx, g.ID, ability.Name); err != nil {
+				return err
+			}
+		}
+		for _, t := range g.Types {
+			if _, err := stmtType.ExecContext(ctx, t.Name); err != nil {
+				return err
+			}
+			if _, err := stmtGenerationType.ExecContext(ctx, g.ID, t.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := markFetched(ctx, tx, "generation-list", "all"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Whether the generations and what they introduced have been fetched from pokeapi
+func (s *sqliteDatabase) HasGenerations(ctx context.Context) (bool, error) {
+	return s.isFetched(ctx, "generation-list", "all")
+}
+
+// Accepts the id or the name of the generation, returns sql.ErrNoRows if it doesn't exist
+func (s *sqliteDatabase) GetGenerationName(ctx context.Context, generation string) (string, error) {
+	var name string
+	err := s.db.QueryRowContext(ctx, `SELECT name FROM generations WHERE name = ? OR CAST(id AS TEXT) = ?`, generation, generation).Scan(&name)
+	return name, err
+}
+
+// Species ids of the generation ordered by national dex number
+func (s *sqliteDatabase) GetGenerationSpeciesIDs(ctx context.Context, generation string) ([]int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT species_id FROM generation_species
+	JOIN generations ON generations.id = generation_species.generation_id
+	WHERE generations.name = ?
+	ORDER BY species_id
+	`, generation)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// Accepts the id or the name of the generation, returns sql.ErrNoRows if it doesn't exist
+func (s *sqliteDatabase) GetGeneration(ctx context.Context, generation string) (model.Generation_details, error) {
+	query := `
+	SELECT generations.id, generations.name, COALESCE(generations.main_region, ''),
+	(
+		SELECT json_group_array(version_group)
+		FROM (SELECT version_group FROM version_group_info WHERE generation_id = generations.id ORDER BY sort_order)
+	),
+	(
+		SELECT json_group_array(species_name)
+		FROM (SELECT species_name FROM generation_species WHERE generation_id = generations.id ORDER BY species_id)
+	),
+	(
+		SELECT json_group_array(move_name)
+		FROM (SELECT move_name FROM generation_moves WHERE generation_id = generations.id ORDER BY move_name)
+	),
+	(
+		SELECT json_group_array(ability_name)
+		FROM (SELECT ability_name FROM generation_abilities WHERE generation_id = generations.id ORDER BY ability_name)
+	),
+	(
+		SELECT json_group_array(type_name)
+		FROM (SELECT type_name FROM generation_types WHERE generation_id = generations.id ORDER BY type_name)
+	)
+	FROM generations
+	WHERE generations.name = ? OR CAST(generations.id AS TEXT) = ?
+	`
+
+	var g model.Generation_details
+	var versionGroupsJSON, speciesJSON, movesJSON, abilitiesJSON, typesJSON []byte
+
+	err := s.db.QueryRowContext(ctx, query, generation, generation).Scan(
+		&g.ID,
+		&g.Name,
+		&g.MainRegion,
+		&versionGroupsJSON,
+		&speciesJSON,
+		&movesJSON,
+		&abilitiesJSON,
+		&typesJSON,
+	)
+	if err != nil {
+		return model.Generation_details{}, err
+	}
+
+	for target, data := range map[*[]string][]byte{
+		&g.VersionGroups:  versionGroupsJSON,
+		&g.PokemonSpecies: speciesJSON,
+		&g.Moves:          movesJSON,
+		&g.Abilities:      abilitiesJSON,
+		&g.Types:          typesJSON,
+	} {
+		if err := json.Unmarshal(data, target); err != nil {
+			return model.Generation_details{}, err
+		}
+	}
+
+	return g, nil
+}
+
+// The generations of the version groups must already exist in the database
+func (s *sqliteDatabase) AddVersionGroups(ctx context.Context, versionGroups []model.VersionGroup) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmtVersionGroup, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO version_groups (version_name) VALUES (?)`)
+	defer stmtVersionGroup.Close()
+	stmtVersionGroupInfo, _ := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO version_group_info (version_group, id, sort_order, generation_id) VALUES (?, ?, ?, ?)`)
+	defer stmtVersionGroupInfo.Close()
+	stmtVersion, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO versions (name) VALUES (?)`)
+	defer stmtVersion.Close()
+	stmtVersionInfo, _ := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO version_info (version, id, version_group) VALUES (?, ?, ?)`)
+	defer stmtVersionInfo.Close()
+
+	for _, vg := range versionGroups {
+		if _, err := stmtVersionGroup.ExecContext(ctx, vg.Name); err != nil {
+			return err
+		}
+		if _, err := stmtVersionGroupInfo.ExecContext(ctx, vg.Name, vg.ID, vg.Order, vg.Generation.ID()); err != nil {
+			return err
+		}
+
+		for _, version := range vg.Versions {
+			if _, err := stmtVersion.ExecContext(ctx, version.Name); err != nil {
+				return err
+			}
+			if _, err := stmtVersionInfo.ExecContext(ctx, version.Name, version.ID(), vg.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := markFetched(ctx, tx, "version-group-list", "all"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Whether the version groups and their versions have been fetched from pokeapi
+func (s *sqliteDatabase) HasVersionGroups(ctx context.Context) (bool, error) {
+	return s.isFetched(ctx, "version-group-list", "all")
+}
+
+func (s *sqliteDatabase) GetGames(ctx context.Context) ([]model.Game, error) {
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT vg.version_group, vg.sort_order, generations.name,
+	(
+		SELECT json_group_array(version)
+		FROM (SELECT version FROM version_info WHERE version_group = vg.version_group ORDER BY id)
+	),
+	(
+		SELECT json_group_array(pokedex_name)
+		FROM (SELECT pokedex_name FROM pokedex_version_groups WHERE version_group = vg.version_group ORDER BY pokedex_name)
+	)
+	FROM version_group_info AS vg
+	JOIN generations ON generations.id = vg.generation_id
+	ORDER BY vg.sort_order
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	games := []model.Game{}
+	for rows.Next() {
+		var game model.Game
+		var versionsJSON, pokedexesJSON []byte
+		if err := rows.Scan(&game.VersionGroup, &game.Order, &game.Generation, &versionsJSON, &pokedexesJSON); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(versionsJSON, &game.Versions); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(pokedexesJSON, &game.Pokedexes); err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+
+	return games, rows.Err()
+}
+
+// Whether the version is known from the version groups, versions seen in encounters alone don't count
+func (s *sqliteDatabase) HasVersion(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM version_info WHERE version = ?)`, name).Scan(&exists)
+	return exists, err
+}
+
+// Whether the version group is known from pokeapi, version groups seen in learnsets alone don't count
+func (s *sqliteDatabase) HasVersionGroup(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM version_group_info WHERE version_group = ?)`, name).Scan(&exists)
+	return exists, err
+}
+
+// Stores the index of the pokemon in the games of every version and marks them as fetched
+func insertPokemonGameIndices(ctx context.Context, tx *sql.Tx, pokemonID int, gameIndices []model.VersionGameIndex) error {
+	stmtVersion, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO versions (name) VALUES (?)`)
+	defer stmtVersion.Close()
+	stmtGameIndex, _ := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO pokemon_game_indices (pokemon_id, version, game_index) VALUES (?, ?, ?)`)
+	defer stmtGameIndex.Close()
+
+	for _, g := range gameIndices {
+		if _, err := stmtVersion.ExecContext(ctx, g.Version.Name); err != nil {
+			return err
+		}
+		if _, err := stmtGameIndex.ExecContext(ctx, pokemonID, g.Version.Name, g.GameIndex); err != nil {
+			return err
+		}
+	}
+
+	return markFetched(ctx, tx, "pokemon-game-indices", strconv.Itoa(pokemonID))
+}
+
+// Stores the game indices of a pokemon added before its game indices were kept, the pokemon must already exist in the database
+func (s *sqliteDatabase) AddPokemonGameIndices(ctx context.Context, pokemonID int, gameIndices []model.VersionGameIndex) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertPokemonGameIndices(ctx, tx, pokemonID, gameIndices); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteDatabase) HasPokemonGameIndices(ctx context.Context, pokemonID int) (bool, error) {
+	return s.isFetched(ctx, "pokemon-game-indices", strconv.Itoa(pokemonID))
+}
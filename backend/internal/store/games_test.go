@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"poke-atlas/web-service/internal/model"
+	"strconv"
+	"testing"
+)
+
+func TestGetPokemonDetailedGameIndices(t *testing.T) {
+	s := newTestDatabase(t)
+	ctx := context.Background()
+
+	err := s.AddGenerations(ctx, []model.Generation{{ID: 1, Name: "generation-i"}, {ID: 2, Name: "generation-ii"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.AddVersionGroups(ctx, []model.VersionGroup{
+		{ID: 1, Name: "red-blue", Order: 1, Generation: resource("generation", 1, "generation-i"), Versions: []model.NamedResource{resource("version", 1, "red"), resource("version", 2, "blue")}},
+		{ID: 3, Name: "gold-silver", Order: 3, Generation: resource("generation", 2, "generation-ii"), Versions: []model.NamedResource{resource("version", 4, "gold"), resource("version", 5, "silver")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Stored out of release order
+	err = s.AddPokemon(ctx, model.Pokemon{ID: 25, Name: "pikachu", GameIndices: []model.VersionGameIndex{
+		{GameIndex: 25, Version: resource("version", 4, "gold")},
+		{GameIndex: 84, Version: resource("version", 2, "blue")},
+		{GameIndex: 84, Version: resource("version", 1, "red")},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pokemon, err := s.GetPokemonDetailed(ctx, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"red 84", "blue 84", "gold 25"}
+	if len(pokemon.GameIndices) != len(expected) {
+		t.Fatalf("expected %v, got %+v", expected, pokemon.GameIndices)
+	}
+	for i, g := range pokemon.GameIndices {
+		if got := g.Version + " " + strconv.Itoa(g.GameIndex); got != expected[i] {
+			t.Errorf("game index %d: expected %s, got %s", i, expected[i], got)
+		}
+	}
+
+	fetched, err := s.HasPokemonGameIndices(ctx, 25)
+	if err != nil || !fetched {
+		t.Errorf("expected game indices to be marked fetched, got %v %v", fetched, err)
+	}
+}
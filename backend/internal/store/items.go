@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+	"strconv"
+)
+
+// Adds the item with the pokemon holding it. The holder pokemon must already exist in the database
+func (s *sqliteDatabase) AddItem(ctx context.Context, item model.Item) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Effects are stored in english only
+	var effect, shortEffect string
+	for _, e := range item.EffectEntries {
+		if e.Language.Name == "en" {
+			effect, shortEffect = e.Effect, e.ShortEffect
+			break
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO items (name, id, category, cost, fling_power, fling_effect, effect, short_effect, sprite_url)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (name) DO UPDATE SET
+		id = excluded.id,
+		category = excluded.category,
+		cost = excluded.cost,
+		fling_power = excluded.fling_power,
+		fling_effect = excluded.fling_effect,
+		effect = excluded.effect,
+		short_effect = excluded.short_effect,
+		sprite_url = excluded.sprite_url
+	`, item.Name, item.ID, item.Category.Name, item.Cost, item.FlingPower, resourceName(item.FlingEffect), effect, shortEffect, item.Sprites.Default)
+	if err != nil {
+		return err
+	}
+
+	stmtVersion, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO versions (name) VALUES (?)`)
+	defer stmtVersion.Close()
+	stmtPokemonHeldItem, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO pokemon_held_items (pokemon_id, item_name, version, rarity) VALUES (?, ?, ?, ?)`)
+	defer stmtPokemonHeldItem.Close()
+
+	for _, h := range item.HeldByPokemon {
+		for _, v := range h.VersionDetails {
+			if _, err := stmtVersion.ExecContext(ctx, v.Version.Name); err != nil {
+				return err
+			}
+			if _, err := stmtPokemonHeldItem.ExecContext(ctx, h.Pokemon.ID(), item.Name, v.Version.Name, v.Rarity); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := markFetched(ctx, tx, "item", item.Name); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the item has not been fetched yet
+func (s *sqliteDatabase) GetItem(ctx context.Context, name string) (model.Item_details, error) {
+	query := `
+	SELECT items.id, items.name, items.category, items.cost, items.fling_power, COALESCE(items.fling_effect, ''),
+	items.effect, items.short_effect, items.sprite_url,
+	(
+		SELECT json_group_array(json_object('version', by_version.version, 'pokemon', json(by_version.pokemon)))
+		FROM (
+			SELECT held.version, json_group_array(
+				json_object(
+					'pokemon_id', pokemons.id,
+					'pokemon_name', pokemons.name,
+					'sprite_url', pokemons.sprite_url,
+					'rarity', held.rarity
+				)
+			) as pokemon
+			FROM (SELECT * FROM pokemon_held_items WHERE item_name = ? ORDER BY version, rarity DESC, pokemon_id) as held
+			JOIN pokemons ON pokemons.id = held.pokemon_id
+			GROUP BY held.version
+			ORDER BY held.version
+		) as by_version
+	) as held_by
+	FROM items
+	JOIN fetched_resources ON fetched_resources.resource = 'item' AND fetched_resources.name = items.name
+	WHERE items.name = ?
+	`
+
+	var item model.Item_details
+	var heldByJSON []byte
+
+	err := s.db.QueryRowContext(ctx, query, name, name).Scan(
+		&item.ID,
+		&item.Name,
+		&item.Category,
+		&item.Cost,
+		&item.FlingPower,
+		&item.FlingEffect,
+		&item.Effect,
+		&item.ShortEffect,
+		&item.SpriteUrl,
+		&heldByJSON,
+	)
+	if err == sql.ErrNoRows {
+		return model.Item_details{}, sql.ErrNoRows
+	}
+	if err != nil {
+		return model.Item_details{}, err
+	}
+
+	if err := json.Unmarshal(heldByJSON, &item.HeldBy); err != nil {
+		return model.Item_details{}, err
+	}
+
+	return item, nil
+}
+
+// Stores the items the pokemon may hold in the wild and marks them as fetched
+func insertPokemonHeldItems(ctx context.Context, tx *sql.Tx, pokemonID int, heldItems []model.HeldItem) error {
+	stmtItem, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO items (name, id) VALUES (?, ?)`)
+	defer stmtItem.Close()
+	stmtVersion, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO versions (name) VALUES (?)`)
+	defer stmtVersion.Close()
+	stmtPokemonHeldItem, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO pokemon_held_items (pokemon_id, item_name, version, rarity) VALUES (?, ?, ?, ?)`)
+	defer stmtPokemonHeldItem.Close()
+
+	for _, h := range heldItems {
+		if _, err := stmtItem.ExecContext(ctx, h.Item.Name, h.Item.ID()); err != nil {
+			return err
+		}
+
+		for _, v := range h.VersionDetails {
+			if _, err := stmtVersion.ExecContext(ctx, v.Version.Name); err != nil {
+				return err
+			}
+			if _, err := stmtPokemonHeldItem.ExecContext(ctx, pokemonID, h.Item.Name, v.Version.Name, v.Rarity); err != nil {
+				return err
+			}
+		}
+	}
+
+	return markFetched(ctx, tx, "pokemon-held-items", strconv.Itoa(pokemonID))
+}
+
+// Stores the held items of a pokemon added before its held items were kept, the pokemon must already exist in the database
+func (s *sqliteDatabase) AddPokemonHeldItems(ctx context.Context, pokemonID int, heldItems []model.HeldItem) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertPokemonHeldItems(ctx, tx, pokemonID, heldItems); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteDatabase) HasPokemonHeldItems(ctx context.Context, pokemonID int) (bool, error) {
+	return s.isFetched(ctx, "pokemon-held-items", strconv.Itoa(pokemonID))
+}
@@ -0,0 +1,165 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"poke-atlas/web-service/internal/localization"
+	"poke-atlas/web-service/internal/model"
+	"strconv"
+)
+
+// Stores the names of the resource in every language and marks them as fetched,
+// resources without names in pokeapi are marked too so they aren't fetched again
+func insertLocalizedNames(ctx context.Context, tx *sql.Tx, resource string, name string, names []model.Name) error {
+	stmt, err := tx.PrepareContext(ctx, `
+	INSERT OR REPLACE INTO localized_names (resource, name, language, localized_name, search_name) VALUES (?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, n := range names {
+		if _, err := stmt.ExecContext(ctx, resource, name, n.Language.Name, n.Name, localization.SearchName(n.Name)); err != nil {
+			return err
+		}
+	}
+
+	return markFetched(ctx, tx, resource+"-names", name)
+}
+
+// Whether the names of the resource have been fetched, e.g. ("move", "thunderbolt") or ("pokemon-species", "25")
+func (s *sqliteDatabase) HasLocalizedNames(ctx context.Context, resource string, name string) (bool, error) {
+	return s.isFetched(ctx, resource+"-names", name)
+}
+
+// Stores only the names of the species, the full species are added with AddSpecies
+func (s *sqliteDatabase) AddSpeciesNames(ctx context.Context, species []model.PokemonSpecies) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, sp := range species {
+		if err := insertLocalizedNames(ctx, tx, "pokemon-species", strconv.Itoa(sp.ID), sp.Names); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Marks the names of every species as stored, so a restart doesn't sync them again
+func (s *sqliteDatabase) MarkSpeciesNamesSynced(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := markFetched(ctx, tx, "species-names-sync", "national"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteDatabase) HasSpeciesNamesSynced(ctx context.Context) (bool, error) {
+	return s.isFetched(ctx, "species-names-sync", "national")
+}
+
+func (s *sqliteDatabase) AddStats(ctx context.Context, stats []model.Stat) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmtStat, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO stats (name) VALUES (?)`)
+	defer stmtStat.Close()
+
+	for _, stat := range stats {
+		if _, err := stmtStat.ExecContext(ctx, stat.Name); err != nil {
+			return err
+		}
+		if err := insertLocalizedNames(ctx, tx, "stat", stat.Name, stat.Names); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteDatabase) AddAbility(ctx context.Context, ability model.Ability) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO abilities (name) VALUES (?)`, ability.Name); err != nil {
+		return err
+	}
+	if err := insertLocalizedNames(ctx, tx, "ability", ability.Name, ability.Names); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Localized names of the resources keyed by their name, names without one in the language are left out
+func (s *sqliteDatabase) GetLocalizedNames(ctx context.Context, resource string, names []string, language string) (map[string]string, error) {
+	localized := map[string]string{}
+	for _, name := range names {
+		var localizedName string
+		err := s.db.QueryRowContext(ctx, `
+		SELECT localized_name FROM localized_names WHERE resource = ? AND name = ? AND language = ?
+		`, resource, name, language).Scan(&localizedName)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		localized[name] = localizedName
+	}
+
+	return localized, nil
+}
+
+// Localized names of the pokemon keyed by pokemon name. Pokemon are named after their species,
+// varieties that aren't the default of their species are left out as the name wouldn't tell them apart
+func (s *sqliteDatabase) GetLocalizedPokemonNames(ctx context.Context, pokemonIDs []int, language string) (map[string]string, error) {
+	localized := map[string]string{}
+	for _, id := range pokemonIDs {
+		var name, localizedName string
+		err := s.db.QueryRowContext(ctx, `
+		SELECT pokemons.name, localized_names.localized_name
+		FROM pokemons
+		JOIN localized_names ON localized_names.resource = 'pokemon-species'
+			AND localized_names.name = CAST(`+pokemonSpeciesColumn+` AS TEXT)
+			AND localized_names.language = ?
+		WHERE pokemons.id = ?
+		AND COALESCE((SELECT is_default FROM pokemon_varieties WHERE pokemon_varieties.pokemon_id = pokemons.id), 1)
+		`, language, id).Scan(&name, &localizedName)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		localized[name] = localizedName
+	}
+
+	return localized, nil
+}
+
+// Finds the resource by its name in any language, ignoring case. Returns sql.ErrNoRows if no stored name matches
+func (s *sqliteDatabase) FindLocalizedName(ctx context.Context, resource string, localizedName string) (string, error) {
+	var name string
+	err := s.db.QueryRowContext(ctx, `
+	SELECT name FROM localized_names WHERE resource = ? AND search_name = ? ORDER BY language = 'en' DESC, language LIMIT 1
+	`, resource, localization.SearchName(localizedName)).Scan(&name)
+	return name, err
+}
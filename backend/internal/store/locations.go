@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+)
+
+// Stores the names of every location, used for searching locations
+func (s *sqliteDatabase) AddLocations(ctx context.Context, locations []model.NamedResource) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmtLocation, _ := tx.PrepareContext(ctx, `
+	INSERT INTO locations (name, id) VALUES (?, ?)
+	ON CONFLICT (name) DO UPDATE SET id = excluded.id
+	`)
+	defer stmtLocation.Close()
+
+	for _, l := range locations {
+		if _, err := stmtLocation.ExecContext(ctx, l.Name, l.ID()); err != nil {
+			return err
+		}
+	}
+
+	if err := markFetched(ctx, tx, "location-list", "all"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the location list has not been fetched yet
+func (s *sqliteDatabase) SearchLocations(ctx context.Context, search string, limit int) ([]model.Location_summary, error) {
+	fetched, err := s.isFetched(ctx, "location-list", "all")
+	if err != nil {
+		return nil, err
+	}
+	if !fetched {
+		return nil, sql.ErrNoRows
+	}
+
+	query := `
+	SELECT id, name, COALESCE(region, '')
+	FROM locations
+	WHERE name LIKE '%' || ? || '%'
+	ORDER BY id
+	LIMIT ?
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, search, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := []model.Location_summary{}
+	for rows.Next() {
+		var location model.Location_summary
+		if err := rows.Scan(&location.ID, &location.Name, &location.Region); err != nil {
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+
+	return locations, rows.Err()
+}
+
+func (s *sqliteDatabase) AddLocation(ctx context.Context, location model.Location) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var region *string
+	if location.Region != nil {
+		region = &location.Region.Name
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO locations (name, id, region) VALUES (?, ?, ?)
+	ON CONFLICT (name) DO UPDATE SET id = excluded.id, region = excluded.region
+	`, location.Name, location.ID, region)
+	if err != nil {
+		return err
+	}
+
+	stmtArea, _ := tx.PrepareContext(ctx, `
+	INSERT INTO location_areas (name, id, location) VALUES (?, ?, ?)
+	ON CONFLICT (name) DO UPDATE SET id = excluded.id, location = excluded.location
+	`)
+	defer stmtArea.Close()
+
+	for _, a := range location.Areas {
+		if _, err := stmtArea.ExecContext(ctx, a.Name, a.ID(), location.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := markFetched(ctx, tx, "location", location.Name); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns the location with the names of its areas, sql.ErrNoRows if the location has not been fetched yet
+func (s *sqliteDatabase) GetLocation(ctx context.Context, name string) (model.Location_details, error) {
+	query := `
+	SELECT locations.id, locations.name, COALESCE(locations.region, ''),
+	(
+		SELECT json_group_array(json_object('name', location_areas.name, 'location', location_areas.location))
+		FROM (SELECT * FROM location_areas WHERE location_areas.location = locations.name ORDER BY id) as location_areas
+	) as areas
+	FROM locations
+	JOIN fetched_resources ON fetched_resources.resource = 'location' AND fetched_resources.name = locations.name
+	WHERE locations.name = ?
+	`
+
+	var location model.Location_details
+	var areasJSON []byte
+
+	err := s.db.QueryRowContext(ctx, query, name).Scan(&location.ID, &location.Name, &location.Region, &areasJSON)
+	if err == sql.ErrNoRows {
+		return model.Location_details{}, sql.ErrNoRows
+	}
+	if err != nil {
+		return model.Location_details{}, err
+	}
+
+	if err := json.Unmarshal(areasJSON, &location.Areas); err != nil {
+		return model.Location_details{}, err
+	}
+
+	return location, nil
+}
+
+// Adds the location area and encounters of every pokemon in it. The pokemon must already exist in the database
+func (s *sqliteDatabase) AddLocationArea(ctx context.Context, area model.LocationArea) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT OR IGNORE INTO locations (name, id) VALUES (?, ?)`, area.Location.Name, area.Location.ID())
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO location_areas (name, id, location) VALUES (?, ?, ?)
+	ON CONFLICT (name) DO UPDATE SET id = excluded.id, location = excluded.location
+	`, area.Name, area.ID, area.Location.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range area.PokemonEncounters {
+		if err := insertEncounters(ctx, tx, p.Pokemon.ID(), area.Name, p.VersionDetails); err != nil {
+			return err
+		}
+	}
+
+	if err := markFetched(ctx, tx, "location-area", area.Name); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the location area has not been fetched yet.
+// An empty version returns pokemon of every version
+func (s *sqliteDatabase) GetLocationArea(ctx context.Context, name string, version string) (model.Location_area, error) {
+	query := `
+	SELECT location_areas.name, COALESCE(location_areas.location, ''),
+	(
+		SELECT json_group_array(json_object('version', by_version.version, 'pokemon', json(by_version.pokemon)))
+		FROM (
+			SELECT by_pokemon.version, json_group_array(
+				json_object(
+					'pokemon_id', by_pokemon.pokemon_id,
+					'pokemon_name', by_pokemon.name,
+					'sprite_url', by_pokemon.sprite_url,
+					'encounters', json(by_pokemon.encounters)
+				)
+			) as pokemon
+			FROM (
+				SELECT e.version, e.pokemon_id, pokemons.name, pokemons.sprite_url, json_group_array(
+					json_object(
+						'version', e.version,
+						'method', e.method,
+						'min_level', e.min_level,
+						'max_level', e.max_level,
+						'chance', e.chance,
+						'conditions', json(e.conditions)
+					)
+				) as encounters
+				FROM (SELECT * FROM pokemon_encounters ORDER BY method, min_level) as e
+				JOIN pokemons ON pokemons.id = e.pokemon_id
+				WHERE e.location_area = ? AND (? = '' OR e.version = ?)
+				GROUP BY e.version, e.pokemon_id
+				ORDER BY e.version, e.pokemon_id
+			) as by_pokemon
+			GROUP BY by_pokemon.version
+			ORDER BY by_pokemon.version
+		) as by_version
+	) as versions
+	FROM location_areas
+	JOIN fetched_resources ON fetched_resources.resource = 'location-area' AND fetched_resources.name = location_areas.name
+	WHERE location_areas.name = ?
+	`
+
+	var area model.Location_area
+	var versionsJSON []byte
+
+	err := s.db.QueryRowContext(ctx, query, name, version, version, name).Scan(&area.Name, &area.Location, &versionsJSON)
+	if err == sql.ErrNoRows {
+		return model.Location_area{}, sql.ErrNoRows
+	}
+	if err != nil {
+		return model.Location_area{}, err
+	}
+
+	if err := json.Unmarshal(versionsJSON, &area.Versions); err != nil {
+		return model.Location_area{}, err
+	}
+
+	return area, nil
+}
@@ -0,0 +1,173 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"poke-atlas/web-service/internal/cries"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/sprites"
+	"strconv"
+)
+
+// Path the front sprite of the pokemon is served at, empty if pokeapi has no sprite of it
+func spritePath(pokemon model.Pokemon) string {
+	if pokemon.Sprites.FrontDefault == "" {
+		return ""
+	}
+	return sprites.ProxyPath(pokemon.ID, sprites.FrontDefault)
+}
+
+// Stores the urls of every sprite variant of the pokemon and marks them as fetched
+func insertPokemonSprites(ctx context.Context, tx *sql.Tx, pokemonID int, pokemonSprites model.PokemonSprites) error {
+	stmt, err := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO pokemon_sprites (pokemon_id, variant, url) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for variant, url := range sprites.URLs(pokemonSprites) {
+		if _, err := stmt.ExecContext(ctx, pokemonID, variant, url); err != nil {
+			return err
+		}
+	}
+
+	return markFetched(ctx, tx, "pokemon-sprites", strconv.Itoa(pokemonID))
+}
+
+// Stores the sprites of a pokemon added before its sprite variants were kept, the pokemon must already exist in the database
+func (s *sqliteDatabase) AddPokemonSprites(ctx context.Context, pokemonID int, pokemonSprites model.PokemonSprites) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertPokemonSprites(ctx, tx, pokemonID, pokemonSprites); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteDatabase) HasPokemonSprites(ctx context.Context, pokemonID int) (bool, error) {
+	return s.isFetched(ctx, "pokemon-sprites", strconv.Itoa(pokemonID))
+}
+
+// Returns sql.ErrNoRows if the pokemon doesn't have the variant
+func (s *sqliteDatabase) GetPokemonSpriteURL(ctx context.Context, pokemonID int, variant string) (string, error) {
+	var url string
+	err := s.db.QueryRowContext(ctx, `SELECT url FROM pokemon_sprites WHERE pokemon_id = ? AND variant = ?`, pokemonID, variant).Scan(&url)
+	return url, err
+}
+
+// Returns sql.ErrNoRows if the form of the pokemon doesn't exist or doesn't have the variant
+func (s *sqliteDatabase) GetPokemonFormSpriteURL(ctx context.Context, pokemonID int, form string, variant string) (string, error) {
+	column := "sprite_url"
+	if variant == sprites.FrontShiny {
+		column = "shiny_sprite_url"
+	}
+
+	var url string
+	err := s.db.QueryRowContext(ctx, `SELECT COALESCE(`+column+`, '') FROM pokemon_forms WHERE pokemon_id = ? AND name = ?`, pokemonID, form).Scan(&url)
+	if err == nil && url == "" {
+		return "", sql.ErrNoRows
+	}
+	return url, err
+}
+
+// Returns sql.ErrNoRows if the url has not been cached yet
+func (s *sqliteDatabase) GetCachedMedia(ctx context.Context, url string) (model.Cached_media, error) {
+	var media model.Cached_media
+	err := s.db.QueryRowContext(ctx, `SELECT url, hash, content_type, size FROM media_cache WHERE url = ?`, url).Scan(
+		&media.URL,
+		&media.Hash,
+		&media.ContentType,
+		&media.Size,
+	)
+	return media, err
+}
+
+func (s *sqliteDatabase) AddCachedMedia(ctx context.Context, media model.Cached_media) error {
+	_, err := s.db.ExecContext(ctx, `
+	INSERT OR REPLACE INTO media_cache (url, hash, content_type, size) VALUES (?, ?, ?, ?)
+	`, media.URL, media.Hash, media.ContentType, media.Size)
+	return err
+}
+
+// Returns sql.ErrNoRows if the image has not been derived with the size and format yet
+func (s *sqliteDatabase) GetDerivedMedia(ctx context.Context, sourceHash string, size int, format string) (model.Cached_media, error) {
+	var media model.Cached_media
+	err := s.db.QueryRowContext(ctx, `
+	SELECT hash, content_type, byte_size FROM derived_media WHERE source_hash = ? AND size = ? AND format = ?
+	`, sourceHash, size, format).Scan(&media.Hash, &media.ContentType, &media.Size)
+	return media, err
+}
+
+func (s *sqliteDatabase) AddDerivedMedia(ctx context.Context, sourceHash string, size int, format string, media model.Cached_media) error {
+	_, err := s.db.ExecContext(ctx, `
+	INSERT OR REPLACE INTO derived_media (source_hash, size, format, hash, content_type, byte_size) VALUES (?, ?, ?, ?, ?, ?)
+	`, sourceHash, size, format, media.Hash, media.ContentType, media.Size)
+	return err
+}
+
+// Stores the urls of the cries of the pokemon and marks them as fetched
+func insertPokemonCries(ctx context.Context, tx *sql.Tx, pokemonID int, pokemonCries model.PokemonCries) error {
+	stmt, err := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO pokemon_cries (pokemon_id, variant, url) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for variant, url := range cries.URLs(pokemonCries) {
+		if _, err := stmt.ExecContext(ctx, pokemonID, variant, url); err != nil {
+			return err
+		}
+	}
+
+	return markFetched(ctx, tx, "pokemon-cries", strconv.Itoa(pokemonID))
+}
+
+// Stores the cries of a pokemon added before its cries were kept, the pokemon must already exist in the database
+func (s *sqliteDatabase) AddPokemonCries(ctx context.Context, pokemonID int, pokemonCries model.PokemonCries) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertPokemonCries(ctx, tx, pokemonID, pokemonCries); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteDatabase) HasPokemonCries(ctx context.Context, pokemonID int) (bool, error) {
+	return s.isFetched(ctx, "pokemon-cries", strconv.Itoa(pokemonID))
+}
+
+// Returns sql.ErrNoRows if the pokemon doesn't have the variant
+func (s *sqliteDatabase) GetPokemonCryURL(ctx context.Context, pokemonID int, variant string) (string, error) {
+	var url string
+	err := s.db.QueryRowContext(ctx, `SELECT url FROM pokemon_cries WHERE pokemon_id = ? AND variant = ?`, pokemonID, variant).Scan(&url)
+	return url, err
+}
+
+func (s *sqliteDatabase) GetPokemonCryVariants(ctx context.Context, pokemonID int) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT variant FROM pokemon_cries WHERE pokemon_id = ? ORDER BY variant`, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := []string{}
+	for rows.Next() {
+		var variant string
+		if err := rows.Scan(&variant); err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+
+	return variants, rows.Err()
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/sprites"
+	"testing"
+)
+
+func TestSpritePaths(t *testing.T) {
+	s := newTestDatabase(t)
+	ctx := context.Background()
+
+	addTestPokemon(t, s, 201, "unown", "psychic")
+	species := model.PokemonSpecies{ID: 201, Name: "unown", Varieties: []model.PokemonSpeciesVariety{{IsDefault: true, Pokemon: resource("pokemon", 201, "unown")}}}
+	forms := []model.PokemonForm{
+		{ID: 201, Name: "unown-a", FormName: "a", IsDefault: true, Pokemon: resource("pokemon", 201, "unown"),
+			Sprites: model.PokemonFormSprites{FrontDefault: "https://example/201.png", FrontShiny: "https://example/shiny/201.png"}},
+		{ID: 10001, Name: "unown-b", FormName: "b", FormOrder: 1, Pokemon: resource("pokemon", 201, "unown"),
+			Sprites: model.PokemonFormSprites{FrontDefault: "https://example/201-b.png"}},
+	}
+	if err := s.AddSpecies(ctx, species, forms); err != nil {
+		t.Fatal(err)
+	}
+
+	pokemon, err := s.GetPokemon(ctx, "unown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pokemon.SpriteUrl != "/api/v1/sprites/201/front-default" {
+		t.Errorf("expected the proxy path of the sprite, got %q", pokemon.SpriteUrl)
+	}
+
+	varieties, err := s.GetSpeciesVarieties(ctx, 201)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unownB := varieties.Varieties[0].Forms[1]
+	if unownB.SpriteUrl != "/api/v1/sprites/201/front-default?form=unown-b" || unownB.ShinySpriteUrl != "" {
+		t.Errorf("expected the proxy path of the form sprite and no shiny sprite, got %q and %q", unownB.SpriteUrl, unownB.ShinySpriteUrl)
+	}
+
+	// The proxy serves the original url
+	url, err := s.GetPokemonFormSpriteURL(ctx, 201, "unown-b", sprites.FrontDefault)
+	if err != nil || url != "https://example/201-b.png" {
+		t.Errorf("expected the original form sprite, got %q, %v", url, err)
+	}
+	if _, err := s.GetPokemonFormSpriteURL(ctx, 201, "unown-b", sprites.FrontShiny); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows for a missing variant, got %v", err)
+	}
+}
+
+func TestMigrateSpritePaths(t *testing.T) {
+	s := newTestDatabase(t)
+	ctx := context.Background()
+
+	addTestPokemon(t, s, 25, "pikachu", "electric")
+	rockStar := model.Pokemon{ID: 10093, Name: "pikachu-rock-star", Species: resource("pokemon-species", 25, "pikachu"),
+		Types: []model.PokemonType{{Slot: 1, Type: resource("type", 1, "electric")}}}
+	if err := s.AddPokemon(ctx, rockStar); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.db.Exec(`UPDATE pokemons SET sprite_url = 'https://example/25.png' WHERE id = 25`); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.migrateSpritePaths(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, expected := range map[string]string{
+		"pikachu": "/api/v1/sprites/25/front-default",
+		// Pokemon without a sprite keep having none
+		"pikachu-rock-star": "",
+	} {
+		pokemon, err := s.GetPokemon(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pokemon.SpriteUrl != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, pokemon.SpriteUrl)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"poke-atlas/web-service/internal/model"
+)
+
+func (s *sqliteDatabase) AddMove(ctx context.Context, move model.Move) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Effects are stored in english only
+	var shortEffect string
+	for _, e := range move.EffectEntries {
+		if e.Language.Name == "en" {
+			shortEffect = e.ShortEffect
+			break
+		}
+	}
+
+	var critRate int
+	var minHits, maxHits *int
+	if move.Meta != nil {
+		critRate, minHits, maxHits = move.Meta.CritRate, move.Meta.MinHits, move.Meta.MaxHits
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO moves (name) VALUES (?)`, move.Name); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO types (name) VALUES (?)`, move.Type.Name); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT OR REPLACE INTO move_details (move_name, id, type, damage_class, target, power, accuracy, pp, priority, crit_rate, min_hits, max_hits, short_effect)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, move.Name, move.ID, move.Type.Name, move.DamageClass.Name, move.Target.Name, move.Power, move.Accuracy, move.PP, move.Priority, critRate, minHits, maxHits, shortEffect)
+	if err != nil {
+		return err
+	}
+
+	if err := insertLocalizedNames(ctx, tx, "move", move.Name, move.Names); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the move has not been fetched yet
+func (s *sqliteDatabase) GetMove(ctx context.Context, name string) (model.Move_details, error) {
+	query := `
+	SELECT id, move_name, type, damage_class, target, COALESCE(power, 0), COALESCE(accuracy, 0), COALESCE(pp, 0), priority,
+	crit_rate, COALESCE(min_hits, 0), COALESCE(max_hits, 0), short_effect
+	FROM move_details
+	WHERE move_name = ?
+	`
+
+	var m model.Move_details
+	err := s.db.QueryRowContext(ctx, query, name).Scan(
+		&m.ID, &m.Name, &m.Type, &m.DamageClass, &m.Target, &m.Power, &m.Accuracy, &m.PP, &m.Priority,
+		&m.CritRate, &m.MinHits, &m.MaxHits, &m.ShortEffect,
+	)
+
+	return m, err
+}
+
+func (s *sqliteDatabase) AddTypes(ctx context.Context, types []model.Type) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmtType, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO types (name) VALUES (?)`)
+	defer stmtType.Close()
+	stmtEfficacy, _ := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO type_efficacy (attacking_type, defending_type, damage_factor) VALUES (?, ?, ?)`)
+	defer stmtEfficacy.Close()
+
+	// Every type has to exist before the matchups referencing it
+	for _, t := range types {
+		if _, err := stmtType.ExecContext(ctx, t.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range types {
+		relations := []struct {
+			defending []model.NamedResource
+			factor    int
+		}{
+			{t.DamageRelations.NoDamageTo, 0},
+			{t.DamageRelations.HalfDamageTo, 50},
+			{t.DamageRelations.DoubleDamageTo, 200},
+		}
+
+		for _, relation := range relations {
+			for _, defending := range relation.defending {
+				if _, err := stmtType.ExecContext(ctx, defending.Name); err != nil {
+					return err
+				}
+				if _, err := stmtEfficacy.ExecContext(ctx, t.Name, defending.Name, relation.factor); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, t := range types {
+		if err := insertLocalizedNames(ctx, tx, "type", t.Name, t.Names); err != nil {
+			return err
+		}
+	}
+
+	if err := markFetched(ctx, tx, "type-list", "all"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the types have not been fetched yet
+func (s *sqliteDatabase) GetTypeChart(ctx context.Context) (model.Type_chart, error) {
+	fetched, err := s.isFetched(ctx, "type-list", "all")
+	if err != nil {
+		return nil, err
+	}
+	if !fetched {
+		return nil, sql.ErrNoRows
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT attacking_type, defending_type, damage_factor FROM type_efficacy`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chart := model.Type_chart{}
+	for rows.Next() {
+		var attacking, defending string
+		var factor int
+		if err := rows.Scan(&attacking, &defending, &factor); err != nil {
+			return nil, err
+		}
+		if chart[attacking] == nil {
+			chart[attacking] = map[string]int{}
+		}
+		chart[attacking][defending] = factor
+	}
+
+	return chart, rows.Err()
+}
+
+func (s *sqliteDatabase) GetPokemonTypes(ctx context.Context, pokemonID int) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT type_name FROM pokemon_types WHERE pokemon_id = ? ORDER BY slot`, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	types := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		types = append(types, name)
+	}
+
+	return types, rows.Err()
+}
@@ -0,0 +1,177 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+)
+
+func (s *sqliteDatabase) AddNatures(ctx context.Context, natures []model.Nature) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmtStat, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO stats (name) VALUES (?)`)
+	defer stmtStat.Close()
+	stmtNature, _ := tx.PrepareContext(ctx, `
+	INSERT OR IGNORE INTO natures (name, id, increased_stat, decreased_stat, likes_flavor, hates_flavor) VALUES (?, ?, ?, ?, ?, ?)
+	`)
+	defer stmtNature.Close()
+
+	for _, n := range natures {
+		for _, stat := range []*model.NamedResource{n.IncreasedStat, n.DecreasedStat} {
+			if stat == nil {
+				continue
+			}
+			if _, err := stmtStat.ExecContext(ctx, stat.Name); err != nil {
+				return err
+			}
+		}
+
+		_, err := stmtNature.ExecContext(ctx, n.Name, n.ID, resourceName(n.IncreasedStat), resourceName(n.DecreasedStat), resourceName(n.LikesFlavor), resourceName(n.HatesFlavor))
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := markFetched(ctx, tx, "nature-list", "all"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the natures have not been fetched yet
+func (s *sqliteDatabase) GetNatures(ctx context.Context) ([]model.Nature_details, error) {
+	fetched, err := s.isFetched(ctx, "nature-list", "all")
+	if err != nil {
+		return nil, err
+	}
+	if !fetched {
+		return nil, sql.ErrNoRows
+	}
+
+	query := `
+	SELECT id, name, COALESCE(increased_stat, ''), COALESCE(decreased_stat, ''), COALESCE(likes_flavor, ''), COALESCE(hates_flavor, '')
+	FROM natures
+	ORDER BY id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	natures := []model.Nature_details{}
+	for rows.Next() {
+		var n model.Nature_details
+		if err := rows.Scan(&n.ID, &n.Name, &n.IncreasedStat, &n.DecreasedStat, &n.LikesFlavor, &n.HatesFlavor); err != nil {
+			return nil, err
+		}
+		natures = append(natures, n)
+	}
+
+	return natures, rows.Err()
+}
+
+func (s *sqliteDatabase) AddCharacteristics(ctx context.Context, characteristics []model.Characteristic) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmtStat, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO stats (name) VALUES (?)`)
+	defer stmtStat.Close()
+	stmtCharacteristic, _ := tx.PrepareContext(ctx, `
+	INSERT OR IGNORE INTO characteristics (id, highest_stat, gene_modulo, possible_values, description) VALUES (?, ?, ?, ?, ?)
+	`)
+	defer stmtCharacteristic.Close()
+
+	for _, c := range characteristics {
+		// Descriptions are stored in english only
+		var description string
+		for _, d := range c.Descriptions {
+			if d.Language.Name == "en" {
+				description = d.Description
+				break
+			}
+		}
+
+		possibleValues, _ := json.Marshal(c.PossibleValues)
+
+		if _, err := stmtStat.ExecContext(ctx, c.HighestStat.Name); err != nil {
+			return err
+		}
+		if _, err := stmtCharacteristic.ExecContext(ctx, c.ID, c.HighestStat.Name, c.GeneModulo, string(possibleValues), description); err != nil {
+			return err
+		}
+	}
+
+	if err := markFetched(ctx, tx, "characteristic-list", "all"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the characteristics have not been fetched yet
+func (s *sqliteDatabase) GetCharacteristics(ctx context.Context) ([]model.Characteristic_details, error) {
+	fetched, err := s.isFetched(ctx, "characteristic-list", "all")
+	if err != nil {
+		return nil, err
+	}
+	if !fetched {
+		return nil, sql.ErrNoRows
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, highest_stat, gene_modulo, possible_values, description FROM characteristics ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	characteristics := []model.Characteristic_details{}
+	for rows.Next() {
+		var c model.Characteristic_details
+		var possibleValuesJSON []byte
+		if err := rows.Scan(&c.ID, &c.HighestStat, &c.GeneModulo, &possibleValuesJSON, &c.Description); err != nil {
+			return nil, err
+		}
+		json.Unmarshal(possibleValuesJSON, &c.PossibleValues)
+		characteristics = append(characteristics, c)
+	}
+
+	return characteristics, rows.Err()
+}
+
+// Returns the name and base stats of the pokemon, sql.ErrNoRows if the pokemon is not in the database
+func (s *sqliteDatabase) GetBaseStats(ctx context.Context, pokemonID int) (string, model.Stat_spread, error) {
+	var name string
+	err := s.db.QueryRowContext(ctx, `SELECT name FROM pokemons WHERE id = ?`, pokemonID).Scan(&name)
+	if err != nil {
+		return "", model.Stat_spread{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT stat_name, base_stat FROM pokemon_stats WHERE pokemon_id = ?`, pokemonID)
+	if err != nil {
+		return "", model.Stat_spread{}, err
+	}
+	defer rows.Close()
+
+	var base model.Stat_spread
+	for rows.Next() {
+		var stat string
+		var value int
+		if err := rows.Scan(&stat, &value); err != nil {
+			return "", model.Stat_spread{}, err
+		}
+		base.Set(stat, value)
+	}
+
+	return name, base, rows.Err()
+}
@@ -0,0 +1,141 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+)
+
+func (s *sqliteDatabase) AddPokedexes(ctx context.Context, pokedexes []model.Pokedex) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmtPokedex, _ := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO pokedexes (name, id, region, is_main_series) VALUES (?, ?, ?, ?)`)
+	defer stmtPokedex.Close()
+	stmtVersionGroup, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO version_groups (version_name) VALUES (?)`)
+	defer stmtVersionGroup.Close()
+	stmtPokedexVersionGroup, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO pokedex_version_groups (pokedex_name, version_group) VALUES (?, ?)`)
+	defer stmtPokedexVersionGroup.Close()
+	stmtEntry, _ := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO pokedex_entries (pokedex_name, entry_number, species_id, species_name) VALUES (?, ?, ?, ?)`)
+	defer stmtEntry.Close()
+
+	for _, p := range pokedexes {
+		if _, err := stmtPokedex.ExecContext(ctx, p.Name, p.ID, resourceName(p.Region), p.IsMainSeries); err != nil {
+			return err
+		}
+
+		for _, versionGroup := range p.VersionGroups {
+			if _, err := stmtVersionGroup.ExecContext(ctx, versionGroup.Name); err != nil {
+				return err
+			}
+			if _, err := stmtPokedexVersionGroup.ExecContext(ctx, p.Name, versionGroup.Name); err != nil {
+				return err
+			}
+		}
+
+		for _, entry := range p.PokemonEntries {
+			if _, err := stmtEntry.ExecContext(ctx, p.Name, entry.EntryNumber, entry.PokemonSpecies.ID(), entry.PokemonSpecies.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := markFetched(ctx, tx, "pokedex-list", "all"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Whether the pokedexes and their entries have been fetched from pokeapi
+func (s *sqliteDatabase) HasPokedexes(ctx context.Context) (bool, error) {
+	return s.isFetched(ctx, "pokedex-list", "all")
+}
+
+func (s *sqliteDatabase) HasPokedex(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM pokedexes WHERE name = ?)`, name).Scan(&exists)
+	return exists, err
+}
+
+// An empty version group returns every pokedex
+func (s *sqliteDatabase) GetPokedexes(ctx context.Context, versionGroup string) ([]model.Pokedex_summary, error) {
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT pokedexes.name, COALESCE(pokedexes.region, ''), pokedexes.is_main_series,
+	(
+		SELECT json_group_array(version_group)
+		FROM (SELECT version_group FROM pokedex_version_groups WHERE pokedex_name = pokedexes.name ORDER BY version_group)
+	),
+	(SELECT COUNT(*) FROM pokedex_entries WHERE pokedex_name = pokedexes.name)
+	FROM pokedexes
+	WHERE ? = '' OR pokedexes.name IN (SELECT pokedex_name FROM pokedex_version_groups WHERE version_group = ?)
+	ORDER BY pokedexes.id
+	`, versionGroup, versionGroup)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pokedexes := []model.Pokedex_summary{}
+	for rows.Next() {
+		var p model.Pokedex_summary
+		var versionGroupsJSON []byte
+		if err := rows.Scan(&p.Name, &p.Region, &p.IsMainSeries, &versionGroupsJSON, &p.EntryCount); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(versionGroupsJSON, &p.VersionGroups); err != nil {
+			return nil, err
+		}
+		pokedexes = append(pokedexes, p)
+	}
+
+	return pokedexes, rows.Err()
+}
+
+// Returns a page of the entries of the pokedex, sql.ErrNoRows if the pokedex doesn't exist.
+// Sprites and types are empty for species whose default pokemon is not stored yet
+func (s *sqliteDatabase) GetPokedexEntries(ctx context.Context, name string, offset int, limit int) (model.Pokedex_entries, error) {
+	page := model.Pokedex_entries{Pokedex: name, Offset: offset, Limit: limit, Entries: []model.Pokedex_entry{}}
+
+	err := s.db.QueryRowContext(ctx, `
+	SELECT COALESCE(region, ''), (SELECT COUNT(*) FROM pokedex_entries WHERE pokedex_name = pokedexes.name)
+	FROM pokedexes WHERE name = ?
+	`, name).Scan(&page.Region, &page.Total)
+	if err != nil {
+		return model.Pokedex_entries{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT pe.entry_number, pe.species_id, pe.species_name, COALESCE(pokemons.sprite_url, ''),
+	(
+		SELECT json_group_array(type_name)
+		FROM (SELECT type_name FROM pokemon_types WHERE pokemon_id = pe.species_id ORDER BY slot)
+	)
+	FROM pokedex_entries AS pe
+	LEFT JOIN pokemons ON pokemons.id = pe.species_id
+	WHERE pe.pokedex_name = ?
+	ORDER BY pe.entry_number
+	LIMIT ? OFFSET ?
+	`, name, limit, offset)
+	if err != nil {
+		return model.Pokedex_entries{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry model.Pokedex_entry
+		var typesJSON []byte
+		if err := rows.Scan(&entry.EntryNumber, &entry.PokemonID, &entry.Name, &entry.SpriteUrl, &typesJSON); err != nil {
+			return model.Pokedex_entries{}, err
+		}
+		if err := json.Unmarshal(typesJSON, &entry.Types); err != nil {
+			return model.Pokedex_entries{}, err
+		}
+		page.Entries = append(page.Entries, entry)
+	}
+
+	return page, rows.Err()
+}
@@ -0,0 +1,196 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+)
+
+// Species id of the pokemons row, varieties like raichu-alola count as their species
+const pokemonSpeciesColumn = `COALESCE((SELECT species_id FROM pokemon_varieties WHERE pokemon_varieties.pokemon_id = pokemons.id), pokemons.id)`
+
+// The version must already exist in the database
+func (s *sqliteDatabase) CreateSave(ctx context.Context, save model.Save) (int, error) {
+	result, err := s.db.ExecContext(ctx, `INSERT INTO saves (trainer_name, version) VALUES (?, ?)`, save.TrainerName, save.Version)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	return int(id), err
+}
+
+// Returns sql.ErrNoRows if the save doesn't exist
+func (s *sqliteDatabase) GetSave(ctx context.Context, id int) (model.Save, error) {
+	var save model.Save
+	err := s.db.QueryRowContext(ctx, `SELECT id, trainer_name, version, created_at FROM saves WHERE id = ?`, id).Scan(
+		&save.ID,
+		&save.TrainerName,
+		&save.Version,
+		&save.CreatedAt,
+	)
+	return save, err
+}
+
+func (s *sqliteDatabase) GetSaves(ctx context.Context) ([]model.Save, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, trainer_name, version, created_at FROM saves ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	saves := []model.Save{}
+	for rows.Next() {
+		var save model.Save
+		if err := rows.Scan(&save.ID, &save.TrainerName, &save.Version, &save.CreatedAt); err != nil {
+			return nil, err
+		}
+		saves = append(saves, save)
+	}
+
+	return saves, rows.Err()
+}
+
+// Returns sql.ErrNoRows if the save doesn't exist
+func (s *sqliteDatabase) DeleteSave(ctx context.Context, id int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM save_pokemon WHERE save_id = ?`, id); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM saves WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if deleted, err := result.RowsAffected(); err != nil {
+		return err
+	} else if deleted == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
+
+// The species must already exist in the database
+func (s *sqliteDatabase) SetSavePokemon(ctx context.Context, saveID int, speciesID int, status model.Save_pokemon_status) error {
+	_, err := s.db.ExecContext(ctx, `
+	INSERT INTO save_pokemon (save_id, species_id, seen, caught, shiny) VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT DO UPDATE SET seen = excluded.seen, caught = excluded.caught, shiny = excluded.shiny, updated_at = CURRENT_TIMESTAMP
+	`, saveID, speciesID, status.Seen, status.Caught, status.Shiny)
+	return err
+}
+
+const saveEntryQuery = `
+SELECT sp.species_id, species.name, COALESCE(pokemons.sprite_url, ''), sp.seen, sp.caught, sp.shiny, sp.updated_at
+FROM save_pokemon AS sp
+JOIN species ON species.id = sp.species_id
+LEFT JOIN pokemons ON pokemons.id = sp.species_id
+`
+
+// Species of the save that are at least seen, ordered by national dex number
+func (s *sqliteDatabase) GetSaveEntries(ctx context.Context, saveID int) ([]model.Save_entry, error) {
+	rows, err := s.db.QueryContext(ctx, saveEntryQuery+`WHERE sp.save_id = ? AND sp.seen ORDER BY sp.species_id`, saveID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []model.Save_entry{}
+	for rows.Next() {
+		entry, err := scanSaveEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// Returns sql.ErrNoRows if the species has never been marked in the save
+func (s *sqliteDatabase) GetSaveEntry(ctx context.Context, saveID int, speciesID int) (model.Save_entry, error) {
+	return scanSaveEntry(s.db.QueryRowContext(ctx, saveEntryQuery+`WHERE sp.save_id = ? AND sp.species_id = ?`, saveID, speciesID))
+}
+
+func scanSaveEntry(row interface{ Scan(dest ...any) error }) (model.Save_entry, error) {
+	var entry model.Save_entry
+	err := row.Scan(&entry.SpeciesID, &entry.SpeciesName, &entry.SpriteUrl, &entry.Seen, &entry.Caught, &entry.Shiny, &entry.UpdatedAt)
+	return entry, err
+}
+
+// Counts the species of every generation seen and caught in the save, an empty generation counts all of them.
+// A pokedex limits the count to the species of its entries
+func (s *sqliteDatabase) GetSaveCompletion(ctx context.Context, saveID int, generation string, pokedex string) ([]model.Completion, error) {
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT generations.name, COUNT(*), COALESCE(SUM(sp.seen), 0), COALESCE(SUM(sp.caught), 0), COALESCE(SUM(sp.shiny), 0)
+	FROM generations
+	JOIN generation_species AS gs ON gs.generation_id = generations.id
+	LEFT JOIN save_pokemon AS sp ON sp.save_id = ? AND sp.species_id = gs.species_id
+	WHERE (? = '' OR generations.name = ?)
+	AND (? = '' OR gs.species_id IN (SELECT species_id FROM pokedex_entries WHERE pokedex_name = ?))
+	GROUP BY generations.id
+	ORDER BY generations.id
+	`, saveID, generation, generation, pokedex, pokedex)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	completions := []model.Completion{}
+	for rows.Next() {
+		var c model.Completion
+		if err := rows.Scan(&c.Generation, &c.Total, &c.Seen, &c.Caught, &c.Shiny); err != nil {
+			return nil, err
+		}
+		completions = append(completions, c)
+	}
+
+	return completions, rows.Err()
+}
+
+// Pokemon with stored encounters in the version whose species isn't caught in the save.
+// An empty generation doesn't filter by generation
+func (s *sqliteDatabase) GetObtainablePokemon(ctx context.Context, saveID int, version string, generation string) ([]model.Obtainable_pokemon, error) {
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT pokemons.id, pokemons.name, COALESCE(pokemons.sprite_url, ''), COALESCE(sp.seen, 0),
+	json_group_array(DISTINCT pokemon_encounters.location_area)
+	FROM pokemon_encounters
+	JOIN pokemons ON pokemons.id = pokemon_encounters.pokemon_id
+	LEFT JOIN save_pokemon AS sp ON sp.save_id = ? AND sp.species_id = `+pokemonSpeciesColumn+`
+	WHERE pokemon_encounters.version = ? AND COALESCE(sp.caught, 0) = 0
+	AND (? = '' OR `+pokemonSpeciesColumn+` IN (
+		SELECT species_id FROM generation_species
+		JOIN generations ON generations.id = generation_species.generation_id
+		WHERE generations.name = ?
+	))
+	GROUP BY pokemons.id
+	ORDER BY pokemons.id
+	`, saveID, version, generation, generation)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	obtainable := []model.Obtainable_pokemon{}
+	for rows.Next() {
+		var p model.Obtainable_pokemon
+		var areasJSON []byte
+		if err := rows.Scan(&p.PokemonID, &p.PokemonName, &p.SpriteUrl, &p.Seen, &areasJSON); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(areasJSON, &p.LocationAreas); err != nil {
+			return nil, err
+		}
+		slices.Sort(p.LocationAreas)
+		obtainable = append(obtainable, p)
+	}
+
+	return obtainable, rows.Err()
+}
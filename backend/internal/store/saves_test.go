@@ -0,0 +1,219 @@
+package store
+
+import (
+	"context"
+	"poke-atlas/web-service/internal/model"
+	"slices"
+	"testing"
+)
+
+func walkEncounter(area string, version string) model.LocationAreaEncounter {
+	return model.LocationAreaEncounter{
+		LocationArea: model.NamedResource{Name: area},
+		VersionDetails: []model.VersionEncounterDetail{{
+			Version:          model.NamedResource{Name: version},
+			MaxChance:        10,
+			EncounterDetails: []model.Encounter{{MinLevel: 3, MaxLevel: 5, Chance: 10, Method: model.NamedResource{Name: "walk"}}},
+		}},
+	}
+}
+
+// Save of red with bulbasaur caught, pikachu seen, raichu caught shiny and rowlet seen.
+// Generation i has bulbasaur, pikachu and raichu, generation vii has rowlet and yungoos
+func newTestSave(t *testing.T) (*sqliteDatabase, int) {
+	t.Helper()
+	s := newTestDatabase(t)
+	ctx := context.Background()
+
+	addTestPokemon(t, s, 1, "bulbasaur", "grass", "poison")
+	addTestPokemon(t, s, 25, "pikachu", "electric")
+	addTestPokemon(t, s, 26, "raichu", "electric")
+	addTestPokemon(t, s, 722, "rowlet", "grass", "flying")
+	addTestPokemon(t, s, 734, "yungoos", "normal")
+	if err := s.AddPokemon(ctx, model.Pokemon{ID: 10100, Name: "raichu-alola", Species: resource("pokemon-species", 26, "raichu")}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, species := range []model.PokemonSpecies{
+		{ID: 1, Name: "bulbasaur", Varieties: []model.PokemonSpeciesVariety{{IsDefault: true, Pokemon: resource("pokemon", 1, "bulbasaur")}}},
+		{ID: 25, Name: "pikachu", Varieties: []model.PokemonSpeciesVariety{{IsDefault: true, Pokemon: resource("pokemon", 25, "pikachu")}}},
+		{ID: 26, Name: "raichu", Varieties: []model.PokemonSpeciesVariety{
+			{IsDefault: true, Pokemon: resource("pokemon", 26, "raichu")},
+			{Pokemon: resource("pokemon", 10100, "raichu-alola")},
+		}},
+		{ID: 722, Name: "rowlet", Varieties: []model.PokemonSpeciesVariety{{IsDefault: true, Pokemon: resource("pokemon", 722, "rowlet")}}},
+	} {
+		if err := s.AddSpecies(ctx, species, nil); err != nil {
+			t.Fatalf("adding species %s: %v", species.Name, err)
+		}
+	}
+
+	err := s.AddGenerations(ctx, []model.Generation{
+		{ID: 1, Name: "generation-i", PokemonSpecies: []model.NamedResource{
+			resource("pokemon-species", 1, "bulbasaur"),
+			resource("pokemon-species", 25, "pikachu"),
+			resource("pokemon-species", 26, "raichu"),
+		}},
+		{ID: 7, Name: "generation-vii", PokemonSpecies: []model.NamedResource{
+			resource("pokemon-species", 722, "rowlet"),
+			resource("pokemon-species", 734, "yungoos"),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.AddVersionGroups(ctx, []model.VersionGroup{
+		{ID: 1, Name: "red-blue", Order: 1, Generation: resource("generation", 1, "generation-i"),
+			Versions: []model.NamedResource{resource("version", 1, "red"), resource("version", 2, "blue")}},
+		{ID: 17, Name: "sun-moon", Order: 18, Generation: resource("generation", 7, "generation-vii"),
+			Versions: []model.NamedResource{resource("version", 27, "sun"), resource("version", 28, "moon")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.AddPokedexes(ctx, []model.Pokedex{
+		{ID: 2, Name: "kanto", IsMainSeries: true, PokemonEntries: []model.PokedexEntry{
+			{EntryNumber: 1, PokemonSpecies: resource("pokemon-species", 1, "bulbasaur")},
+			{EntryNumber: 25, PokemonSpecies: resource("pokemon-species", 25, "pikachu")},
+		}},
+		{ID: 16, Name: "original-alola", IsMainSeries: true, PokemonEntries: []model.PokedexEntry{
+			{EntryNumber: 1, PokemonSpecies: resource("pokemon-species", 722, "rowlet")},
+			{EntryNumber: 26, PokemonSpecies: resource("pokemon-species", 26, "raichu")},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saveID, err := s.CreateSave(ctx, model.Save{TrainerName: "red", Version: "red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for speciesID, status := range map[int]model.Save_pokemon_status{
+		1:   {Seen: true, Caught: true},
+		25:  {Seen: true},
+		26:  {Seen: true, Caught: true, Shiny: true},
+		722: {Seen: true},
+	} {
+		if err := s.SetSavePokemon(ctx, saveID, speciesID, status); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s, saveID
+}
+
+func TestGetSaveCompletion(t *testing.T) {
+	s, saveID := newTestSave(t)
+
+	tests := []struct {
+		name       string
+		generation string
+		pokedex    string
+		expected   []model.Completion
+	}{
+		{"national", "", "", []model.Completion{
+			{Generation: "generation-i", Total: 3, Seen: 3, Caught: 2, Shiny: 1},
+			{Generation: "generation-vii", Total: 2, Seen: 1},
+		}},
+		{"generation", "generation-vii", "", []model.Completion{
+			{Generation: "generation-vii", Total: 2, Seen: 1},
+		}},
+		{"pokedex", "", "kanto", []model.Completion{
+			{Generation: "generation-i", Total: 2, Seen: 2, Caught: 1},
+		}},
+		{"pokedex across generations", "", "original-alola", []model.Completion{
+			{Generation: "generation-i", Total: 1, Seen: 1, Caught: 1, Shiny: 1},
+			{Generation: "generation-vii", Total: 1, Seen: 1},
+		}},
+		{"generation and pokedex", "generation-vii", "original-alola", []model.Completion{
+			{Generation: "generation-vii", Total: 1, Seen: 1},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			completions, err := s.GetSaveCompletion(context.Background(), saveID, test.generation, test.pokedex)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(completions, test.expected) {
+				t.Errorf("expected %+v, got %+v", test.expected, completions)
+			}
+		})
+	}
+}
+
+func TestGetSaveCompletionOfEmptySave(t *testing.T) {
+	s, _ := newTestSave(t)
+	ctx := context.Background()
+
+	saveID, err := s.CreateSave(ctx, model.Save{TrainerName: "blue", Version: "blue"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	completions, err := s.GetSaveCompletion(ctx, saveID, "generation-i", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []model.Completion{{Generation: "generation-i", Total: 3}}
+	if !slices.Equal(completions, expected) {
+		t.Errorf("expected %+v, got %+v", expected, completions)
+	}
+}
+
+func TestGetObtainablePokemon(t *testing.T) {
+	s, saveID := newTestSave(t)
+	ctx := context.Background()
+
+	encounters := map[int][]model.LocationAreaEncounter{
+		1:     {walkEncounter("pallet-town-area", "red")},
+		25:    {walkEncounter("viridian-forest-area", "red"), walkEncounter("power-plant-area", "red"), walkEncounter("alola-route-1-area", "sun")},
+		10100: {walkEncounter("alola-route-1-area", "sun")},
+		734:   {walkEncounter("alola-route-1-area", "sun")},
+	}
+	for pokemonID, areas := range encounters {
+		if err := s.AddPokemonEncounters(ctx, pokemonID, areas); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		version    string
+		generation string
+		expected   []model.Obtainable_pokemon
+	}{
+		// Caught bulbasaur is left out
+		{"caught species", "red", "", []model.Obtainable_pokemon{
+			{PokemonID: 25, PokemonName: "pikachu", SpriteUrl: "/api/v1/sprites/25/front-default", Seen: true, LocationAreas: []string{"power-plant-area", "viridian-forest-area"}},
+		}},
+		// Raichu-alola counts as the caught raichu species
+		{"caught variety", "sun", "", []model.Obtainable_pokemon{
+			{PokemonID: 25, PokemonName: "pikachu", SpriteUrl: "/api/v1/sprites/25/front-default", Seen: true, LocationAreas: []string{"alola-route-1-area"}},
+			{PokemonID: 734, PokemonName: "yungoos", SpriteUrl: "/api/v1/sprites/734/front-default", LocationAreas: []string{"alola-route-1-area"}},
+		}},
+		{"generation", "sun", "generation-vii", []model.Obtainable_pokemon{
+			{PokemonID: 734, PokemonName: "yungoos", SpriteUrl: "/api/v1/sprites/734/front-default", LocationAreas: []string{"alola-route-1-area"}},
+		}},
+		{"no encounters", "blue", "", []model.Obtainable_pokemon{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obtainable, err := s.GetObtainablePokemon(ctx, saveID, test.version, test.generation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.EqualFunc(obtainable, test.expected, func(a, b model.Obtainable_pokemon) bool {
+				return a.PokemonID == b.PokemonID && a.PokemonName == b.PokemonName && a.SpriteUrl == b.SpriteUrl &&
+					a.Seen == b.Seen && slices.Equal(a.LocationAreas, b.LocationAreas)
+			}) {
+				t.Errorf("expected %+v, got %+v", test.expected, obtainable)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/sprites"
+	"strconv"
+)
+
+// Subquery returning the species ID of the pokemon ID given as two parameters.
+// Falls back to the pokemon ID for pokemon stored before their species was known
+const speciesIDQuery = `COALESCE((SELECT species_id FROM pokemon_varieties WHERE pokemon_varieties.pokemon_id = ?), ?)`
+
+func (s *sqliteDatabase) GetPokemonSpeciesID(ctx context.Context, pokemonID int) (int, error) {
+	var speciesID int
+	err := s.db.QueryRowContext(ctx, `SELECT `+speciesIDQuery, pokemonID, pokemonID).Scan(&speciesID)
+	return speciesID, err
+}
+
+// Stores the names of the forms of the pokemon and marks them as fetched
+func insertPokemonFormNames(ctx context.Context, tx *sql.Tx, pokemonID int, forms []model.NamedResource) error {
+	stmt, err := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO pokemon_form_names (pokemon_id, form_name) VALUES (?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, form := range forms {
+		if _, err := stmt.ExecContext(ctx, pokemonID, form.Name); err != nil {
+			return err
+		}
+	}
+
+	return markFetched(ctx, tx, "pokemon-form-names", strconv.Itoa(pokemonID))
+}
+
+// Stores the form names of a pokemon added before they were kept, the pokemon must already exist in the database
+func (s *sqliteDatabase) AddPokemonFormNames(ctx context.Context, pokemonID int, forms []model.NamedResource) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertPokemonFormNames(ctx, tx, pokemonID, forms); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteDatabase) HasPokemonFormNames(ctx context.Context, pokemonID int) (bool, error) {
+	return s.isFetched(ctx, "pokemon-form-names", strconv.Itoa(pokemonID))
+}
+
+func (s *sqliteDatabase) GetPokemonFormNames(ctx context.Context, pokemonID int) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT form_name FROM pokemon_form_names WHERE pokemon_id = ? ORDER BY form_name`, pokemonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+// Adds the species with the forms of its varieties. The variety pokemon must already exist in the database
+func (s *sqliteDatabase) AddSpecies(ctx context.Context, species model.PokemonSpecies, forms []model.PokemonForm) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO species (id, name, generation, evolution_chain_id, is_baby, is_legendary, is_mythical) VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (id) DO UPDATE SET
+		generation = excluded.generation,
+		evolution_chain_id = excluded.evolution_chain_id,
+		is_baby = excluded.is_baby,
+		is_legendary = excluded.is_legendary,
+		is_mythical = excluded.is_mythical
+	`, species.ID, species.Name, species.Generation.Name, species.EvolutionChain.ID(), species.IsBaby, species.IsLegendary, species.IsMythical)
+	if err != nil {
+		return err
+	}
+
+	stmtVariety, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO pokemon_varieties (pokemon_id, species_id, is_default) VALUES (?, ?, ?)`)
+	defer stmtVariety.Close()
+
+	for _, v := range species.Varieties {
+		if _, err := stmtVariety.ExecContext(ctx, v.Pokemon.ID(), species.ID, v.IsDefault); err != nil {
+			return err
+		}
+	}
+
+	stmtForm, _ := tx.PrepareContext(ctx, `
+	INSERT OR IGNORE INTO pokemon_forms (id, name, pokemon_id, form_name, form_order, is_default, is_battle_only, is_mega, sprite_url, shiny_sprite_url, version_group)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	defer stmtForm.Close()
+
+	for _, f := range forms {
+		_, err := stmtForm.ExecContext(ctx,
+			f.ID, f.Name, f.Pokemon.ID(), f.FormName, f.FormOrder, f.IsDefault, f.IsBattleOnly, f.IsMega,
+			f.Sprites.FrontDefault, f.Sprites.FrontShiny, f.VersionGroup.Name,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := insertSpeciesFlavorText(ctx, tx, species.ID, species.FlavorTextEntries); err != nil {
+		return err
+	}
+
+	if err := insertLocalizedNames(ctx, tx, "pokemon-species", strconv.Itoa(species.ID), species.Names); err != nil {
+		return err
+	}
+
+	if err := markFetched(ctx, tx, "species", strconv.Itoa(species.ID)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the species has not been fetched yet
+func (s *sqliteDatabase) GetSpeciesVarieties(ctx context.Context, speciesID int) (model.Species_varieties, error) {
+	query := `
+	SELECT species.id, species.name,
+	(
+		SELECT json_group_array(
+			json_object(
+				'pokemon_id', pokemons.id,
+				'name', pokemons.name,
+				'is_default', json(IIF(pv.is_default, 'true', 'false')),
+				'sprite_url', pokemons.sprite_url,
+				'types', (
+					SELECT json_group_array(type_name)
+					FROM (SELECT type_name FROM pokemon_types WHERE pokemon_types.pokemon_id = pokemons.id ORDER BY slot)
+				),
+				'forms', (
+					SELECT json_group_array(
+						json_object(
+							'form_id', pf.id,
+							'pokemon_id', pf.pokemon_id,
+							'name', pf.name,
+							'form_name', pf.form_name,
+							'is_default', json(IIF(pf.is_default, 'true', 'false')),
+							'is_battle_only', json(IIF(pf.is_battle_only, 'true', 'false')),
+							'is_mega', json(IIF(pf.is_mega, 'true', 'false')),
+							'sprite_url', pf.sprite_url,
+							'shiny_sprite_url', pf.shiny_sprite_url
+						)
+					)
+					FROM (SELECT * FROM pokemon_forms WHERE pokemon_forms.pokemon_id = pokemons.id ORDER BY form_order, id) as pf
+				)
+			)
+		)
+		FROM (SELECT * FROM pokemon_varieties WHERE pokemon_varieties.species_id = species.id ORDER BY is_default DESC, pokemon_id) as pv
+		JOIN pokemons ON pokemons.id = pv.pokemon_id
+	) as varieties
+	FROM species
+	JOIN fetched_resources ON fetched_resources.resource = 'species' AND fetched_resources.name = CAST(species.id AS TEXT)
+	WHERE species.id = ?
+	`
+
+	var species model.Species_varieties
+	var varietiesJSON []byte
+
+	err := s.db.QueryRowContext(ctx, query, speciesID).Scan(&species.SpeciesID, &species.Name, &varietiesJSON)
+	if err == sql.ErrNoRows {
+		return model.Species_varieties{}, sql.ErrNoRows
+	}
+	if err != nil {
+		return model.Species_varieties{}, err
+	}
+
+	if err := json.Unmarshal(varietiesJSON, &species.Varieties); err != nil {
+		return model.Species_varieties{}, err
+	}
+
+	for i := range species.Varieties {
+		variety := &species.Varieties[i]
+		variety.Category = model.FormDefault
+
+		for j := range variety.Forms {
+			form := &variety.Forms[j]
+			form.Category = model.FormCategory(*form, variety.IsDefault)
+			form.SpriteUrl = formSpritePath(*form, form.SpriteUrl, sprites.FrontDefault)
+			form.ShinySpriteUrl = formSpritePath(*form, form.ShinySpriteUrl, sprites.FrontShiny)
+			if form.IsDefault {
+				variety.Category = form.Category
+			}
+		}
+	}
+
+	return species, nil
+}
+
+// Path the sprite of the form is served at, the original url is kept in pokemon_forms as the source of the sprite proxy
+func formSpritePath(form model.Pokemon_form, url string, variant string) string {
+	if url == "" {
+		return ""
+	}
+	return sprites.FormProxyPath(form.PokemonID, form.Name, variant)
+}
+
+// Stores the pokedex entries of the species and marks them as fetched
+func insertSpeciesFlavorText(ctx context.Context, tx *sql.Tx, speciesID int, flavorTexts []model.FlavorText) error {
+	stmtVersion, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO versions (name) VALUES (?)`)
+	defer stmtVersion.Close()
+	stmtFlavorText, _ := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO species_flavor_text (species_id, version, language, flavor_text) VALUES (?, ?, ?, ?)`)
+	defer stmtFlavorText.Close()
+
+	for _, f := range flavorTexts {
+		if _, err := stmtVersion.ExecContext(ctx, f.Version.Name); err != nil {
+			return err
+		}
+		if _, err := stmtFlavorText.ExecContext(ctx, speciesID, f.Version.Name, f.Language.Name, f.FlavorText); err != nil {
+			return err
+		}
+	}
+
+	return markFetched(ctx, tx, "species-flavor-text", strconv.Itoa(speciesID))
+}
+
+// Stores the flavor text of a species added before its flavor text was kept, the species must already exist in the database
+func (s *sqliteDatabase) AddSpeciesFlavorText(ctx context.Context, speciesID int, flavorTexts []model.FlavorText) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertSpeciesFlavorText(ctx, tx, speciesID, flavorTexts); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteDatabase) HasSpeciesFlavorText(ctx context.Context, speciesID int) (bool, error) {
+	return s.isFetched(ctx, "species-flavor-text", strconv.Itoa(speciesID))
+}
+
+// Returns the flavor text of every version the species has been in, sql.ErrNoRows if its flavor text has not been fetched yet.
+// Empty version and language don't filter
+func (s *sqliteDatabase) GetSpeciesFlavorText(ctx context.Context, speciesID int, version string, language string) ([]model.Flavor_text_entry, error) {
+	fetched, err := s.HasSpeciesFlavorText(ctx, speciesID)
+	if err != nil {
+		return nil, err
+	}
+	if !fetched {
+		return nil, sql.ErrNoRows
+	}
+
+	// Versions are ordered by release when the games have been fetched
+	rows, err := s.db.QueryContext(ctx, `
+	SELECT flavor_text, language, json_group_array(version)
+	FROM (
+		SELECT ft.flavor_text, ft.language, ft.version, COALESCE(version_info.id, 0) AS version_order
+		FROM species_flavor_text AS ft
+		LEFT JOIN version_info ON version_info.version = ft.version
+		WHERE ft.species_id = ? AND (? = '' OR ft.version = ?) AND (? = '' OR ft.language = ?)
+		ORDER BY version_order, ft.version
+	)
+	GROUP BY language, flavor_text
+	ORDER BY language, MIN(version_order), MIN(version)
+	`, speciesID, version, version, language, language)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []model.Flavor_text_entry{}
+	for rows.Next() {
+		var entry model.Flavor_text_entry
+		var versionsJSON []byte
+		if err := rows.Scan(&entry.Text, &entry.Language, &versionsJSON); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(versionsJSON, &entry.Versions); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/sprites"
 	"strconv"
 	"strings"
 
@@ -18,7 +19,8 @@ type sqliteDatabase struct {
 }
 
 func CreateSqliteDatabase() *sqliteDatabase {
-	db, err := sql.Open("sqlite3", "./pokedb.db")
+	// Concurrent writers wait for each other for up to 5 seconds instead of failing with "database is locked"
+	db, err := sql.Open("sqlite3", "./pokedb.db?_busy_timeout=5000")
 
 	if err != nil {
 		log.Fatal("Failed to open database", err.Error())
@@ -42,7 +44,18 @@ func (s *sqliteDatabase) AddPokemon(ctx context.Context, pokemon model.Pokemon)
 	// basic pokemon information and sprite
 	query := `INSERT OR IGNORE INTO pokemons (id, name, height, weight, sprite_url) VALUES (?, ?, ?, ?, ?)`
 
-	_, err = tx.ExecContext(ctx, query, pokemon.ID, pokemon.Name, pokemon.Height, pokemon.Weight, pokemon.Sprites.FrontDefault)
+	_, err = tx.ExecContext(ctx, query, pokemon.ID, pokemon.Name, pokemon.Height, pokemon.Weight, spritePath(pokemon))
+	if err != nil {
+		return err
+	}
+
+	// species and pokemon_varieties, species ID always matches the ID of its default pokemon
+	_, err = tx.ExecContext(ctx, `INSERT OR IGNORE INTO species (id, name) VALUES (?, ?)`, pokemon.Species.ID(), pokemon.Species.Name)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT OR IGNORE INTO pokemon_varieties (pokemon_id, species_id, is_default) VALUES (?, ?, ?)`, pokemon.ID, pokemon.Species.ID(), pokemon.IsDefault)
 	if err != nil {
 		return err
 	}
@@ -109,6 +122,22 @@ func (s *sqliteDatabase) AddPokemon(ctx context.Context, pokemon model.Pokemon)
 		}
 	}
 
+	if err := insertPokemonSprites(ctx, tx, pokemon.ID, pokemon.Sprites); err != nil {
+		return err
+	}
+	if err := insertPokemonCries(ctx, tx, pokemon.ID, pokemon.Cries); err != nil {
+		return err
+	}
+	if err := insertPokemonFormNames(ctx, tx, pokemon.ID, pokemon.Forms); err != nil {
+		return err
+	}
+	if err := insertPokemonHeldItems(ctx, tx, pokemon.ID, pokemon.HeldItems); err != nil {
+		return err
+	}
+	if err := insertPokemonGameIndices(ctx, tx, pokemon.ID, pokemon.GameIndices); err != nil {
+		return err
+	}
+
 	// pokemon stats
 
 	stmtStats, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO stats (name) VALUES (?)`)
@@ -128,22 +157,71 @@ func (s *sqliteDatabase) AddPokemon(ctx context.Context, pokemon model.Pokemon)
 	return tx.Commit()
 }
 
+func (s *sqliteDatabase) HasPokemon(ctx context.Context, id int) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM pokemons WHERE id = ?)`, id).Scan(&exists)
+	return exists, err
+}
+
+const pokemonSummaryQuery = `
+SELECT pokemons.id, pokemons.name, pokemons.weight, pokemons.height, pokemons.sprite_url, json_group_array(pokemon_types.type_name)
+FROM pokemons
+JOIN pokemon_types ON pokemon_types.pokemon_id = pokemons.id
+`
+
 // Return a brief summary of pokemon for now
 func (s *sqliteDatabase) GetPokemon(ctx context.Context, name string) (model.Pokemon_summary, error) {
-	query := `
-	SELECT pokemons.id, pokemons.name, pokemons.weight, pokemons.height, pokemons.sprite_url, json_group_array(pokemon_types.type_name)
-	FROM pokemons
-	JOIN pokemon_types ON pokemon_types.pokemon_id = pokemons.id
-	WHERE pokemons.name = ?
-	GROUP BY pokemons.id, pokemons.name, pokemons.weight, pokemons.height
-	`
+	pokemon, err := scanPokemonSummary(s.db.QueryRowContext(ctx, pokemonSummaryQuery+`WHERE pokemons.name = ? GROUP BY pokemons.id`, name))
+	if err == sql.ErrNoRows {
+		return model.Pokemon_summary{}, fmt.Errorf("pokemon not found")
+	}
+	return pokemon, err
+}
+
+// Returns sql.ErrNoRows if the pokemon is not in the database
+func (s *sqliteDatabase) GetPokemonByID(ctx context.Context, id int) (model.Pokemon_summary, error) {
+	return scanPokemonSummary(s.db.QueryRowContext(ctx, pokemonSummaryQuery+`WHERE pokemons.id = ? GROUP BY pokemons.id`, id))
+}
+
+// Stored pokemon matching any of the ids or names in one query, in no particular order
+func (s *sqliteDatabase) GetPokemonsByRefs(ctx context.Context, ids []int, names []string) ([]model.Pokemon_summary, error) {
+	idsJSON, err := json.Marshal(ids)
+	if err != nil {
+		return nil, err
+	}
+	namesJSON, err := json.Marshal(names)
+	if err != nil {
+		return nil, err
+	}
 
+	rows, err := s.db.QueryContext(ctx, pokemonSummaryQuery+`
+	WHERE pokemons.id IN (SELECT value FROM json_each(?)) OR pokemons.name IN (SELECT value FROM json_each(?))
+	GROUP BY pokemons.id
+	`, string(idsJSON), string(namesJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pokemons := []model.Pokemon_summary{}
+	for rows.Next() {
+		pokemon, err := scanPokemonSummary(rows)
+		if err != nil {
+			return nil, err
+		}
+		pokemons = append(pokemons, pokemon)
+	}
+
+	return pokemons, rows.Err()
+}
+
+func scanPokemonSummary(row interface{ Scan(dest ...any) error }) (model.Pokemon_summary, error) {
 	var pokemon model.Pokemon_summary
 
 	// Temporary variable to store types in for unmarshaling
 	var typesJSON []byte
 
-	err := s.db.QueryRowContext(ctx, query, name).Scan(
+	err := row.Scan(
 		&pokemon.ID,
 		&pokemon.Name,
 		&pokemon.Weight,
@@ -151,10 +229,6 @@ func (s *sqliteDatabase) GetPokemon(ctx context.Context, name string) (model.Pok
 		&pokemon.SpriteUrl,
 		&typesJSON,
 	)
-
-	if err == sql.ErrNoRows {
-		return model.Pokemon_summary{}, fmt.Errorf("pokemon not found")
-	}
 	if err != nil {
 		return model.Pokemon_summary{}, err
 	}
@@ -215,38 +289,12 @@ func (s *sqliteDatabase) GetPokemons(ctx context.Context, offset int, limit int)
 // TODO: GetPokemonDetailed [name,id,height,weight,abilities,moves,evolution chain,games?]
 func (s *sqliteDatabase) GetPokemonDetailed(ctx context.Context, id int) (model.Pokemon_details, error) {
 	query := `
-		WITH RECURSIVE full_chain AS (
-            -- Start from the queried pokemon and go backwards to find the root
-            SELECT pokemon_id, evolves_to_id, min_level, trigger_name, 0 as depth
-            FROM evolution_chains
-            WHERE evolves_to_id = ?
-            
-            UNION ALL
-            
-            -- Keep going backwards
-            SELECT ec.pokemon_id, ec.evolves_to_id, ec.min_level, ec.trigger_name, fc.depth - 1
-            FROM evolution_chains ec
-            INNER JOIN full_chain fc ON ec.evolves_to_id = fc.pokemon_id
-        ),
-        root_pokemon AS (
-            -- Find the root (pokemon with no prior evolution)
-            SELECT COALESCE(
-                (SELECT pokemon_id FROM full_chain ORDER BY depth LIMIT 1),
-                ?
-            ) as root_id
-        ),
-        complete_chain AS (
-            -- Now traverse forward from the root to get all evolutions
-            SELECT ec.pokemon_id, ec.evolves_to_id, ec.min_level, ec.trigger_name
-            FROM evolution_chains ec, root_pokemon rp
-            WHERE ec.pokemon_id = rp.root_id
-            
-            UNION ALL
-            
-            -- Recursively get all forward evolutions
+		WITH complete_chain AS (
+            -- Every evolution of the chain the queried pokemon belongs to
             SELECT ec.pokemon_id, ec.evolves_to_id, ec.min_level, ec.trigger_name
             FROM evolution_chains ec
-            INNER JOIN complete_chain cc ON ec.pokemon_id = cc.evolves_to_id
+            JOIN evolution_chain_members ecm ON ecm.pokemon_id = ec.pokemon_id
+            WHERE ecm.chain_id = (SELECT chain_id FROM evolution_chain_members WHERE pokemon_id = ` + speciesIDQuery + `)
         )
         SELECT 
         pokemons.id,
@@ -279,19 +327,46 @@ func (s *sqliteDatabase) GetPokemonDetailed(ctx context.Context, id int) (model.
                     'evolves_to_id', cc.evolves_to_id,
                     'evolves_to_name', p2.name,
                     'min_level', COALESCE(cc.min_level, 0),
-                    'trigger_name', cc.trigger_name
+                    'trigger_name', cc.trigger_name,
+                    'details', (` + evolutionDetailsQuery("cc") + `)
                 )
             )
             FROM complete_chain cc
             JOIN pokemons p1 ON cc.pokemon_id = p1.id
             JOIN pokemons p2 ON cc.evolves_to_id = p2.id
-        ) as evolution_chain
+        ) as evolution_chain,
+        (
+            SELECT json_group_array(
+                json_object(
+                    'item', held.item_name,
+                    'sprite_url', COALESCE(items.sprite_url, ''),
+                    'versions', json(held.versions)
+                )
+            )
+            FROM (
+                SELECT item_name, json_group_array(json_object('version', version, 'rarity', rarity)) as versions
+                FROM (SELECT * FROM pokemon_held_items WHERE pokemon_held_items.pokemon_id = pokemons.id ORDER BY version)
+                GROUP BY item_name
+                ORDER BY item_name
+            ) as held
+            JOIN items ON items.name = held.item_name
+        ) as held_items,
+        (
+            SELECT json_group_array(json_object('version', indices.version, 'game_index', indices.game_index))
+            FROM (
+                SELECT pokemon_game_indices.version, pokemon_game_indices.game_index
+                FROM pokemon_game_indices
+                LEFT JOIN version_info ON version_info.version = pokemon_game_indices.version
+                WHERE pokemon_game_indices.pokemon_id = pokemons.id
+                ORDER BY version_info.id, pokemon_game_indices.version
+            ) as indices
+        ) as game_indices
         FROM pokemons
         WHERE pokemons.id = ?
 	`
 
 	var pokemon model.Pokemon_details
-	var statsJSON, typesJSON, evolutionJSON []byte
+	var statsJSON, typesJSON, evolutionJSON, heldItemsJSON, gameIndicesJSON []byte
 
 	err := s.db.QueryRowContext(ctx, query, id, id, id).Scan(
 		&pokemon.ID,
@@ -302,6 +377,8 @@ func (s *sqliteDatabase) GetPokemonDetailed(ctx context.Context, id int) (model.
 		&statsJSON,
 		&typesJSON,
 		&evolutionJSON,
+		&heldItemsJSON,
+		&gameIndicesJSON,
 	)
 	if err == sql.ErrNoRows {
 		return model.Pokemon_details{}, sql.ErrNoRows
@@ -313,6 +390,12 @@ func (s *sqliteDatabase) GetPokemonDetailed(ctx context.Context, id int) (model.
 	json.Unmarshal(statsJSON, &pokemon.Stats)
 	json.Unmarshal(typesJSON, &pokemon.Types)
 	json.Unmarshal(evolutionJSON, &pokemon.EvolutionChain)
+	json.Unmarshal(heldItemsJSON, &pokemon.HeldItems)
+	json.Unmarshal(gameIndicesJSON, &pokemon.GameIndices)
+
+	for i := range pokemon.EvolutionChain {
+		pokemon.EvolutionChain[i].Summary = model.EvolutionSummary(pokemon.EvolutionChain[i].Details)
+	}
 
 	//log.Println("pokemon found: ", pokemon)
 	return pokemon, nil
@@ -337,12 +420,43 @@ func (s *sqliteDatabase) AddEvolutionChain(ctx context.Context, chain model.Evol
 	}
 	defer stmtChain.Close()
 
+	stmtDetail, err := tx.PrepareContext(ctx, `
+	INSERT OR IGNORE INTO evolution_details (
+		pokemon_id, evolves_to_id, detail_index, trigger_name, item, held_item, gender, known_move, known_move_type,
+		location, min_level, min_happiness, min_beauty, min_affection, needs_multiplayer, needs_overworld_rain,
+		party_species, party_type, relative_physical_stats, time_of_day, trade_species, turn_upside_down,
+		used_move, min_move_count, min_steps, min_damage_taken
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmtDetail.Close()
+
+	_, err = tx.ExecContext(ctx, `INSERT OR IGNORE INTO evolution_chain_info (chain_id, baby_trigger_item) VALUES (?, ?)`, chain.Id, resourceName(chain.BabyTriggerItem))
+	if err != nil {
+		return err
+	}
+
+	stmtMember, err := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO evolution_chain_members (pokemon_id, chain_id, is_baby) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmtMember.Close()
+
 	// Recursive function to process chain links
 	var processChainLink func(link model.ChainLink) error
 	processChainLink = func(link model.ChainLink) error {
 		// Get pokemon ID from species URL (extract ID from URL like ".../pokemon-species/21/")
 		fromID := extractIDFromURL(link.Species.URL)
 
+		if _, err := stmtMember.ExecContext(ctx, fromID, chain.Id, link.IsBaby); err != nil {
+			return err
+		}
+		if err := markFetched(ctx, tx, "evolution-details", strconv.Itoa(fromID)); err != nil {
+			return err
+		}
+
 		// Process each evolution
 		for _, evolvesTo := range link.EvolvesTo {
 			toID := extractIDFromURL(evolvesTo.Species.URL)
@@ -363,6 +477,20 @@ func (s *sqliteDatabase) AddEvolutionChain(ctx context.Context, chain model.Evol
 				return err
 			}
 
+			// Insert every alternative evolution condition of the link
+			for i, d := range evolvesTo.EvolutionDetails {
+				_, err := stmtDetail.ExecContext(ctx,
+					fromID, toID, i, d.Trigger.Name, resourceName(d.Item), resourceName(d.HeldItem), d.Gender,
+					resourceName(d.KnownMove), resourceName(d.KnownMoveType), resourceName(d.Location),
+					d.MinLevel, d.MinHappiness, d.MinBeauty, d.MinAffection, d.NeedsMultiplayer, d.NeedsOverworldRain,
+					resourceName(d.PartySpecies), resourceName(d.PartyType), d.RelativePhysicalStats, nullString(d.TimeOfDay),
+					resourceName(d.TradeSpecies), d.TurnUpsideDown, resourceName(d.UsedMove), d.MinMoveCount, d.MinSteps, d.MinDamageTaken,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
 			// Recursively process next evolution stage
 			if err := processChainLink(evolvesTo); err != nil {
 				return err
@@ -380,6 +508,12 @@ func (s *sqliteDatabase) AddEvolutionChain(ctx context.Context, chain model.Evol
 	return tx.Commit()
 }
 
+// Whether the chain of the species has been stored with every evolution condition,
+// chains stored before the conditions were kept only have the first one
+func (s *sqliteDatabase) HasEvolutionDetails(ctx context.Context, speciesID int) (bool, error) {
+	return s.isFetched(ctx, "evolution-details", strconv.Itoa(speciesID))
+}
+
 // TODO: Effect entries for moves?
 // Ability descriptions?
 
@@ -394,6 +528,63 @@ func (s *sqliteDatabase) InitDB() error {
 	sprite_url
 	);
 
+	CREATE TABLE IF NOT EXISTS species (
+	id INTEGER PRIMARY KEY,
+	name TEXT UNIQUE NOT NULL,
+	generation TEXT,
+	evolution_chain_id INTEGER,
+	is_baby INTEGER CHECK (is_baby IN (0, 1)),
+	is_legendary INTEGER CHECK (is_legendary IN (0, 1)),
+	is_mythical INTEGER CHECK (is_mythical IN (0, 1))
+	);
+
+	-- Pokemon of a species, e.g. raichu and raichu-alola are varieties of the raichu species
+	CREATE TABLE IF NOT EXISTS pokemon_varieties (
+	pokemon_id INTEGER PRIMARY KEY,
+	species_id INTEGER NOT NULL,
+	is_default INTEGER CHECK (is_default IN (0, 1)),
+
+	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id),
+	FOREIGN KEY (species_id) REFERENCES species(id)
+	);
+
+	CREATE TABLE IF NOT EXISTS pokemon_forms (
+	id INTEGER PRIMARY KEY,
+	name TEXT UNIQUE NOT NULL,
+	pokemon_id INTEGER NOT NULL,
+	form_name TEXT,
+	form_order INTEGER,
+	is_default INTEGER CHECK (is_default IN (0, 1)),
+	is_battle_only INTEGER CHECK (is_battle_only IN (0, 1)),
+	is_mega INTEGER CHECK (is_mega IN (0, 1)),
+	sprite_url TEXT,
+	shiny_sprite_url TEXT,
+	version_group TEXT,
+
+	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id)
+	);
+
+	-- Forms are only listed by their pokemon, the forms themselves are stored with the species
+	CREATE TABLE IF NOT EXISTS pokemon_form_names (
+	pokemon_id INTEGER NOT NULL,
+	form_name TEXT NOT NULL,
+
+	PRIMARY KEY (pokemon_id, form_name),
+	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id)
+	);
+
+	-- Identical texts of different versions are merged when reading
+	CREATE TABLE IF NOT EXISTS species_flavor_text (
+	species_id INTEGER NOT NULL,
+	version TEXT NOT NULL,
+	language TEXT NOT NULL,
+	flavor_text TEXT NOT NULL,
+
+	PRIMARY KEY (species_id, version, language),
+	FOREIGN KEY (species_id) REFERENCES species(id),
+	FOREIGN KEY (version) REFERENCES versions(name)
+	);
+
 	CREATE TABLE IF NOT EXISTS types (
 	name TEXT PRIMARY KEY
 	);
@@ -437,10 +628,64 @@ func (s *sqliteDatabase) InitDB() error {
 	FOREIGN KEY (stat_name) REFERENCES stats(name)
 	);
 
+	-- Neutral natures have no increased or decreased stat
+	CREATE TABLE IF NOT EXISTS natures (
+	name TEXT PRIMARY KEY,
+	id INTEGER,
+	increased_stat TEXT,
+	decreased_stat TEXT,
+	likes_flavor TEXT,
+	hates_flavor TEXT,
+
+	FOREIGN KEY (increased_stat) REFERENCES stats(name),
+	FOREIGN KEY (decreased_stat) REFERENCES stats(name)
+	);
+
+	CREATE TABLE IF NOT EXISTS characteristics (
+	id INTEGER PRIMARY KEY,
+	highest_stat TEXT NOT NULL,
+	gene_modulo INTEGER,
+	possible_values TEXT,
+	description TEXT,
+
+	FOREIGN KEY (highest_stat) REFERENCES stats(name)
+	);
+
 	CREATE TABLE IF NOT EXISTS moves (
 	name TEXT PRIMARY KEY
 	);
 
+	-- Filled when a move is fetched on its own, moves learned by pokemon only have a name
+	CREATE TABLE IF NOT EXISTS move_details (
+	move_name TEXT PRIMARY KEY,
+	id INTEGER,
+	type TEXT,
+	damage_class TEXT,
+	target TEXT,
+	power INTEGER,
+	accuracy INTEGER,
+	pp INTEGER,
+	priority INTEGER,
+	crit_rate INTEGER,
+	min_hits INTEGER,
+	max_hits INTEGER,
+	short_effect TEXT,
+
+	FOREIGN KEY (move_name) REFERENCES moves(name),
+	FOREIGN KEY (type) REFERENCES types(name)
+	);
+
+	-- Damage factor in percent, only matchups other than 100 are stored
+	CREATE TABLE IF NOT EXISTS type_efficacy (
+	attacking_type TEXT NOT NULL,
+	defending_type TEXT NOT NULL,
+	damage_factor INTEGER NOT NULL,
+
+	PRIMARY KEY (attacking_type, defending_type),
+	FOREIGN KEY (attacking_type) REFERENCES types(name),
+	FOREIGN KEY (defending_type) REFERENCES types(name)
+	);
+
 	CREATE TABLE IF NOT EXISTS move_learn_methods (
 	learn_method TEXT PRIMARY KEY
 	);
@@ -474,17 +719,411 @@ func (s *sqliteDatabase) InitDB() error {
 	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id),
 	FOREIGN KEY (evolves_to_id) REFERENCES pokemons(id)
 	);
+
+	CREATE TABLE IF NOT EXISTS evolution_chain_info (
+	chain_id INTEGER PRIMARY KEY,
+	baby_trigger_item TEXT
+	);
+
+	-- Every pokemon of an evolution chain, including pokemon that don't evolve at all
+	CREATE TABLE IF NOT EXISTS evolution_chain_members (
+	pokemon_id INTEGER PRIMARY KEY,
+	chain_id INTEGER NOT NULL,
+	is_baby INTEGER CHECK (is_baby IN (0, 1)),
+
+	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id),
+	FOREIGN KEY (chain_id) REFERENCES evolution_chain_info(chain_id)
+	);
+
+	-- Every evolution detail of an evolution_chains edge, detail_index keeps pokeapi's order
+	CREATE TABLE IF NOT EXISTS evolution_details (
+	pokemon_id INTEGER NOT NULL,
+	evolves_to_id INTEGER NOT NULL,
+	detail_index INTEGER NOT NULL,
+	trigger_name TEXT,
+	item TEXT,
+	held_item TEXT,
+	gender INTEGER,
+	known_move TEXT,
+	known_move_type TEXT,
+	location TEXT,
+	min_level INTEGER,
+	min_happiness INTEGER,
+	min_beauty INTEGER,
+	min_affection INTEGER,
+	needs_multiplayer INTEGER CHECK (needs_multiplayer IN (0, 1)),
+	needs_overworld_rain INTEGER CHECK (needs_overworld_rain IN (0, 1)),
+	party_species TEXT,
+	party_type TEXT,
+	relative_physical_stats INTEGER,
+	time_of_day TEXT,
+	trade_species TEXT,
+	turn_upside_down INTEGER CHECK (turn_upside_down IN (0, 1)),
+	used_move TEXT,
+	min_move_count INTEGER,
+	min_steps INTEGER,
+	min_damage_taken INTEGER,
+
+	PRIMARY KEY (pokemon_id, evolves_to_id, detail_index),
+	FOREIGN KEY (pokemon_id, evolves_to_id) REFERENCES evolution_chains(pokemon_id, evolves_to_id)
+	);
+
+	CREATE TABLE IF NOT EXISTS locations (
+	name TEXT PRIMARY KEY,
+	id INTEGER,
+	region TEXT
+	);
+
+	CREATE TABLE IF NOT EXISTS items (
+	name TEXT PRIMARY KEY,
+	id INTEGER,
+	category TEXT,
+	cost INTEGER,
+	fling_power INTEGER,
+	fling_effect TEXT,
+	effect TEXT,
+	short_effect TEXT,
+	sprite_url TEXT
+	);
+
+	-- Items wild pokemon may hold, rarity is the percent chance per version
+	CREATE TABLE IF NOT EXISTS pokemon_held_items (
+	pokemon_id INTEGER NOT NULL,
+	item_name TEXT NOT NULL,
+	version TEXT NOT NULL,
+	rarity INTEGER,
+
+	PRIMARY KEY (pokemon_id, item_name, version),
+	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id),
+	FOREIGN KEY (item_name) REFERENCES items(name),
+	FOREIGN KEY (version) REFERENCES versions(name)
+	);
+
+	CREATE TABLE IF NOT EXISTS location_areas (
+	name TEXT PRIMARY KEY,
+	id INTEGER,
+	location TEXT,
+
+	FOREIGN KEY (location) REFERENCES locations(name)
+	);
+
+	CREATE TABLE IF NOT EXISTS versions (
+	name TEXT PRIMARY KEY
+	);
+
+	CREATE TABLE IF NOT EXISTS encounter_methods (
+	name TEXT PRIMARY KEY
+	);
+
+	CREATE TABLE IF NOT EXISTS pokemon_encounters (
+	pokemon_id INTEGER NOT NULL,
+	location_area TEXT NOT NULL,
+	version TEXT NOT NULL,
+	method TEXT NOT NULL,
+	min_level INTEGER NOT NULL,
+	max_level INTEGER NOT NULL,
+	conditions TEXT NOT NULL,
+	chance INTEGER,
+
+	PRIMARY KEY (pokemon_id, location_area, version, method, min_level, max_level, conditions),
+	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id),
+	FOREIGN KEY (location_area) REFERENCES location_areas(name),
+	FOREIGN KEY (version) REFERENCES versions(name),
+	FOREIGN KEY (method) REFERENCES encounter_methods(name)
+	);
+
+	CREATE TABLE IF NOT EXISTS teams (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	-- IVs and EVs are stored as JSON stat spreads
+	CREATE TABLE IF NOT EXISTS team_members (
+	team_id INTEGER NOT NULL,
+	slot INTEGER NOT NULL,
+	pokemon_id INTEGER NOT NULL,
+	level INTEGER NOT NULL,
+	ability TEXT,
+	item TEXT,
+	nature TEXT,
+	ivs TEXT NOT NULL,
+	evs TEXT NOT NULL,
+
+	PRIMARY KEY (team_id, slot),
+	FOREIGN KEY (team_id) REFERENCES teams(id),
+	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id)
+	);
+
+	CREATE TABLE IF NOT EXISTS team_member_moves (
+	team_id INTEGER NOT NULL,
+	slot INTEGER NOT NULL,
+	move_slot INTEGER NOT NULL,
+	move_name TEXT NOT NULL,
+
+	PRIMARY KEY (team_id, slot, move_slot),
+	FOREIGN KEY (team_id, slot) REFERENCES team_members(team_id, slot),
+	FOREIGN KEY (move_name) REFERENCES moves(name)
+	);
+
+	CREATE TABLE IF NOT EXISTS generations (
+	id INTEGER PRIMARY KEY,
+	name TEXT UNIQUE NOT NULL,
+	main_region TEXT
+	);
+
+	-- Species introduced in a generation, stored by name as the species themselves are fetched lazily
+	CREATE TABLE IF NOT EXISTS generation_species (
+	generation_id INTEGER NOT NULL,
+	species_id INTEGER NOT NULL,
+	species_name TEXT NOT NULL,
+
+	PRIMARY KEY (generation_id, species_id),
+	FOREIGN KEY (generation_id) REFERENCES generations(id)
+	);
+
+	CREATE TABLE IF NOT EXISTS generation_moves (
+	generation_id INTEGER NOT NULL,
+	move_name TEXT NOT NULL,
+
+	PRIMARY KEY (generation_id, move_name),
+	FOREIGN KEY (generation_id) REFERENCES generations(id),
+	FOREIGN KEY (move_name) REFERENCES moves(name)
+	);
+
+	CREATE TABLE IF NOT EXISTS generation_abilities (
+	generation_id INTEGER NOT NULL,
+	ability_name TEXT NOT NULL,
+
+	PRIMARY KEY (generation_id, ability_name),
+	FOREIGN KEY (generation_id) REFERENCES generations(id),
+	FOREIGN KEY (ability_name) REFERENCES abilities(name)
+	);
+
+	CREATE TABLE IF NOT EXISTS generation_types (
+	generation_id INTEGER NOT NULL,
+	type_name TEXT NOT NULL,
+
+	PRIMARY KEY (generation_id, type_name),
+	FOREIGN KEY (generation_id) REFERENCES generations(id),
+	FOREIGN KEY (type_name) REFERENCES types(name)
+	);
+
+	-- version_groups only holds the names harvested from learnsets, the rest comes from /version-group
+	CREATE TABLE IF NOT EXISTS version_group_info (
+	version_group TEXT PRIMARY KEY,
+	id INTEGER UNIQUE NOT NULL,
+	sort_order INTEGER NOT NULL,
+	generation_id INTEGER NOT NULL,
+
+	FOREIGN KEY (version_group) REFERENCES version_groups(version_name),
+	FOREIGN KEY (generation_id) REFERENCES generations(id)
+	);
+
+	CREATE TABLE IF NOT EXISTS version_info (
+	version TEXT PRIMARY KEY,
+	id INTEGER NOT NULL,
+	version_group TEXT NOT NULL,
+
+	FOREIGN KEY (version) REFERENCES versions(name),
+	FOREIGN KEY (version_group) REFERENCES version_groups(version_name)
+	);
+
+	-- Internal index of the pokemon in the games of a version, e.g. rhydon is 1 in red
+	CREATE TABLE IF NOT EXISTS pokemon_game_indices (
+	pokemon_id INTEGER NOT NULL,
+	version TEXT NOT NULL,
+	game_index INTEGER NOT NULL,
+
+	PRIMARY KEY (pokemon_id, version),
+	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id),
+	FOREIGN KEY (version) REFERENCES versions(name)
+	);
+
+	CREATE TABLE IF NOT EXISTS pokedexes (
+	name TEXT PRIMARY KEY,
+	id INTEGER UNIQUE NOT NULL,
+	region TEXT,
+	is_main_series INTEGER CHECK (is_main_series IN (0, 1))
+	);
+
+	CREATE TABLE IF NOT EXISTS pokedex_version_groups (
+	pokedex_name TEXT NOT NULL,
+	version_group TEXT NOT NULL,
+
+	PRIMARY KEY (pokedex_name, version_group),
+	FOREIGN KEY (pokedex_name) REFERENCES pokedexes(name),
+	FOREIGN KEY (version_group) REFERENCES version_groups(version_name)
+	);
+
+	-- Entry numbers are regional, e.g. pikachu is 25 in the national dex and 74 in the paldea dex
+	CREATE TABLE IF NOT EXISTS pokedex_entries (
+	pokedex_name TEXT NOT NULL,
+	entry_number INTEGER NOT NULL,
+	species_id INTEGER NOT NULL,
+	species_name TEXT NOT NULL,
+
+	PRIMARY KEY (pokedex_name, entry_number),
+	FOREIGN KEY (pokedex_name) REFERENCES pokedexes(name)
+	);
+
+	CREATE TABLE IF NOT EXISTS saves (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	trainer_name TEXT NOT NULL,
+	version TEXT NOT NULL,
+	created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+	FOREIGN KEY (version) REFERENCES versions(name)
+	);
+
+	-- Species without a row are neither seen nor caught
+	CREATE TABLE IF NOT EXISTS save_pokemon (
+	save_id INTEGER NOT NULL,
+	species_id INTEGER NOT NULL,
+	seen INTEGER NOT NULL CHECK (seen IN (0, 1)),
+	caught INTEGER NOT NULL CHECK (caught IN (0, 1)),
+	shiny INTEGER NOT NULL CHECK (shiny IN (0, 1)),
+	updated_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+	PRIMARY KEY (save_id, species_id),
+	FOREIGN KEY (save_id) REFERENCES saves(id),
+	FOREIGN KEY (species_id) REFERENCES species(id)
+	);
+
+	-- Original urls of every sprite variant, variants a pokemon doesn't have are left out
+	CREATE TABLE IF NOT EXISTS pokemon_sprites (
+	pokemon_id INTEGER NOT NULL,
+	variant TEXT NOT NULL,
+	url TEXT NOT NULL,
+
+	PRIMARY KEY (pokemon_id, variant),
+	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id)
+	);
+
+	-- Original urls of the latest and legacy cry
+	CREATE TABLE IF NOT EXISTS pokemon_cries (
+	pokemon_id INTEGER NOT NULL,
+	variant TEXT NOT NULL,
+	url TEXT NOT NULL,
+
+	PRIMARY KEY (pokemon_id, variant),
+	FOREIGN KEY (pokemon_id) REFERENCES pokemons(id)
+	);
+
+	-- Sprites and cries fetched from their original url, the files are named after their hash in the media cache
+	CREATE TABLE IF NOT EXISTS media_cache (
+	url TEXT PRIMARY KEY,
+	hash TEXT NOT NULL,
+	content_type TEXT NOT NULL,
+	size INTEGER NOT NULL,
+	fetched_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+
+	-- Resized or converted images of cached media, the result is stored in the media cache as well
+	CREATE TABLE IF NOT EXISTS derived_media (
+	source_hash TEXT NOT NULL,
+	size INTEGER NOT NULL,
+	format TEXT NOT NULL,
+	hash TEXT NOT NULL,
+	content_type TEXT NOT NULL,
+	byte_size INTEGER NOT NULL,
+
+	PRIMARY KEY (source_hash, size, format)
+	);
+
+	-- Display names of pokeapi resources per language, e.g. ('type', 'fire', 'de', 'Feuer').
+	-- Species are named by their id, search_name is the lowercase name for lookups by localized name
+	CREATE TABLE IF NOT EXISTS localized_names (
+	resource TEXT NOT NULL,
+	name TEXT NOT NULL,
+	language TEXT NOT NULL,
+	localized_name TEXT NOT NULL,
+	search_name TEXT NOT NULL,
+
+	PRIMARY KEY (resource, name, language)
+	);
+
+	CREATE INDEX IF NOT EXISTS localized_names_search ON localized_names (resource, search_name);
+
+	-- Resources that have been completely fetched from pokeapi, e.g. ('pokemon-encounters', '25').
+	-- Needed where an empty result is valid, many pokemon have no wild encounters at all
+	CREATE TABLE IF NOT EXISTS fetched_resources (
+	resource TEXT NOT NULL,
+	name TEXT NOT NULL,
+
+	PRIMARY KEY (resource, name)
+	);
 	`
 
-	_, err := s.db.Exec(query)
+	if _, err := s.db.Exec(query); err != nil {
+		return err
+	}
 
-	return err
+	return s.migrateSpritePaths()
+}
+
+// Pokemon stored before the sprite proxy point at the original sprite on github
+func (s *sqliteDatabase) migrateSpritePaths() error {
+	rows, err := s.db.Query(`SELECT id FROM pokemons WHERE sprite_url LIKE 'http%'`)
+	if err != nil {
+		return err
+	}
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := s.db.Exec(`UPDATE pokemons SET sprite_url = ? WHERE id = ?`, sprites.ProxyPath(id, sprites.FrontDefault), id); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (s *sqliteDatabase) Close() error {
 	return s.db.Close()
 }
 
+// Helper function for marking a resource as completely fetched from pokeapi
+func markFetched(ctx context.Context, tx *sql.Tx, resource string, name string) error {
+	_, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO fetched_resources (resource, name) VALUES (?, ?)`, resource, name)
+	return err
+}
+
+func (s *sqliteDatabase) isFetched(ctx context.Context, resource string, name string) (bool, error) {
+	var fetched bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM fetched_resources WHERE resource = ? AND name = ?)`, resource, name).Scan(&fetched)
+	return fetched, err
+}
+
+// Helper functions for storing optional pokeapi values as NULL
+func resourceName(resource *model.NamedResource) *string {
+	if resource == nil {
+		return nil
+	}
+	return &resource.Name
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 // Helper function for extracting pokemon ID from pokeapi url
 func extractIDFromURL(url string) int {
 	parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"poke-atlas/web-service/internal/model"
+	"testing"
+)
+
+// Fresh database with the full schema in a temporary file, closed when the test ends
+func newTestDatabase(t *testing.T) *sqliteDatabase {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "pokedb.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	database := &sqliteDatabase{db: db}
+	if err := database.InitDB(); err != nil {
+		t.Fatal(err)
+	}
+	return database
+}
+
+func resource(kind string, id int, name string) model.NamedResource {
+	return model.NamedResource{Name: name, URL: fmt.Sprintf("https://pokeapi.co/api/v2/%s/%d/", kind, id)}
+}
+
+// Stores the default pokemon of a species with the given types
+func addTestPokemon(t *testing.T, s *sqliteDatabase, id int, name string, types ...string) {
+	t.Helper()
+
+	pokemon := model.Pokemon{
+		ID:        id,
+		Name:      name,
+		IsDefault: true,
+		Species:   resource("pokemon-species", id, name),
+	}
+	pokemon.Sprites.FrontDefault = fmt.Sprintf("https://example/%d.png", id)
+	for i, typeName := range types {
+		pokemon.Types = append(pokemon.Types, model.PokemonType{Slot: i + 1, Type: resource("type", i+1, typeName)})
+	}
+
+	if err := s.AddPokemon(context.Background(), pokemon); err != nil {
+		t.Fatalf("adding %s: %v", name, err)
+	}
+}
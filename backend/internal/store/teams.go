@@ -0,0 +1,233 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"poke-atlas/web-service/internal/model"
+)
+
+// Selects teams with their members as JSON, ordered by slot
+const teamQuery = `
+SELECT teams.id, teams.name, teams.created_at, teams.updated_at,
+(
+	SELECT json_group_array(
+		json_object(
+			'pokemon_id', m.pokemon_id,
+			'pokemon_name', pokemons.name,
+			'sprite_url', COALESCE(pokemons.sprite_url, ''),
+			'level', m.level,
+			'ability', COALESCE(m.ability, ''),
+			'item', COALESCE(m.item, ''),
+			'nature', COALESCE(m.nature, ''),
+			'moves', json((
+				SELECT json_group_array(move_name)
+				FROM (
+					SELECT move_name FROM team_member_moves
+					WHERE team_member_moves.team_id = m.team_id AND team_member_moves.slot = m.slot
+					ORDER BY move_slot
+				)
+			)),
+			'ivs', json(m.ivs),
+			'evs', json(m.evs)
+		)
+	)
+	FROM (SELECT * FROM team_members WHERE team_members.team_id = teams.id ORDER BY slot) AS m
+	JOIN pokemons ON pokemons.id = m.pokemon_id
+) AS members
+FROM teams
+`
+
+// Members must be validated and their pokemon must already exist in the database
+func (s *sqliteDatabase) CreateTeam(ctx context.Context, team model.Team) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO teams (name) VALUES (?)`, team.Name)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := insertTeamMembers(ctx, tx, int(id), team.Members); err != nil {
+		return 0, err
+	}
+
+	return int(id), tx.Commit()
+}
+
+// Replaces the name and members of the team, returns sql.ErrNoRows if the team doesn't exist
+func (s *sqliteDatabase) UpdateTeam(ctx context.Context, id int, team model.Team) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE teams SET name = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, team.Name, id)
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err != nil {
+		return err
+	} else if updated == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := deleteTeamMembers(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := insertTeamMembers(ctx, tx, id, team.Members); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the team doesn't exist
+func (s *sqliteDatabase) DeleteTeam(ctx context.Context, id int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := deleteTeamMembers(ctx, tx, id); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM teams WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if deleted, err := result.RowsAffected(); err != nil {
+		return err
+	} else if deleted == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
+
+// Returns sql.ErrNoRows if the team doesn't exist
+func (s *sqliteDatabase) GetTeam(ctx context.Context, id int) (model.Team, error) {
+	return scanTeam(s.db.QueryRowContext(ctx, teamQuery+`WHERE teams.id = ?`, id))
+}
+
+func (s *sqliteDatabase) GetTeams(ctx context.Context) ([]model.Team, error) {
+	rows, err := s.db.QueryContext(ctx, teamQuery+`ORDER BY teams.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := []model.Team{}
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, rows.Err()
+}
+
+// Helper for scanning a row of teamQuery from either sql.Row or sql.Rows
+func scanTeam(row interface{ Scan(dest ...any) error }) (model.Team, error) {
+	var team model.Team
+	var membersJSON []byte
+
+	if err := row.Scan(&team.ID, &team.Name, &team.CreatedAt, &team.UpdatedAt, &membersJSON); err != nil {
+		return model.Team{}, err
+	}
+	if err := json.Unmarshal(membersJSON, &team.Members); err != nil {
+		return model.Team{}, err
+	}
+
+	return team, nil
+}
+
+func insertTeamMembers(ctx context.Context, tx *sql.Tx, teamID int, members []model.Team_member) error {
+	stmtMember, _ := tx.PrepareContext(ctx, `
+	INSERT INTO team_members (team_id, slot, pokemon_id, level, ability, item, nature, ivs, evs) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	defer stmtMember.Close()
+	stmtMove, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO moves (name) VALUES (?)`)
+	defer stmtMove.Close()
+	stmtMemberMove, _ := tx.PrepareContext(ctx, `INSERT INTO team_member_moves (team_id, slot, move_slot, move_name) VALUES (?, ?, ?, ?)`)
+	defer stmtMemberMove.Close()
+
+	for slot, m := range members {
+		ivs, _ := json.Marshal(m.IVs)
+		evs, _ := json.Marshal(m.EVs)
+
+		_, err := stmtMember.ExecContext(ctx, teamID, slot+1, m.PokemonID, m.Level, nullString(m.Ability), nullString(m.Item), nullString(m.Nature), string(ivs), string(evs))
+		if err != nil {
+			return err
+		}
+
+		for moveSlot, move := range m.Moves {
+			if _, err := stmtMove.ExecContext(ctx, move); err != nil {
+				return err
+			}
+			if _, err := stmtMemberMove.ExecContext(ctx, teamID, slot+1, moveSlot+1, move); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func deleteTeamMembers(ctx context.Context, tx *sql.Tx, teamID int) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM team_member_moves WHERE team_id = ?`, teamID); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `DELETE FROM team_members WHERE team_id = ?`, teamID)
+	return err
+}
+
+// Names of every stored pokemon, for matching imported names
+func (s *sqliteDatabase) GetPokemonNames(ctx context.Context) ([]string, error) {
+	return s.queryNames(ctx, `SELECT name FROM pokemons ORDER BY id`)
+}
+
+// Names of every known item, including items only known as held items of stored pokemon
+func (s *sqliteDatabase) GetItemNames(ctx context.Context) ([]string, error) {
+	return s.queryNames(ctx, `SELECT name FROM items ORDER BY name`)
+}
+
+// Moves the pokemon can learn in any version group
+func (s *sqliteDatabase) GetPokemonMoveNames(ctx context.Context, pokemonID int) ([]string, error) {
+	return s.queryNames(ctx, `SELECT DISTINCT move_name FROM pokemon_moves WHERE pokemon_id = ? ORDER BY move_name`, pokemonID)
+}
+
+func (s *sqliteDatabase) GetPokemonAbilityNames(ctx context.Context, pokemonID int) ([]string, error) {
+	return s.queryNames(ctx, `SELECT ability_name FROM pokemon_ability WHERE pokemon_id = ? ORDER BY ability_name`, pokemonID)
+}
+
+func (s *sqliteDatabase) queryNames(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
@@ -0,0 +1,156 @@
+package teams
+
+import (
+	"maps"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/damage"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/stats"
+	"slices"
+	"strings"
+)
+
+const (
+	MaxMembers   = 6
+	MaxMoves     = 4
+	DefaultLevel = 50
+)
+
+// A team member with the stored data the analysis needs
+type Member struct {
+	PokemonID int
+	Name      string
+	Types     []string
+	Ability   string
+	Speed     int
+	Moves     []model.Move_details
+}
+
+// Validates the team and fills in default levels and IVs
+func ValidateTeam(team *model.Team) error {
+	team.Name = strings.TrimSpace(team.Name)
+	if team.Name == "" {
+		return apierror.Validationf("team name is required")
+	}
+	if len(team.Members) > MaxMembers {
+		return apierror.Validationf("a team has at most %d members", MaxMembers)
+	}
+
+	for i := range team.Members {
+		member := &team.Members[i]
+
+		if member.PokemonID < 1 {
+			return apierror.Validationf("member %d: pokemon id is required", i+1)
+		}
+		if member.Level == 0 {
+			member.Level = DefaultLevel
+		}
+
+		statReq := StatRequest(*member)
+		if err := stats.ValidateRequest(&statReq); err != nil {
+			return apierror.Validationf("member %d: %v", i+1, err)
+		}
+		member.IVs = statReq.IVs
+
+		if len(member.Moves) > MaxMoves {
+			return apierror.Validationf("member %d: at most %d moves are allowed", i+1, MaxMoves)
+		}
+		for j, move := range member.Moves {
+			if move == "" {
+				return apierror.Validationf("member %d: move %d is empty", i+1, j+1)
+			}
+			if slices.Contains(member.Moves[:j], move) {
+				return apierror.Validationf("member %d: duplicate move %s", i+1, move)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Stat calculation of a member, teams always use the standard formula
+func StatRequest(member model.Team_member) model.Stat_calculation_request {
+	return model.Stat_calculation_request{
+		Level:   member.Level,
+		Nature:  member.Nature,
+		Formula: stats.FormulaStandard,
+		IVs:     member.IVs,
+		EVs:     member.EVs,
+	}
+}
+
+// Analyzes type matchups, move coverage and speed of the members
+func Analyze(members []Member, chart model.Type_chart) model.Team_analysis {
+	analysis := model.Team_analysis{
+		SharedWeaknesses: []string{},
+		Defense:          []model.Type_matchup{},
+		UncoveredTypes:   []string{},
+		Coverage:         []model.Type_coverage{},
+		SpeedTiers:       []model.Speed_tier{},
+	}
+
+	for _, t := range chartTypes(chart) {
+		matchup := model.Type_matchup{Type: t, Weak: []string{}, Resistant: []string{}, Immune: []string{}}
+
+		for _, member := range members {
+			switch effectiveness := damage.Effectiveness(t, member.Types, member.Ability, chart); {
+			case effectiveness == 0:
+				matchup.Immune = append(matchup.Immune, member.Name)
+			case effectiveness < 1:
+				matchup.Resistant = append(matchup.Resistant, member.Name)
+			case effectiveness > 1:
+				matchup.Weak = append(matchup.Weak, member.Name)
+			}
+		}
+
+		if len(matchup.Weak) >= 2 && len(matchup.Weak) > len(matchup.Resistant)+len(matchup.Immune) {
+			analysis.SharedWeaknesses = append(analysis.SharedWeaknesses, t)
+		}
+		analysis.Defense = append(analysis.Defense, matchup)
+
+		coverage := model.Type_coverage{Type: t, Moves: []string{}}
+		for _, member := range members {
+			for _, move := range member.Moves {
+				if move.DamageClass == model.DamageClassStatus || move.Power == 0 {
+					continue
+				}
+
+				effectiveness := damage.Effectiveness(move.Type, []string{t}, "", chart)
+				coverage.Effectiveness = max(coverage.Effectiveness, effectiveness)
+				if effectiveness > 1 {
+					coverage.Moves = append(coverage.Moves, member.Name+": "+move.Name)
+				}
+			}
+		}
+
+		if coverage.Effectiveness <= 1 {
+			analysis.UncoveredTypes = append(analysis.UncoveredTypes, t)
+		}
+		analysis.Coverage = append(analysis.Coverage, coverage)
+	}
+
+	for _, member := range members {
+		analysis.SpeedTiers = append(analysis.SpeedTiers, model.Speed_tier{
+			PokemonID:   member.PokemonID,
+			PokemonName: member.Name,
+			Speed:       member.Speed,
+		})
+	}
+	slices.SortStableFunc(analysis.SpeedTiers, func(a, b model.Speed_tier) int {
+		return b.Speed - a.Speed
+	})
+
+	return analysis
+}
+
+// Every type appearing in the chart, types without any matchups like stellar are left out
+func chartTypes(chart model.Type_chart) []string {
+	types := map[string]bool{}
+	for attacking, defending := range chart {
+		types[attacking] = true
+		for t := range defending {
+			types[t] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(types))
+}
@@ -0,0 +1,87 @@
+package teams
+
+import (
+	"errors"
+	"poke-atlas/web-service/internal/apierror"
+	"poke-atlas/web-service/internal/model"
+	"poke-atlas/web-service/internal/stats"
+	"slices"
+	"testing"
+)
+
+var chart = model.Type_chart{
+	"ground":   {"electric": 200, "fire": 200, "flying": 0, "grass": 50},
+	"water":    {"fire": 200, "ground": 200, "grass": 50, "water": 50},
+	"electric": {"water": 200, "flying": 200, "ground": 0, "electric": 50, "grass": 50},
+	"grass":    {"water": 200, "ground": 200, "fire": 50, "grass": 50, "flying": 50},
+	"fire":     {"grass": 200, "fire": 50, "water": 50},
+	"flying":   {"grass": 200, "electric": 50},
+}
+
+var surf = model.Move_details{Name: "surf", Type: "water", DamageClass: model.DamageClassSpecial, Power: 90}
+var thunderbolt = model.Move_details{Name: "thunderbolt", Type: "electric", DamageClass: model.DamageClassSpecial, Power: 90}
+var thunderWave = model.Move_details{Name: "thunder-wave", Type: "electric", DamageClass: model.DamageClassStatus}
+
+func TestAnalyze(t *testing.T) {
+	members := []Member{
+		{PokemonID: 26, Name: "raichu", Types: []string{"electric"}, Speed: 110, Moves: []model.Move_details{thunderbolt, thunderWave}},
+		{PokemonID: 135, Name: "jolteon", Types: []string{"electric"}, Speed: 200, Moves: []model.Move_details{thunderbolt}},
+		{PokemonID: 642, Name: "thundurus", Types: []string{"electric", "flying"}, Speed: 111, Moves: []model.Move_details{surf}},
+		{PokemonID: 604, Name: "eelektross", Types: []string{"electric"}, Ability: "levitate", Speed: 50},
+		{PokemonID: 25, Name: "pikachu", Types: []string{"electric"}, Speed: 90},
+	}
+
+	analysis := Analyze(members, chart)
+
+	if !slices.Equal(analysis.SharedWeaknesses, []string{"ground"}) {
+		t.Errorf("expected shared weakness ground, got %v", analysis.SharedWeaknesses)
+	}
+
+	ground := analysis.Defense[slices.IndexFunc(analysis.Defense, func(m model.Type_matchup) bool { return m.Type == "ground" })]
+	if !slices.Equal(ground.Weak, []string{"raichu", "jolteon", "pikachu"}) || !slices.Equal(ground.Immune, []string{"thundurus", "eelektross"}) {
+		t.Errorf("unexpected ground matchup %+v", ground)
+	}
+
+	if !slices.Equal(analysis.UncoveredTypes, []string{"electric", "grass"}) {
+		t.Errorf("expected electric and grass uncovered, got %v", analysis.UncoveredTypes)
+	}
+
+	var speeds []int
+	for _, tier := range analysis.SpeedTiers {
+		speeds = append(speeds, tier.Speed)
+	}
+	if !slices.Equal(speeds, []int{200, 111, 110, 90, 50}) {
+		t.Errorf("expected speed tiers sorted from fastest, got %v", speeds)
+	}
+}
+
+func TestValidateTeam(t *testing.T) {
+	team := model.Team{Name: " rain ", Members: []model.Team_member{{PokemonID: 186, Moves: []string{"surf"}}}}
+	if err := ValidateTeam(&team); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if team.Name != "rain" || team.Members[0].Level != DefaultLevel || team.Members[0].IVs.Speed != stats.MaxIV {
+		t.Errorf("expected defaults to be filled in, got %+v", team)
+	}
+
+	tests := []struct {
+		name string
+		team model.Team
+	}{
+		{"no name", model.Team{}},
+		{"too many members", model.Team{Name: "a", Members: make([]model.Team_member, 7)}},
+		{"missing pokemon", model.Team{Name: "a", Members: []model.Team_member{{}}}},
+		{"too many moves", model.Team{Name: "a", Members: []model.Team_member{{PokemonID: 1, Moves: []string{"a", "b", "c", "d", "e"}}}}},
+		{"duplicate move", model.Team{Name: "a", Members: []model.Team_member{{PokemonID: 1, Moves: []string{"surf", "surf"}}}}},
+		{"ev total too high", model.Team{Name: "a", Members: []model.Team_member{{PokemonID: 1, EVs: model.Stat_spread{HP: 252, Attack: 252, Speed: 252}}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateTeam(&test.team)
+			if !errors.As(err, &apierror.ValidationError{}) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
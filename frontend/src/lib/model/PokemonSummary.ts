@@ -5,6 +5,6 @@ export type PokemonSummary = {
     name: string;
     weight: number;
     height: number;
-    sprite_url: string;
+    sprite_url: string; // Path on the api, forwarded by routes/api/v1/sprites
     types: PokemonType[];
 }
\ No newline at end of file
@@ -0,0 +1,26 @@
+import type { RequestHandler } from './$types';
+import { env } from '$env/dynamic/private';
+
+// The api returns sprite urls as paths on the backend, forward them so the browser never talks to github
+export const GET: RequestHandler = async ({ fetch, params, url, request }) => {
+    const apiAddress = env.API_ADDRESS || 'localhost:8080';
+    const requestHeaders = new Headers();
+    const ifNoneMatch = request.headers.get('if-none-match');
+    if (ifNoneMatch) {
+        requestHeaders.set('if-none-match', ifNoneMatch);
+    }
+
+    const response = await fetch(`http://${apiAddress}/api/v1/sprites/${params.id}/${params.variant}${url.search}`, {
+        headers: requestHeaders
+    });
+
+    const headers = new Headers();
+    for (const name of ['content-type', 'cache-control', 'etag']) {
+        const value = response.headers.get(name);
+        if (value) {
+            headers.set(name, value);
+        }
+    }
+
+    return new Response(response.body, { status: response.status, headers });
+};
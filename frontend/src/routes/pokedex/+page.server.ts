@@ -16,7 +16,7 @@ export async function load({ fetch, url, setHeaders }) {
         return {}
     }
     const apiAddress = env.API_ADDRESS || 'localhost:8080';
-    const response = await fetch(`http://${apiAddress}/pokemons/${parsedInt}`);
+    const response = await fetch(`http://${apiAddress}/api/v1/pokemons/${parsedInt}`);
     const pokemon: PokemonSummary[] = await response.json();
 
     if (pokemon.length === 0) {
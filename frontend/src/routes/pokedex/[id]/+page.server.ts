@@ -15,7 +15,7 @@ export async function load({ fetch, params, setHeaders }) {
         return {}
     }
     const apiAddress = env.API_ADDRESS || 'localhost:8080';
-    const response = await fetch(`http://${apiAddress}/pokemondetailed/${parsedInt}`);
+    const response = await fetch(`http://${apiAddress}/api/v1/pokemondetailed/${parsedInt}`);
     const pokemon: PokemonDetailed = await response.json();
 
     if (pokemon == null) {